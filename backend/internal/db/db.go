@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // =============================================================================
@@ -23,6 +24,19 @@ type UserFollowing struct {
 	UserID        uuid.UUID  `db:"user_id"`
 	XAuthorID     int64      `db:"x_author_id"`
 	LastCheckedAt *time.Time `db:"last_checked_at"` // Nullable in DB
+	UnfollowedAt  *time.Time `db:"unfollowed_at"`   // Nullable in DB; set when the author was unfollowed on X
+	Pinned        bool       `db:"pinned"`          // Kept first when the user is over the following limit
+	ExcludedAt    *time.Time `db:"excluded_at"`     // Nullable in DB; set while the author is over the following limit
+}
+
+// FollowingEvent represents the following_events table (user-scoped, RLS enabled)
+type FollowingEvent struct {
+	ID         int64     `db:"id"`
+	UserID     uuid.UUID `db:"user_id"`
+	XAuthorID  int64     `db:"x_author_id"`
+	Event      string    `db:"event"`  // CHECK: 'follow', 'unfollow'
+	RunID      *string   `db:"run_id"` // Nullable in DB
+	OccurredAt time.Time `db:"occurred_at"`
 }
 
 // Post represents the posts table (user-scoped, RLS enabled)
@@ -37,9 +51,75 @@ type Post struct {
 	IngestedAt     time.Time `db:"ingested_at"`
 	FirstVisibleAt time.Time `db:"first_visible_at"`
 	EditedSeen     bool      `db:"edited_seen"`
+	PostEngagementCounts
+	MetricsUpdatedAt *time.Time `db:"metrics_updated_at"` // Nullable in DB; null until the first engagement refresh
+	RawText          *string    `db:"raw_text"`           // Nullable in DB; tweet text as returned by X
+	DeletedAt        *time.Time `db:"deleted_at"`         // Nullable in DB; set when the tweet was deleted on X
+	InReplyToID      *int64     `db:"in_reply_to_id"`     // Nullable in DB; parent tweet of a self-reply
+	Kind             string     `db:"kind"`               // One of the PostKind values
+	RepostedByID     *int64     `db:"reposted_by_id"`     // Nullable in DB; followed author who retweeted a 'retweet' post
+	QuotedPostID     *int64     `db:"quoted_post_id"`     // Nullable in DB; tweet quoted by a 'quote' post
+	Source           string     `db:"source"`             // One of the PostSource values
 	// ts field (tsvector) not included as it's internal to PostgreSQL
 }
 
+// Post kinds (posts.kind): how a post entered the user's feed
+const (
+	PostKindOriginal = "original" // Original post or self-reply of a followed author
+	PostKindReply    = "reply"    // Reply of a followed author to another author
+	PostKindQuote    = "quote"    // Quote tweet of a followed author
+	PostKindRetweet  = "retweet"  // Tweet retweeted by a followed author, stored under its original author
+	PostKindQuoted   = "quoted"   // Tweet quoted by a 'quote' post, stored under its original author
+)
+
+// Post sources (posts.source): which ingestion stage a post came from
+const (
+	PostSourceFeed    = "feed"    // Followed, listed or searched authors
+	PostSourceMention = "mention" // Mention of the user's own X account
+)
+
+// PostEngagementCounts holds a tweet's engagement metrics, shared by posts and post_engagement
+type PostEngagementCounts struct {
+	LikeCount     int64 `db:"like_count"`
+	RetweetCount  int64 `db:"retweet_count"`
+	ReplyCount    int64 `db:"reply_count"`
+	QuoteCount    int64 `db:"quote_count"`
+	ViewCount     int64 `db:"view_count"`
+	BookmarkCount int64 `db:"bookmark_count"`
+}
+
+// PostEngagement represents the post_engagement table (global, no RLS)
+// One row per engagement refresh of a tweet
+type PostEngagement struct {
+	XPostID    int64     `db:"x_post_id"`
+	CapturedAt time.Time `db:"captured_at"`
+	PostEngagementCounts
+}
+
+// PostRevision represents the post_revisions table (global, no RLS)
+// One row per detected edit of a tweet
+type PostRevision struct {
+	ID           int64     `db:"id"`
+	XPostID      int64     `db:"x_post_id"`
+	PreviousText string    `db:"previous_text"`
+	Text         string    `db:"text"`
+	DetectedAt   time.Time `db:"detected_at"`
+}
+
+// Thread represents a row of the threads view (user-scoped through posts RLS)
+// Groups a user's posts by author and conversation; PostIDs are in chronological order
+type Thread struct {
+	UserID          uuid.UUID     `db:"user_id"`
+	ConversationID  int64         `db:"conversation_id"`
+	AuthorID        int64         `db:"author_id"`
+	PostCount       int           `db:"post_count"`
+	PostIDs         pq.Int64Array `db:"post_ids"`
+	StartedAt       time.Time     `db:"started_at"`
+	LastPublishedAt time.Time     `db:"last_published_at"`
+	LastIngestedAt  time.Time     `db:"last_ingested_at"`
+	Complete        bool          `db:"complete"` // False while a self-reply's parent has not been ingested
+}
+
 // QAMessage represents the qa_messages table (user-scoped, RLS enabled)
 type QAMessage struct {
 	ID        string    `db:"id"` // ULID as string
@@ -48,6 +128,8 @@ type QAMessage struct {
 	Answer    string    `db:"answer"`
 	DateFrom  time.Time `db:"date_from"`
 	DateTo    time.Time `db:"date_to"`
+	ListID    *int64    `db:"list_id"` // Nullable in DB; list the question was scoped to
+	Source    *string   `db:"source"`  // Nullable in DB; post source the question was scoped to
 	CreatedAt time.Time `db:"created_at"`
 }
 
@@ -60,16 +142,72 @@ type QASource struct {
 
 // IngestRun represents the ingest_runs table (user-scoped, RLS enabled)
 type IngestRun struct {
+	ID                string     `db:"id"` // ULID as string
+	UserID            uuid.UUID  `db:"user_id"`
+	StartedAt         time.Time  `db:"started_at"`
+	CompletedAt       *time.Time `db:"completed_at"` // Nullable in DB
+	Status            string     `db:"status"`       // CHECK: 'ok', 'rate_limited', 'error', 'cancelled'
+	SinceID           int64      `db:"since_id"`     // Twitter post ID to fetch from
+	FetchedCount      int        `db:"fetched_count"`
+	Retried           int        `db:"retried"`
+	RateLimitHits     int        `db:"rate_limit_hits"`
+	ErrText           *string    `db:"err_text"` // Nullable in DB
+	Phase             string     `db:"phase"`    // CHECK: 'queued', 'following', 'tweets', 'done'
+	FollowingCount    int        `db:"following_count"`
+	TweetsCount       int        `db:"tweets_count"`
+	CancelRequestedAt *time.Time `db:"cancel_requested_at"` // Nullable in DB
+	HeartbeatAt       *time.Time `db:"heartbeat_at"`        // Nullable in DB; refreshed while a worker executes the run
+}
+
+// IngestRunError represents the ingest_run_errors table (user-scoped, RLS enabled)
+type IngestRunError struct {
+	ID           int64     `db:"id"`
+	RunID        string    `db:"run_id"`
+	UserID       uuid.UUID `db:"user_id"`
+	Phase        string    `db:"phase"`         // CHECK: 'following', 'tweets'
+	AuthorHandle *string   `db:"author_handle"` // Nullable in DB
+	Message      string    `db:"message"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// IngestRunDailyStats represents ingest_runs rate limiting aggregated per day
+type IngestRunDailyStats struct {
+	Day             time.Time `db:"day"`
+	Runs            int       `db:"runs"`
+	RateLimitedRuns int       `db:"rate_limited_runs"`
+	RateLimitHits   int       `db:"rate_limit_hits"`
+	Retried         int       `db:"retried"`
+}
+
+// AuthorWatermark represents the author_watermarks table (user-scoped, RLS enabled)
+// It stores the newest tweet up to which a followed author's timeline was ingested so the next run knows where to stop.
+// While a run catching up stops at the page limit, the resume fields record where the next run continues
+type AuthorWatermark struct {
+	UserID         uuid.UUID  `db:"user_id"`
+	XAuthorID      int64      `db:"x_author_id"`
+	LastTweetID    int64      `db:"last_tweet_id"`
+	LastTweetAt    time.Time  `db:"last_tweet_at"`
+	ResumeCursor   *string    `db:"resume_cursor"`    // Timeline cursor where the last run stopped before the watermark
+	PendingTweetID *int64     `db:"pending_tweet_id"` // Newest tweet fetched while catching up; becomes the watermark once caught up
+	PendingTweetAt *time.Time `db:"pending_tweet_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+// IngestJob represents the ingest_jobs table (system table, no RLS)
+type IngestJob struct {
 	ID            string     `db:"id"` // ULID as string
 	UserID        uuid.UUID  `db:"user_id"`
-	StartedAt     time.Time  `db:"started_at"`
-	CompletedAt   *time.Time `db:"completed_at"` // Nullable in DB
-	Status        string     `db:"status"`       // CHECK: 'ok', 'rate_limited', 'error'
-	SinceID       int64      `db:"since_id"`     // Twitter post ID to fetch from
-	FetchedCount  int        `db:"fetched_count"`
-	Retried       int        `db:"retried"`
-	RateLimitHits int        `db:"rate_limit_hits"`
-	ErrText       *string    `db:"err_text"` // Nullable in DB
+	RunID         *string    `db:"run_id"` // Nullable in DB
+	BackfillHours int        `db:"backfill_hours"`
+	Status        string     `db:"status"` // CHECK: 'queued', 'running', 'done', 'failed'
+	Attempts      int        `db:"attempts"`
+	MaxAttempts   int        `db:"max_attempts"`
+	RunAfter      time.Time  `db:"run_after"`
+	LockedBy      *string    `db:"locked_by"`    // Nullable in DB
+	LockedUntil   *time.Time `db:"locked_until"` // Nullable in DB
+	LastError     *string    `db:"last_error"`   // Nullable in DB
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
 }
 
 // FollowingItem represents a joined result from user_following and authors tables
@@ -79,13 +217,167 @@ type FollowingItem struct {
 	DisplayName   *string    `db:"display_name"`
 	LastSeenAt    *time.Time `db:"last_seen_at"`
 	LastCheckedAt *time.Time `db:"last_checked_at"`
+	Pinned        bool       `db:"pinned"`
+	ExcludedAt    *time.Time `db:"excluded_at"`
+}
+
+// FollowingEventItem represents a joined result from following_events and authors tables
+type FollowingEventItem struct {
+	XAuthorID   int64     `db:"x_author_id"`
+	Handle      string    `db:"handle"`
+	DisplayName *string   `db:"display_name"`
+	Event       string    `db:"event"`
+	RunID       *string   `db:"run_id"`
+	OccurredAt  time.Time `db:"occurred_at"`
 }
 
 // PostWithAuthor represents a post with author information
 type PostWithAuthor struct {
 	Post
-	Handle      string  `db:"handle"`
-	DisplayName *string `db:"display_name"`
+	Handle           string       `db:"handle"`
+	DisplayName      *string      `db:"display_name"`
+	RepostedByHandle *string      `db:"reposted_by_handle"` // Handle of the author who retweeted a 'retweet' post
+	QuotedHandle     *string      `db:"quoted_handle"`      // Author handle of the tweet quoted by a 'quote' post
+	QuotedText       *string      `db:"quoted_text"`        // Text of the tweet quoted by a 'quote' post
+	Article          *PostArticle `db:"-"`                  // X article linked from the post; loaded separately for Q&A
+	Links            []PostLink   `db:"-"`                  // Pages linked from the post; loaded separately for Q&A
+	Media            []PostMedia  `db:"-"`                  // Images and videos of the post; loaded separately for Q&A
+}
+
+// PostArticle represents the post_articles table with chunks from post_article_chunks (user-scoped, RLS enabled)
+type PostArticle struct {
+	XPostID    int64    `db:"x_post_id"`
+	Title      *string  `db:"title"` // Nullable in DB
+	ChunkCount int      `db:"chunk_count"`
+	Chunks     []string `db:"-"` // Ordered by chunk_index; may hold only some chunks of the article
+	// ChunkIndexes holds the chunk_index of each of Chunks when only some chunks were selected; nil means the leading chunks
+	ChunkIndexes []int `db:"-"`
+}
+
+// PostLink represents the post_links table (user-scoped, RLS enabled)
+type PostLink struct {
+	XPostID   int64     `db:"x_post_id"`
+	URL       string    `db:"url"`       // Link as found in the post
+	FinalURL  string    `db:"final_url"` // URL after redirects
+	Title     *string   `db:"title"`     // Nullable in DB
+	Summary   *string   `db:"summary"`   // Nullable in DB; set when the page was summarized
+	Content   string    `db:"content"`   // Readable text of the page, truncated
+	FetchedAt time.Time `db:"fetched_at"`
+}
+
+// PostMedia represents the post_media table (user-scoped, RLS enabled)
+type PostMedia struct {
+	UserID      uuid.UUID  `db:"user_id"`
+	XPostID     int64      `db:"x_post_id"`
+	MediaIndex  int        `db:"media_index"` // Position among the post's media, images first
+	MediaType   string     `db:"media_type"`  // One of the MediaType values
+	URL         string     `db:"url"`
+	URLHash     string     `db:"url_hash"`     // SHA-256 of the URL, shared by every copy of the same media
+	DurationMs  *int       `db:"duration_ms"`  // Nullable in DB; set for videos
+	Description *string    `db:"description"`  // Nullable in DB; set once described
+	Model       *string    `db:"model"`        // Nullable in DB; model that made the description
+	Status      string     `db:"status"`       // One of the MediaStatus values
+	Error       *string    `db:"error"`        // Nullable in DB; reason of the last failed or skipped attempt
+	Attempts    int        `db:"attempts"`     // Number of description attempts
+	DescribedAt *time.Time `db:"described_at"` // Nullable in DB
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+// Media types (post_media.media_type)
+const (
+	MediaTypeImage = "image"
+	MediaTypeVideo = "video"
+)
+
+// Media statuses (post_media.status): progress of the description of a media item
+const (
+	MediaStatusPending   = "pending"   // Not described yet, e.g. stored without an OpenRouter client or transcriber
+	MediaStatusDescribed = "described" // Description stored
+	MediaStatusFailed    = "failed"    // Last attempt failed; retried by the media reprocessor
+	MediaStatusSkipped   = "skipped"   // Not describable, e.g. a video over the limits
+)
+
+// MediaDescriptionCacheEntry represents the media_description_cache table (system table, no RLS)
+// Descriptions are shared by all users and keyed by image content or normalized URL
+type MediaDescriptionCacheEntry struct {
+	CacheKey    string    `db:"cache_key"` // 'url:' or 'sha256:' followed by a SHA-256 hex
+	Model       string    `db:"model"`     // Model that made the description
+	Description string    `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+	ExpiresAt   time.Time `db:"expires_at"`
+}
+
+// TimelineFetch represents the timeline_fetches table (system table, no RLS)
+// One page of an author's timeline fetched once per fetch cycle and shared by every user ingesting the author
+type TimelineFetch struct {
+	XAuthorID    int64      `db:"x_author_id"`
+	PageCursor   string     `db:"page_cursor"` // Empty for the first page
+	CycleStart   time.Time  `db:"cycle_start"`
+	Status       string     `db:"status"`   // Progress of the fetch, see the TimelineFetchStatus constants
+	ClaimID      string     `db:"claim_id"` // ULID of the fetch attempt holding the claim
+	ClaimedUntil time.Time  `db:"claimed_until"`
+	Response     []byte     `db:"response"`     // Fetched page as JSON; nil until done
+	Error        *string    `db:"error"`        // Error of a failed fetch
+	ErrorStatus  *int       `db:"error_status"` // HTTP status of a failed fetch rejected by the API
+	FetchedAt    *time.Time `db:"fetched_at"`
+}
+
+// Timeline fetch statuses (timeline_fetches.status)
+const (
+	TimelineFetchStatusFetching = "fetching" // Claimed by a run; other followers wait
+	TimelineFetchStatusDone     = "done"     // Response stored for the rest of the cycle
+	TimelineFetchStatusFailed   = "failed"   // Waiting followers get the error; the next run fetches again
+)
+
+// IngestPolicy represents the ingest_policies table (user-scoped, RLS enabled)
+// Users without a row get the column defaults, which drop retweets, quotes and replies to others
+type IngestPolicy struct {
+	UserID          uuid.UUID      `db:"user_id"`
+	IncludeRetweets bool           `db:"include_retweets"`
+	IncludeQuotes   bool           `db:"include_quotes"`
+	IncludeReplies  bool           `db:"include_replies"`  // Replies to other authors; self-replies are always ingested
+	Languages       pq.StringArray `db:"languages"`        // X language codes; empty allows every language
+	IncludeMentions bool           `db:"include_mentions"` // Ingest mentions of the user's own account
+	UpdatedAt       time.Time      `db:"updated_at"`
+}
+
+// FeedList represents the feed_lists table (user-scoped, RLS enabled)
+type FeedList struct {
+	UserID       uuid.UUID  `db:"user_id"`
+	XListID      int64      `db:"x_list_id"`
+	Name         *string    `db:"name"` // Nullable in DB
+	CreatedAt    time.Time  `db:"created_at"`
+	LastSyncedAt *time.Time `db:"last_synced_at"` // Nullable in DB; null until the members were first synced
+}
+
+// FeedListItem represents a feed list with the number of its members and of those excluded by the list author limit
+type FeedListItem struct {
+	FeedList
+	MemberCount         int `db:"member_count"`
+	ExcludedMemberCount int `db:"excluded_member_count"`
+}
+
+// ListAuthor represents an author ingested through the user's lists
+type ListAuthor struct {
+	XAuthorID int64         `db:"x_author_id"`
+	ListIDs   pq.Int64Array `db:"list_ids"`
+}
+
+// SearchSource represents the search_sources table (user-scoped, RLS enabled)
+type SearchSource struct {
+	ID          string     `db:"id"` // ULID
+	UserID      uuid.UUID  `db:"user_id"`
+	Query       string     `db:"query"` // twitterapi.io advanced search query
+	Name        *string    `db:"name"`  // Nullable in DB
+	CreatedAt   time.Time  `db:"created_at"`
+	LastRunAt   *time.Time `db:"last_run_at"`   // Nullable in DB; null until the query first ran
+	LastTweetID *int64     `db:"last_tweet_id"` // Nullable in DB; newest result seen
+}
+
+// SearchSourceItem represents a search source with the number of posts it found
+type SearchSourceItem struct {
+	SearchSource
+	PostCount int `db:"post_count"`
 }
 
 // Session represents a user session in the database
@@ -100,11 +392,13 @@ type Session struct {
 
 // User represents a user in the database
 type User struct {
-	ID           uuid.UUID `db:"id"`
-	Email        string    `db:"email"`
-	PasswordHash string    `db:"password_hash"`
-	XUsername    string    `db:"x_username"`
-	XDisplayName string    `db:"x_display_name"`
-	CreatedAt    time.Time `db:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"`
+	ID             uuid.UUID `db:"id"`
+	Email          string    `db:"email"`
+	PasswordHash   string    `db:"password_hash"`
+	XUsername      string    `db:"x_username"`
+	XDisplayName   string    `db:"x_display_name"`
+	Plan           string    `db:"plan"`
+	FollowingLimit *int      `db:"following_limit"` // Nullable in DB; overrides the plan's limit
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
 }
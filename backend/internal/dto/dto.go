@@ -60,7 +60,16 @@ type SessionDTO struct {
 	AuthenticatedAt  time.Time `json:"authenticated_at"`
 	SessionExpiresAt time.Time `json:"session_expires_at"`
 	FollowingCount   int       `json:"following_count"`
-	FollowingLimit   int       `json:"following_limit"`
+	FollowingLimit   int       `json:"following_limit"` // From users.following_limit, or the limit of users.plan
+	Plan             string    `json:"plan"`            // From users.plan
+	// Followed authors over the following limit; their tweets are not ingested
+	ExcludedAuthorCount int `json:"excluded_author_count"`
+	// The most active excluded authors, at most 20; GET /api/v1/following lists all of them
+	ExcludedAuthors []ExcludedAuthorDTO `json:"excluded_authors"`
+	// Authors ingested through the user's lists, from the limit of users.plan
+	ListAuthorLimit int `json:"list_author_limit"`
+	// Listed authors over the list author limit; their tweets are not ingested
+	ExcludedListAuthorCount int `json:"excluded_list_author_count"`
 }
 
 // =============================================================================
@@ -81,17 +90,100 @@ type TriggerIngestResponseDTO struct {
 	StartedAt   time.Time `json:"started_at"`    // From ingest_runs.started_at
 }
 
+// CancelIngestResponseDTO represents response after requesting cancellation of an ingestion run
+// Response model for POST /api/v1/ingest/runs/{id}/cancel
+type CancelIngestResponseDTO struct {
+	IngestRunID       string     `json:"ingest_run_id"`          // ULID from ingest_runs.id
+	Status            string     `json:"status"`                 // 'cancelled' once stopped, 'cancelling' while the worker winds down
+	CancelRequestedAt *time.Time `json:"cancel_requested_at"`    // From ingest_runs.cancel_requested_at
+	CompletedAt       *time.Time `json:"completed_at,omitempty"` // From ingest_runs.completed_at (nullable)
+}
+
 // IngestRunDTO represents a single ingestion run
 // Maps to: ingest_runs table
 type IngestRunDTO struct {
-	ID            string     `json:"id"`                        // From ingest_runs.id (ULID)
-	Status        string     `json:"status"`                    // From ingest_runs.status
-	StartedAt     time.Time  `json:"started_at"`                // From ingest_runs.started_at
-	CompletedAt   *time.Time `json:"completed_at,omitempty"`    // From ingest_runs.completed_at (nullable)
-	FetchedCount  int        `json:"fetched_count"`             // From ingest_runs.fetched_count
-	Retried       int        `json:"retried,omitempty"`         // From ingest_runs.retried
-	RateLimitHits int        `json:"rate_limit_hits,omitempty"` // From ingest_runs.rate_limit_hits
-	Error         string     `json:"error,omitempty"`           // From ingest_runs.err_text (nullable)
+	ID            string     `json:"id"`                     // From ingest_runs.id (ULID)
+	Status        string     `json:"status"`                 // From ingest_runs.status
+	StartedAt     time.Time  `json:"started_at"`             // From ingest_runs.started_at
+	CompletedAt   *time.Time `json:"completed_at,omitempty"` // From ingest_runs.completed_at (nullable)
+	FetchedCount  int        `json:"fetched_count"`          // From ingest_runs.fetched_count
+	Retried       int        `json:"retried"`                // From ingest_runs.retried
+	RateLimitHits int        `json:"rate_limit_hits"`        // From ingest_runs.rate_limit_hits
+	Error         string     `json:"error,omitempty"`        // From ingest_runs.err_text (nullable)
+}
+
+// IngestRateLimitStatsDTO represents rate limiting aggregated over recent ingestion runs
+// Response model for GET /api/v1/ingest/rate-limits
+type IngestRateLimitStatsDTO struct {
+	Days            int                     `json:"days"`              // Size of the reporting window in days
+	Runs            int                     `json:"runs"`              // Runs started in the window
+	RateLimitedRuns int                     `json:"rate_limited_runs"` // Runs that ended with status 'rate_limited'
+	RateLimitHits   int                     `json:"rate_limit_hits"`   // Sum of ingest_runs.rate_limit_hits
+	Retried         int                     `json:"retried"`           // Sum of ingest_runs.retried
+	Daily           []IngestRateLimitDayDTO `json:"daily"`             // Per-day breakdown, newest first
+}
+
+// IngestRateLimitDayDTO represents rate limiting of ingestion runs started on a single day (UTC)
+type IngestRateLimitDayDTO struct {
+	Date            string `json:"date"` // YYYY-MM-DD
+	Runs            int    `json:"runs"`
+	RateLimitedRuns int    `json:"rate_limited_runs"`
+	RateLimitHits   int    `json:"rate_limit_hits"`
+	Retried         int    `json:"retried"`
+}
+
+// IngestPolicyDTO represents the user's ingest policy
+// Response model for GET and PUT /api/v1/ingest/policy
+type IngestPolicyDTO struct {
+	IncludeRetweets bool       `json:"include_retweets"`     // From ingest_policies.include_retweets
+	IncludeQuotes   bool       `json:"include_quotes"`       // From ingest_policies.include_quotes
+	IncludeReplies  bool       `json:"include_replies"`      // From ingest_policies.include_replies
+	Languages       []string   `json:"languages"`            // From ingest_policies.languages; empty allows every language
+	IncludeMentions bool       `json:"include_mentions"`     // From ingest_policies.include_mentions
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"` // From ingest_policies.updated_at; null while the defaults apply
+}
+
+// UpdateIngestPolicyCommand represents request to replace the user's ingest policy
+// Command model for PUT /api/v1/ingest/policy
+type UpdateIngestPolicyCommand struct {
+	IncludeRetweets bool     `json:"include_retweets"`
+	IncludeQuotes   bool     `json:"include_quotes"`
+	IncludeReplies  bool     `json:"include_replies"`
+	Languages       []string `json:"languages" validate:"max=20"` // X language codes, e.g. "en", "pl"
+	IncludeMentions bool     `json:"include_mentions"`            // Ingest mentions of the user's own account
+}
+
+// IngestRunDetailDTO represents a single ingestion run with per-phase progress and errors
+// Response model for GET /api/v1/ingest/runs/{id}
+type IngestRunDetailDTO struct {
+	ID                string               `json:"id"`                            // From ingest_runs.id (ULID)
+	Status            string               `json:"status"`                        // From ingest_runs.status
+	Phase             string               `json:"phase"`                         // From ingest_runs.phase
+	StartedAt         time.Time            `json:"started_at"`                    // From ingest_runs.started_at
+	CompletedAt       *time.Time           `json:"completed_at,omitempty"`        // From ingest_runs.completed_at (nullable)
+	CancelRequestedAt *time.Time           `json:"cancel_requested_at,omitempty"` // From ingest_runs.cancel_requested_at (nullable)
+	HeartbeatAt       *time.Time           `json:"heartbeat_at,omitempty"`        // From ingest_runs.heartbeat_at (nullable)
+	Progress          IngestRunProgressDTO `json:"progress"`
+	FetchedCount      int                  `json:"fetched_count"`   // From ingest_runs.fetched_count
+	Retried           int                  `json:"retried"`         // From ingest_runs.retried
+	RateLimitHits     int                  `json:"rate_limit_hits"` // From ingest_runs.rate_limit_hits
+	Error             string               `json:"error,omitempty"` // From ingest_runs.err_text (nullable)
+	Errors            []IngestRunErrorDTO  `json:"errors"`          // From ingest_run_errors
+}
+
+// IngestRunProgressDTO represents per-phase progress of an ingestion run
+type IngestRunProgressDTO struct {
+	Following int `json:"following"` // From ingest_runs.following_count
+	Tweets    int `json:"tweets"`    // From ingest_runs.tweets_count
+}
+
+// IngestRunErrorDTO represents a non-fatal error recorded during an ingestion run
+// Maps to: ingest_run_errors table
+type IngestRunErrorDTO struct {
+	Phase        string    `json:"phase"`                   // From ingest_run_errors.phase
+	AuthorHandle string    `json:"author_handle,omitempty"` // From ingest_run_errors.author_handle (nullable)
+	Message      string    `json:"message"`                 // From ingest_run_errors.message
+	OccurredAt   time.Time `json:"occurred_at"`             // From ingest_run_errors.created_at
 }
 
 // IngestStatusDTO represents current and recent ingestion status
@@ -110,44 +202,64 @@ type IngestStatusDTO struct {
 // Command model for POST /api/v1/qa
 type CreateQACommand struct {
 	Question string     `json:"question" validate:"required,min=1,max=2000"`
-	DateFrom *time.Time `json:"date_from,omitempty"` // Optional, defaults to 24 hours ago
-	DateTo   *time.Time `json:"date_to,omitempty"`   // Optional, defaults to now
+	DateFrom *time.Time `json:"date_from,omitempty"`                                      // Optional, defaults to 24 hours ago
+	DateTo   *time.Time `json:"date_to,omitempty"`                                        // Optional, defaults to now
+	ListID   *int64     `json:"list_id,omitempty"`                                        // Optional, scopes the question to posts ingested through a registered list
+	Source   *string    `json:"source,omitempty" validate:"omitempty,oneof=feed mention"` // Optional, "mention" keeps only mentions of the user, "feed" leaves them out
 }
 
 // QASourceDTO represents a source post for Q&A answer
 // Maps to: posts table joined with authors table via qa_sources junction table
 type QASourceDTO struct {
-	XPostID           int64     `json:"x_post_id"`              // From posts.x_post_id
-	AuthorHandle      string    `json:"author_handle"`          // From authors.handle
-	AuthorDisplayName string    `json:"author_display_name"`    // From authors.display_name
-	PublishedAt       time.Time `json:"published_at"`           // From posts.published_at
-	URL               string    `json:"url"`                    // From posts.url
-	TextPreview       string    `json:"text_preview,omitempty"` // Truncated posts.text (for list view)
-	Text              string    `json:"text,omitempty"`         // Full posts.text (for detail view)
+	XPostID           int64             `json:"x_post_id"`                 // From posts.x_post_id
+	AuthorHandle      string            `json:"author_handle"`             // From authors.handle
+	AuthorDisplayName string            `json:"author_display_name"`       // From authors.display_name
+	PublishedAt       time.Time         `json:"published_at"`              // From posts.published_at
+	URL               string            `json:"url"`                       // From posts.url
+	TextPreview       string            `json:"text_preview,omitempty"`    // Truncated posts.text (for list view)
+	Text              string            `json:"text,omitempty"`            // Full posts.text (for detail view)
+	Edited            bool              `json:"edited"`                    // From posts.edited_seen
+	Deleted           bool              `json:"deleted"`                   // posts.deleted_at is set
+	Kind              string            `json:"kind"`                      // From posts.kind
+	RepostedBy        string            `json:"reposted_by,omitempty"`     // Handle of the author who retweeted a 'retweet' post
+	ConversationID    *int64            `json:"conversation_id,omitempty"` // From posts.conversation_id, set when the post is part of a thread
+	Thread            []QAThreadPostDTO `json:"thread,omitempty"`          // All posts of the thread in order, from the threads view
+}
+
+// QAThreadPostDTO represents one post of a self-reply thread shown as a Q&A source
+type QAThreadPostDTO struct {
+	XPostID     int64     `json:"x_post_id"`    // From posts.x_post_id
+	PublishedAt time.Time `json:"published_at"` // From posts.published_at
+	URL         string    `json:"url"`          // From posts.url
+	Text        string    `json:"text"`         // From posts.text
 }
 
 // QADetailDTO represents full Q&A interaction details
 // Maps to: qa_messages table with sources from qa_sources -> posts -> authors
 type QADetailDTO struct {
-	ID        string        `json:"id"`         // From qa_messages.id (ULID)
-	Question  string        `json:"question"`   // From qa_messages.question
-	Answer    string        `json:"answer"`     // From qa_messages.answer
-	DateFrom  time.Time     `json:"date_from"`  // From qa_messages.date_from
-	DateTo    time.Time     `json:"date_to"`    // From qa_messages.date_to
-	CreatedAt time.Time     `json:"created_at"` // From qa_messages.created_at
-	Sources   []QASourceDTO `json:"sources"`    // From qa_sources joined with posts and authors
+	ID        string        `json:"id"`                // From qa_messages.id (ULID)
+	Question  string        `json:"question"`          // From qa_messages.question
+	Answer    string        `json:"answer"`            // From qa_messages.answer
+	DateFrom  time.Time     `json:"date_from"`         // From qa_messages.date_from
+	DateTo    time.Time     `json:"date_to"`           // From qa_messages.date_to
+	ListID    *int64        `json:"list_id,omitempty"` // From qa_messages.list_id (nullable)
+	Source    *string       `json:"source,omitempty"`  // From qa_messages.source (nullable)
+	CreatedAt time.Time     `json:"created_at"`        // From qa_messages.created_at
+	Sources   []QASourceDTO `json:"sources"`           // From qa_sources joined with posts and authors
 }
 
 // QAListItemDTO represents Q&A item in paginated list
 // Maps to: qa_messages table with source count from qa_sources
 type QAListItemDTO struct {
-	ID            string    `json:"id"`             // From qa_messages.id (ULID)
-	Question      string    `json:"question"`       // From qa_messages.question
-	AnswerPreview string    `json:"answer_preview"` // Truncated qa_messages.answer
-	DateFrom      time.Time `json:"date_from"`      // From qa_messages.date_from
-	DateTo        time.Time `json:"date_to"`        // From qa_messages.date_to
-	CreatedAt     time.Time `json:"created_at"`     // From qa_messages.created_at
-	SourcesCount  int       `json:"sources_count"`  // COUNT from qa_sources
+	ID            string    `json:"id"`                // From qa_messages.id (ULID)
+	Question      string    `json:"question"`          // From qa_messages.question
+	AnswerPreview string    `json:"answer_preview"`    // Truncated qa_messages.answer
+	DateFrom      time.Time `json:"date_from"`         // From qa_messages.date_from
+	DateTo        time.Time `json:"date_to"`           // From qa_messages.date_to
+	ListID        *int64    `json:"list_id,omitempty"` // From qa_messages.list_id (nullable)
+	Source        *string   `json:"source,omitempty"`  // From qa_messages.source (nullable)
+	CreatedAt     time.Time `json:"created_at"`        // From qa_messages.created_at
+	SourcesCount  int       `json:"sources_count"`     // COUNT from qa_sources
 }
 
 // QAListResponseDTO represents paginated Q&A list response
@@ -169,6 +281,18 @@ type FollowingItemDTO struct {
 	DisplayName   string     `json:"display_name"`              // From authors.display_name
 	LastSeenAt    *time.Time `json:"last_seen_at,omitempty"`    // From authors.last_seen_at (nullable)
 	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"` // From user_following.last_checked_at (nullable)
+	Pinned        bool       `json:"pinned"`                    // From user_following.pinned
+	Excluded      bool       `json:"excluded"`                  // user_following.excluded_at is set
+}
+
+// ExcludedAuthorDTO represents a followed author left out by the user's following limit
+// Maps to: user_following table joined with authors table
+type ExcludedAuthorDTO struct {
+	XAuthorID   int64      `json:"x_author_id"`            // From authors.x_author_id
+	Handle      string     `json:"handle"`                 // From authors.handle
+	DisplayName string     `json:"display_name"`           // From authors.display_name
+	LastSeenAt  *time.Time `json:"last_seen_at,omitempty"` // From authors.last_seen_at (nullable)
+	ExcludedAt  time.Time  `json:"excluded_at"`            // From user_following.excluded_at
 }
 
 // FollowingListResponseDTO represents paginated following list response
@@ -176,6 +300,72 @@ type FollowingListResponseDTO struct {
 	Items []FollowingItemDTO `json:"items"`
 }
 
+// FollowingEventDTO represents a follow or unfollow detected by a following sync
+// Maps to: following_events table joined with authors table
+type FollowingEventDTO struct {
+	XAuthorID   int64     `json:"x_author_id"`             // From following_events.x_author_id
+	Handle      string    `json:"handle"`                  // From authors.handle
+	DisplayName string    `json:"display_name"`            // From authors.display_name
+	Event       string    `json:"event"`                   // From following_events.event ('follow' or 'unfollow')
+	IngestRunID string    `json:"ingest_run_id,omitempty"` // From following_events.run_id (nullable)
+	OccurredAt  time.Time `json:"occurred_at"`             // From following_events.occurred_at
+}
+
+// FollowingEventsResponseDTO represents the list of recent follow/unfollow events
+// Response model for GET /api/v1/following/events
+type FollowingEventsResponseDTO struct {
+	Items []FollowingEventDTO `json:"items"`
+}
+
+// FeedListDTO represents an X list registered as a feed source
+// Maps to: feed_lists table with member count from feed_list_members
+type FeedListDTO struct {
+	XListID     int64  `json:"x_list_id"`    // From feed_lists.x_list_id
+	Name        string `json:"name"`         // From feed_lists.name
+	MemberCount int    `json:"member_count"` // COUNT from feed_list_members
+	// Members left out by the user's list author limit (feed_list_members.excluded_at is set)
+	ExcludedMemberCount int        `json:"excluded_member_count"`
+	CreatedAt           time.Time  `json:"created_at"`               // From feed_lists.created_at
+	LastSyncedAt        *time.Time `json:"last_synced_at,omitempty"` // From feed_lists.last_synced_at (nullable)
+}
+
+// FeedListsResponseDTO represents the user's registered lists
+// Response model for GET /api/v1/lists
+type FeedListsResponseDTO struct {
+	Items []FeedListDTO `json:"items"`
+}
+
+// AddFeedListCommand represents request to register an X list as a feed source
+// Command model for POST /api/v1/lists
+type AddFeedListCommand struct {
+	XListID int64  `json:"x_list_id" validate:"required,gt=0"`
+	Name    string `json:"name" validate:"max=100"` // Optional label shown to the user
+}
+
+// SearchSourceDTO represents a saved search query used as a feed source
+// Maps to: search_sources table with post count from post_search_sources
+type SearchSourceDTO struct {
+	ID        string     `json:"id"`                    // From search_sources.id
+	Query     string     `json:"query"`                 // From search_sources.query
+	Name      string     `json:"name"`                  // From search_sources.name
+	PostCount int        `json:"post_count"`            // COUNT from post_search_sources
+	CreatedAt time.Time  `json:"created_at"`            // From search_sources.created_at
+	LastRunAt *time.Time `json:"last_run_at,omitempty"` // From search_sources.last_run_at (nullable)
+}
+
+// SearchSourcesResponseDTO represents the user's saved search queries
+// Response model for GET /api/v1/search-sources
+type SearchSourcesResponseDTO struct {
+	Items []SearchSourceDTO `json:"items"`
+}
+
+// AddSearchSourceCommand represents request to save a search query as a feed source
+// Command model for POST /api/v1/search-sources
+type AddSearchSourceCommand struct {
+	Query string `json:"query" validate:"required,max=512"` // twitterapi.io advanced search query
+	Name  string `json:"name" validate:"max=100"`           // Optional label shown to the user
+}
+
 // =============================================================================
 // System Health DTOs
 // =============================================================================
@@ -237,12 +427,24 @@ type TweetDTO struct {
 	URL            string    `json:"url"`
 	PublishedAt    time.Time `json:"published_at"`
 	ConversationID int64     `json:"conversation_id"`
+	RawText        string    `json:"raw_text"`                 // Tweet text as returned by X; Text of posts ingested before post_media may have media descriptions appended
+	InReplyToID    int64     `json:"in_reply_to_id,omitempty"` // Parent tweet of a self-reply; 0 otherwise
+	Kind           string    `json:"kind"`                     // posts.kind
+	RepostedByID   int64     `json:"reposted_by_id,omitempty"` // Followed author who retweeted a 'retweet' post
+	QuotedPostID   int64     `json:"quoted_post_id,omitempty"` // Tweet quoted by a 'quote' post
+	Source         string    `json:"source"`                   // posts.source; empty is stored as "feed"
+	LikeCount      int64     `json:"like_count"`
+	RetweetCount   int64     `json:"retweet_count"`
+	ReplyCount     int64     `json:"reply_count"`
+	QuoteCount     int64     `json:"quote_count"`
+	ViewCount      int64     `json:"view_count"`
+	BookmarkCount  int64     `json:"bookmark_count"`
 }
 
 // UserDTO represents user data from Twitter API
 type UserDTO struct {
-	ID          int64     `json:"id"`
-	Handle      string    `json:"handle"`
-	DisplayName string    `json:"display_name"`
-	LastSeenAt  time.Time `json:"last_seen_at"`
+	ID          int64      `json:"id"`
+	Handle      string     `json:"handle"`
+	DisplayName string     `json:"display_name"`
+	LastSeenAt  *time.Time `json:"last_seen_at,omitempty"` // Time of the newest tweet seen; nil until one is ingested
 }
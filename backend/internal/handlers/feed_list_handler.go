@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/services"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FeedListHandler handles requests for X lists registered as feed sources
+type FeedListHandler struct {
+	feedListService *services.FeedListService
+	validator       *validator.Validate
+}
+
+// NewFeedListHandler creates a new FeedListHandler instance
+func NewFeedListHandler(feedListService *services.FeedListService) *FeedListHandler {
+	return &FeedListHandler{
+		feedListService: feedListService,
+		validator:       validator.New(),
+	}
+}
+
+// GetLists handles GET /api/v1/lists endpoint
+func (h *FeedListHandler) GetLists(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	response, err := h.feedListService.GetLists(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas pobierania list", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AddList handles POST /api/v1/lists endpoint
+// Members of the list are read and ingested from the next ingestion run
+func (h *FeedListHandler) AddList(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	var cmd dto.AddFeedListCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, "INVALID_INPUT", "Nieprawidłowe dane wejściowe", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(cmd); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, "INVALID_INPUT", "Nieprawidłowe dane wejściowe", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("list_id", cmd.XListID))
+
+	list, err := h.feedListService.AddList(ctx, userID, cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrFeedListExists):
+			h.respondWithError(c, http.StatusConflict, "ALREADY_EXISTS", "Lista jest już zarejestrowana jako źródło", nil)
+		case errors.Is(err, services.ErrTooManyFeedLists):
+			h.respondWithError(c, http.StatusBadRequest, "TOO_MANY_LISTS", "Można zarejestrować maksymalnie 10 list", map[string]interface{}{
+				"max_items": services.MaxFeedLists,
+			})
+		default:
+			span.RecordError(err)
+			h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas dodawania listy", nil)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// RemoveList handles DELETE /api/v1/lists/:x_list_id endpoint
+// Posts ingested through the list are kept
+func (h *FeedListHandler) RemoveList(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	// Extract list ID from URL parameter
+	listIDStr := c.Param("x_list_id")
+	listID, err := strconv.ParseInt(listIDStr, 10, 64)
+	if err != nil || listID <= 0 {
+		h.respondWithError(c, http.StatusBadRequest, "INVALID_LIST_ID", "Nieprawidłowy identyfikator listy", map[string]interface{}{
+			"provided_value": listIDStr,
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("list_id", listID),
+	)
+
+	err = h.feedListService.RemoveList(ctx, userID, listID)
+	if err != nil {
+		if errors.Is(err, services.ErrFeedListNotFound) {
+			h.respondWithError(c, http.StatusNotFound, "NOT_FOUND", "Lista o podanym ID nie jest zarejestrowana", nil)
+			return
+		}
+		span.RecordError(err)
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas usuwania listy", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponseDTO{
+		Message: "Lista usunięta pomyślnie",
+	})
+}
+
+// respondWithError sends a standardized error response
+func (h *FeedListHandler) respondWithError(c *gin.Context, statusCode int, code, message string, details map[string]interface{}) {
+	response := dto.ErrorResponseDTO{
+		Error: dto.ErrorDetailDTO{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+	c.JSON(statusCode, response)
+}
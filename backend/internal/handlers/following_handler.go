@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -55,6 +57,121 @@ func (h *FollowingHandler) GetFollowing(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetFollowingEvents handles GET /api/v1/following/events endpoint
+// Returns follow/unfollow changes detected by following syncs, newest first
+func (h *FollowingHandler) GetFollowingEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	// Parse and validate limit query parameter
+	limit := 50 // default value
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < 1 || parsedLimit > 200 {
+			h.respondWithError(c, http.StatusBadRequest, "INVALID_LIMIT", "Parametr 'limit' musi być liczbą całkowitą z zakresu 1-200", map[string]interface{}{
+				"provided_value": limitStr,
+				"min_value":      1,
+				"max_value":      200,
+			})
+			return
+		}
+		limit = parsedLimit
+	}
+
+	span.SetAttributes(attribute.Int("limit", limit))
+
+	// Call service layer to get following events
+	response, err := h.followingService.GetFollowingEvents(ctx, userID, limit)
+	if err != nil {
+		span.RecordError(err)
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas pobierania historii obserwowanych", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PinAuthor handles PUT /api/v1/following/:x_author_id/pin endpoint
+// Pinned authors are kept first when the user follows more authors than their following limit
+func (h *FollowingHandler) PinAuthor(c *gin.Context) {
+	h.setPinned(c, true)
+}
+
+// UnpinAuthor handles DELETE /api/v1/following/:x_author_id/pin endpoint
+func (h *FollowingHandler) UnpinAuthor(c *gin.Context) {
+	h.setPinned(c, false)
+}
+
+// setPinned pins or unpins the author given in the URL for the authenticated user
+func (h *FollowingHandler) setPinned(c *gin.Context, pinned bool) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	// Extract author ID from URL parameter
+	authorIDStr := c.Param("x_author_id")
+	authorID, err := strconv.ParseInt(authorIDStr, 10, 64)
+	if err != nil || authorID <= 0 {
+		h.respondWithError(c, http.StatusBadRequest, "INVALID_AUTHOR_ID", "Nieprawidłowy identyfikator autora", map[string]interface{}{
+			"provided_value": authorIDStr,
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("author_id", authorID),
+		attribute.Bool("pinned", pinned),
+	)
+
+	// Call service layer to update the pin
+	err = h.followingService.SetPinned(ctx, userID, authorID, pinned)
+	if err != nil {
+		if errors.Is(err, services.ErrFollowingNotFound) {
+			h.respondWithError(c, http.StatusNotFound, "NOT_FOUND", "Autor o podanym ID nie jest obserwowany przez użytkownika", nil)
+			return
+		}
+		span.RecordError(err)
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas aktualizacji przypięcia autora", nil)
+		return
+	}
+
+	message := "Autor przypięty pomyślnie"
+	if !pinned {
+		message = "Autor odpięty pomyślnie"
+	}
+	c.JSON(http.StatusOK, dto.MessageResponseDTO{
+		Message: message,
+	})
+}
+
 // respondWithError sends a standardized error response
 func (h *FollowingHandler) respondWithError(c *gin.Context, statusCode int, code, message string, details map[string]interface{}) {
 	response := dto.ErrorResponseDTO{
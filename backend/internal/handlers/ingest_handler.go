@@ -1,14 +1,12 @@
 package handlers
 
 import (
-	"context"
+	"errors"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/oklog/ulid/v2"
 	"github.com/sopeal/AskYourFeed/internal/dto"
 	"github.com/sopeal/AskYourFeed/internal/services"
 	"github.com/sopeal/AskYourFeed/pkg/logger"
@@ -19,14 +17,16 @@ import (
 // IngestHandler handles ingestion-related HTTP requests
 type IngestHandler struct {
 	ingestStatusService *services.IngestStatusService
-	ingestService       *services.IngestService
+	ingestQueue         *services.IngestQueue
+	ingestPolicyService *services.IngestPolicyService
 }
 
 // NewIngestHandler creates a new IngestHandler instance
-func NewIngestHandler(ingestStatusService *services.IngestStatusService, ingestService *services.IngestService) *IngestHandler {
+func NewIngestHandler(ingestStatusService *services.IngestStatusService, ingestQueue *services.IngestQueue, ingestPolicyService *services.IngestPolicyService) *IngestHandler {
 	return &IngestHandler{
 		ingestStatusService: ingestStatusService,
-		ingestService:       ingestService,
+		ingestQueue:         ingestQueue,
+		ingestPolicyService: ingestPolicyService,
 	}
 }
 
@@ -145,56 +145,324 @@ func (h *IngestHandler) TriggerIngest(c *gin.Context) {
 
 	span.SetAttributes(attribute.Int("backfill_hours", req.BackfillHours))
 
-	// Check if there's already a running ingest (409 Conflict)
-	currentRun, err := h.ingestStatusService.GetIngestStatus(ctx, userID, 1)
+	// Create the run and enqueue a durable ingest job; a worker executes it asynchronously
+	run, job, err := h.ingestQueue.Submit(ctx, userID, req.BackfillHours)
 	if err != nil {
+		if errors.Is(err, services.ErrIngestInProgress) {
+			h.respondIngestInProgress(c, userID)
+			return
+		}
 		span.RecordError(err)
-		logger.Error("failed to check current ingest status",
+		logger.Error("failed to enqueue ingest job",
 			err,
-			"user_id", userID)
-		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas sprawdzania statusu ingestion", nil)
+			"user_id", userID,
+			"backfill_hours", req.BackfillHours)
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas uruchamiania ingestion", nil)
 		return
 	}
 
-	if currentRun.CurrentRun != nil {
-		h.respondWithError(c, http.StatusConflict, "INGEST_IN_PROGRESS", "Ingestion już trwa dla tego użytkownika", map[string]interface{}{
-			"current_run_id": currentRun.CurrentRun.ID,
-			"started_at":     currentRun.CurrentRun.StartedAt,
-		})
+	span.SetAttributes(
+		attribute.String("run_id", run.ID),
+		attribute.String("job_id", job.ID),
+	)
+
+	// Return immediate response with the created run
+	response := dto.TriggerIngestResponseDTO{
+		IngestRunID: run.ID,
+		Status:      "triggered",
+		StartedAt:   run.StartedAt,
+	}
+
+	c.JSON(http.StatusAccepted, response)
+}
+
+// GetIngestRun handles GET /api/v1/ingest/runs/{id} endpoint
+// Returns a single ingestion run with per-phase progress and errors
+func (h *IngestHandler) GetIngestRun(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
 		return
 	}
 
-	// Generate run ID for response
-	runID := ulid.Make().String()
-	startedAt := time.Now()
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	// Extract run ID from URL parameter
+	runID := c.Param("id")
+	if runID == "" {
+		h.respondWithError(c, http.StatusBadRequest, "MISSING_ID", "Brak identyfikatora ingestion", nil)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("run_id", runID),
+	)
+
+	// Call service layer
+	run, err := h.ingestStatusService.GetIngestRun(ctx, userID, runID)
+	if err != nil {
+		if errors.Is(err, services.ErrIngestRunNotFound) {
+			h.respondWithError(c, http.StatusNotFound, "NOT_FOUND", "Ingestion o podanym ID nie została znaleziona lub nie należy do użytkownika", nil)
+			return
+		}
+		span.RecordError(err)
+		logger.Error("failed to get ingest run",
+			err,
+			"user_id", userID,
+			"run_id", runID,
+			"path", c.Request.URL.Path)
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas pobierania ingestion", nil)
+		return
+	}
 
-	// Trigger ingestion asynchronously (don't wait for completion)
-	go func() {
-		// Create a new context for the background operation
-		backgroundCtx := context.Background()
-		err := h.ingestService.IngestUserData(backgroundCtx, userID, req.BackfillHours)
+	c.JSON(http.StatusOK, run)
+}
+
+// GetRateLimitStats handles GET /api/v1/ingest/rate-limits endpoint
+// Returns rate limit hits and retries aggregated per day over recent ingestion runs
+func (h *IngestHandler) GetRateLimitStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	// Parse and validate days query parameter
+	days := 7 // default value
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsedDays, err := strconv.Atoi(daysStr)
 		if err != nil {
-			logger.Error("background ingestion failed",
+			h.respondWithError(c, http.StatusBadRequest, "INVALID_DAYS", "Parametr 'days' musi być liczbą całkowitą", map[string]interface{}{
+				"provided_value": daysStr,
+			})
+			return
+		}
+
+		if parsedDays < 1 || parsedDays > 90 {
+			h.respondWithError(c, http.StatusBadRequest, "INVALID_DAYS", "Parametr 'days' musi mieścić się w zakresie 1-90", map[string]interface{}{
+				"provided_value": parsedDays,
+				"min_value":      1,
+				"max_value":      90,
+			})
+			return
+		}
+
+		days = parsedDays
+	}
+
+	span.SetAttributes(attribute.Int("days", days))
+
+	// Call service layer
+	stats, err := h.ingestStatusService.GetRateLimitStats(ctx, userID, days)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to get rate limit stats",
+			err,
+			"user_id", userID,
+			"days", days,
+			"path", c.Request.URL.Path)
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas pobierania statystyk limitów API", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// CancelIngestRun handles POST /api/v1/ingest/runs/{id}/cancel endpoint
+// Stops an in-progress ingestion run of the authenticated user
+func (h *IngestHandler) CancelIngestRun(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	// Extract run ID from URL parameter
+	runID := c.Param("id")
+	if runID == "" {
+		h.respondWithError(c, http.StatusBadRequest, "MISSING_ID", "Brak identyfikatora ingestion", nil)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("run_id", runID),
+	)
+
+	// Call service layer
+	run, err := h.ingestQueue.Cancel(ctx, userID, runID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrIngestRunNotFound):
+			h.respondWithError(c, http.StatusNotFound, "NOT_FOUND", "Ingestion o podanym ID nie została znaleziona lub nie należy do użytkownika", nil)
+		case errors.Is(err, services.ErrIngestRunCompleted):
+			h.respondWithError(c, http.StatusConflict, "INGEST_RUN_COMPLETED", "Ingestion została już zakończona", nil)
+		default:
+			span.RecordError(err)
+			logger.Error("failed to cancel ingest run",
 				err,
 				"user_id", userID,
-				"backfill_hours", req.BackfillHours)
-		} else {
-			logger.Info("background ingestion completed successfully",
-				"user_id", userID,
-				"backfill_hours", req.BackfillHours)
+				"run_id", runID,
+				"path", c.Request.URL.Path)
+			h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas anulowania ingestion", nil)
 		}
-	}()
+		return
+	}
 
-	// Return immediate response indicating ingestion was triggered
-	response := dto.TriggerIngestResponseDTO{
-		IngestRunID: runID,
-		Status:      "triggered",
-		StartedAt:   startedAt,
+	response := dto.CancelIngestResponseDTO{
+		IngestRunID:       run.ID,
+		Status:            "cancelling",
+		CancelRequestedAt: run.CancelRequestedAt,
+		CompletedAt:       run.CompletedAt,
+	}
+	if run.CompletedAt != nil {
+		response.Status = run.Status
 	}
 
 	c.JSON(http.StatusAccepted, response)
 }
 
+// GetIngestPolicy handles GET /api/v1/ingest/policy endpoint
+// Returns the content filter applied to the user's ingestion
+func (h *IngestHandler) GetIngestPolicy(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	policy, err := h.ingestPolicyService.GetPolicy(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("failed to get ingest policy",
+			err,
+			"user_id", userID,
+			"path", c.Request.URL.Path)
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas pobierania polityki ingestion", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdateIngestPolicy handles PUT /api/v1/ingest/policy endpoint
+// Replaces the content filter applied to the user's ingestion from the next run on
+func (h *IngestHandler) UpdateIngestPolicy(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	var cmd dto.UpdateIngestPolicyCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, "INVALID_INPUT", "Nieprawidłowe dane wejściowe", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	policy, err := h.ingestPolicyService.UpdatePolicy(ctx, userID, cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidLanguage):
+			h.respondWithError(c, http.StatusBadRequest, "INVALID_LANGUAGE", "Nieprawidłowy kod języka", map[string]interface{}{
+				"field":             "languages",
+				"validation_errors": err.Error(),
+			})
+		case errors.Is(err, services.ErrTooManyLanguages):
+			h.respondWithError(c, http.StatusBadRequest, "TOO_MANY_LANGUAGES", "Lista języków może zawierać maksymalnie 20 pozycji", map[string]interface{}{
+				"field":     "languages",
+				"max_items": services.MaxPolicyLanguages,
+			})
+		default:
+			span.RecordError(err)
+			logger.Error("failed to update ingest policy",
+				err,
+				"user_id", userID,
+				"path", c.Request.URL.Path)
+			h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas zapisywania polityki ingestion", nil)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// respondIngestInProgress sends a 409 Conflict response including the run that is in progress (if still known)
+func (h *IngestHandler) respondIngestInProgress(c *gin.Context, userID uuid.UUID) {
+	var details map[string]interface{}
+
+	status, err := h.ingestStatusService.GetIngestStatus(c.Request.Context(), userID, 1)
+	if err != nil {
+		logger.Warn("failed to fetch current ingest run for conflict response",
+			"error", err,
+			"user_id", userID)
+	} else if status.CurrentRun != nil {
+		details = map[string]interface{}{
+			"current_run_id": status.CurrentRun.ID,
+			"started_at":     status.CurrentRun.StartedAt,
+		}
+	}
+
+	h.respondWithError(c, http.StatusConflict, "INGEST_IN_PROGRESS", "Ingestion już trwa dla tego użytkownika", details)
+}
+
 // respondWithError sends a standardized error response
 func (h *IngestHandler) respondWithError(c *gin.Context, statusCode int, code, message string, details map[string]interface{}) {
 	response := dto.ErrorResponseDTO{
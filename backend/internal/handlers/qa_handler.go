@@ -80,6 +80,11 @@ func (h *QAHandler) CreateQA(c *gin.Context) {
 					})
 					return
 				}
+			case "Source":
+				h.respondWithError(c, http.StatusBadRequest, "INVALID_SOURCE", "Źródło musi mieć wartość feed lub mention", map[string]interface{}{
+					"field": "source",
+				})
+				return
 			}
 		}
 
@@ -110,6 +115,14 @@ func (h *QAHandler) CreateQA(c *gin.Context) {
 		return
 	}
 
+	// Validate list scope
+	if cmd.ListID != nil && *cmd.ListID <= 0 {
+		h.respondWithError(c, http.StatusBadRequest, "INVALID_LIST_ID", "Nieprawidłowy identyfikator listy", map[string]interface{}{
+			"list_id": *cmd.ListID,
+		})
+		return
+	}
+
 	span.SetAttributes(
 		attribute.String("date_from", dateFrom.Format(time.RFC3339)),
 		attribute.String("date_to", dateTo.Format(time.RFC3339)),
@@ -117,7 +130,7 @@ func (h *QAHandler) CreateQA(c *gin.Context) {
 	)
 
 	// Call service layer to create Q&A
-	qaDetail, err := h.qaService.CreateQA(ctx, userID, cmd.Question, *dateFrom, *dateTo)
+	qaDetail, err := h.qaService.CreateQA(ctx, userID, cmd.Question, *dateFrom, *dateTo, cmd.ListID, cmd.Source)
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
@@ -342,6 +355,8 @@ func (h *QAHandler) handleServiceError(c *gin.Context, err error) {
 		h.respondWithError(c, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Usługa LLM jest tymczasowo niedostępna", nil)
 	case services.ErrRateLimitExceeded:
 		h.respondWithError(c, http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED", "Przekroczono limit zapytań. Spróbuj ponownie później", nil)
+	case services.ErrFeedListNotFound:
+		h.respondWithError(c, http.StatusNotFound, "LIST_NOT_FOUND", "Lista nie jest zarejestrowana jako źródło", nil)
 	default:
 		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd serwera. Spróbuj ponownie później", nil)
 	}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/services"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SearchSourceHandler handles requests for saved search queries used as feed sources
+type SearchSourceHandler struct {
+	searchSourceService *services.SearchSourceService
+	validator           *validator.Validate
+}
+
+// NewSearchSourceHandler creates a new SearchSourceHandler instance
+func NewSearchSourceHandler(searchSourceService *services.SearchSourceService) *SearchSourceHandler {
+	return &SearchSourceHandler{
+		searchSourceService: searchSourceService,
+		validator:           validator.New(),
+	}
+}
+
+// GetSearchSources handles GET /api/v1/search-sources endpoint
+func (h *SearchSourceHandler) GetSearchSources(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	response, err := h.searchSourceService.GetSearchSources(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas pobierania wyszukiwań", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AddSearchSource handles POST /api/v1/search-sources endpoint
+// The query first runs at the next ingestion run
+func (h *SearchSourceHandler) AddSearchSource(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	var cmd dto.AddSearchSourceCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, "INVALID_INPUT", "Nieprawidłowe dane wejściowe", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(cmd); err != nil {
+		h.respondWithError(c, http.StatusBadRequest, "INVALID_INPUT", "Nieprawidłowe dane wejściowe", map[string]interface{}{
+			"validation_errors": err.Error(),
+		})
+		return
+	}
+
+	source, err := h.searchSourceService.AddSearchSource(ctx, userID, cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEmptySearchQuery):
+			h.respondWithError(c, http.StatusBadRequest, "INVALID_INPUT", "Zapytanie wyszukiwania nie może być puste", nil)
+		case errors.Is(err, services.ErrSearchSourceExists):
+			h.respondWithError(c, http.StatusConflict, "ALREADY_EXISTS", "To wyszukiwanie jest już zapisane jako źródło", nil)
+		case errors.Is(err, services.ErrTooManySearchSources):
+			h.respondWithError(c, http.StatusBadRequest, "TOO_MANY_SEARCH_SOURCES", "Można zapisać maksymalnie 10 wyszukiwań", map[string]interface{}{
+				"max_items": services.MaxSearchSources,
+			})
+		default:
+			span.RecordError(err)
+			h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas zapisywania wyszukiwania", nil)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, source)
+}
+
+// RemoveSearchSource handles DELETE /api/v1/search-sources/:id endpoint
+// Posts found by the search are kept
+func (h *SearchSourceHandler) RemoveSearchSource(c *gin.Context) {
+	ctx := c.Request.Context()
+	span := trace.SpanFromContext(ctx)
+
+	// Extract user_id from context (set by auth middleware)
+	userIDValue, exists := c.Get("user_id")
+	if !exists {
+		h.respondWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", "Nieprawidłowy lub wygasły token sesji", nil)
+		return
+	}
+
+	userID, ok := userIDValue.(uuid.UUID)
+	if !ok {
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Invalid user ID format", nil)
+		return
+	}
+
+	// Extract search source ID from URL parameter
+	sourceID := c.Param("id")
+	if sourceID == "" {
+		h.respondWithError(c, http.StatusBadRequest, "MISSING_ID", "Brak identyfikatora wyszukiwania", nil)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("search_source_id", sourceID),
+	)
+
+	err := h.searchSourceService.RemoveSearchSource(ctx, userID, sourceID)
+	if err != nil {
+		if errors.Is(err, services.ErrSearchSourceNotFound) {
+			h.respondWithError(c, http.StatusNotFound, "NOT_FOUND", "Wyszukiwanie o podanym ID nie zostało znalezione", nil)
+			return
+		}
+		span.RecordError(err)
+		h.respondWithError(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", "Wystąpił błąd podczas usuwania wyszukiwania", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponseDTO{
+		Message: "Wyszukiwanie usunięte pomyślnie",
+	})
+}
+
+// respondWithError sends a standardized error response
+func (h *SearchSourceHandler) respondWithError(c *gin.Context, statusCode int, code, message string, details map[string]interface{}) {
+	response := dto.ErrorResponseDTO{
+		Error: dto.ErrorDetailDTO{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	}
+	c.JSON(statusCode, response)
+}
@@ -77,7 +77,8 @@ func (r *AuthorRepository) GetAuthorByHandle(ctx context.Context, handle string)
 	return &author, nil
 }
 
-// InsertAuthor inserts a new author
+// InsertAuthor inserts a new author, or updates the handle and display name of a known one
+// A known last_seen_at is kept unless userDTO carries one
 func (r *AuthorRepository) InsertAuthor(ctx context.Context, userDTO *dto.UserDTO) (int64, error) {
 	ctx, span := authorRepoTracer.Start(ctx, "InsertAuthor")
 	defer span.End()
@@ -93,7 +94,7 @@ func (r *AuthorRepository) InsertAuthor(ctx context.Context, userDTO *dto.UserDT
 		ON CONFLICT (x_author_id) DO UPDATE SET
 			handle = EXCLUDED.handle,
 			display_name = EXCLUDED.display_name,
-			last_seen_at = EXCLUDED.last_seen_at
+			last_seen_at = COALESCE(EXCLUDED.last_seen_at, authors.last_seen_at)
 		RETURNING x_author_id
 	`
 
@@ -114,6 +115,7 @@ func (r *AuthorRepository) InsertAuthor(ctx context.Context, userDTO *dto.UserDT
 }
 
 // UpdateAuthorLastSeen updates the last seen timestamp for an author
+// The timestamp only moves forward, so a run reading older tweets does not make the author look less active
 func (r *AuthorRepository) UpdateAuthorLastSeen(ctx context.Context, authorID int64, lastSeenAt interface{}) error {
 	ctx, span := authorRepoTracer.Start(ctx, "UpdateAuthorLastSeen")
 	defer span.End()
@@ -122,7 +124,7 @@ func (r *AuthorRepository) UpdateAuthorLastSeen(ctx context.Context, authorID in
 
 	query := `
 		UPDATE authors
-		SET last_seen_at = $2
+		SET last_seen_at = GREATEST(last_seen_at, $2)
 		WHERE x_author_id = $1
 	`
 
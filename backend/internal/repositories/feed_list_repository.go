@@ -0,0 +1,361 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var feedListRepoTracer = otel.Tracer("feed_list_repository")
+
+// FeedListRepository handles feed_lists, feed_list_members and post_lists data access operations
+type FeedListRepository struct {
+	db *sqlx.DB
+}
+
+// NewFeedListRepository creates a new FeedListRepository instance
+func NewFeedListRepository(database *sqlx.DB) *FeedListRepository {
+	return &FeedListRepository{
+		db: database,
+	}
+}
+
+// GetFeedLists retrieves the lists the user registered as feed sources, with their member counts
+// and how many members are left out by the user's list author limit
+// Returns items ordered by created_at ASC
+func (r *FeedListRepository) GetFeedLists(ctx context.Context, userID uuid.UUID) ([]db.FeedListItem, error) {
+	ctx, span := feedListRepoTracer.Start(ctx, "GetFeedLists")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	query := `
+		SELECT
+			fl.user_id,
+			fl.x_list_id,
+			fl.name,
+			fl.created_at,
+			fl.last_synced_at,
+			COUNT(flm.x_author_id) AS member_count,
+			COUNT(flm.x_author_id) FILTER (WHERE flm.excluded_at IS NOT NULL) AS excluded_member_count
+		FROM feed_lists fl
+		LEFT JOIN feed_list_members flm ON flm.user_id = fl.user_id AND flm.x_list_id = fl.x_list_id
+		WHERE fl.user_id = $1
+		GROUP BY fl.user_id, fl.x_list_id, fl.name, fl.created_at, fl.last_synced_at
+		ORDER BY fl.created_at ASC, fl.x_list_id ASC
+	`
+
+	var items []db.FeedListItem
+	err := r.db.SelectContext(ctx, &items, query, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch feed lists: %w", err)
+	}
+
+	// Return empty slice if no items found (not an error)
+	if items == nil {
+		items = []db.FeedListItem{}
+	}
+
+	span.SetAttributes(attribute.Int("items_found", len(items)))
+
+	return items, nil
+}
+
+// FeedListExists checks if the user registered a list as a feed source
+func (r *FeedListRepository) FeedListExists(ctx context.Context, userID uuid.UUID, listID int64) (bool, error) {
+	ctx, span := feedListRepoTracer.Start(ctx, "FeedListExists")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("list_id", listID),
+	)
+
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM feed_lists
+			WHERE user_id = $1 AND x_list_id = $2
+		)
+	`
+
+	var exists bool
+	err := r.db.GetContext(ctx, &exists, query, userID, listID)
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to check if feed list exists: %w", err)
+	}
+
+	return exists, nil
+}
+
+// InsertFeedList registers a list as a feed source
+// Returns nil if the user already registered the list
+func (r *FeedListRepository) InsertFeedList(ctx context.Context, userID uuid.UUID, listID int64, name *string) (*db.FeedList, error) {
+	ctx, span := feedListRepoTracer.Start(ctx, "InsertFeedList")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("list_id", listID),
+	)
+
+	query := `
+		INSERT INTO feed_lists (user_id, x_list_id, name)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, x_list_id) DO NOTHING
+		RETURNING user_id, x_list_id, name, created_at, last_synced_at
+	`
+
+	var list db.FeedList
+	err := r.db.GetContext(ctx, &list, query, userID, listID, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to insert feed list: %w", err)
+	}
+
+	return &list, nil
+}
+
+// DeleteFeedList removes a list with its members and post tags; the posts are kept
+// Returns false if the user did not register the list
+func (r *FeedListRepository) DeleteFeedList(ctx context.Context, userID uuid.UUID, listID int64) (bool, error) {
+	ctx, span := feedListRepoTracer.Start(ctx, "DeleteFeedList")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("list_id", listID),
+	)
+
+	query := `
+		DELETE FROM feed_lists
+		WHERE user_id = $1 AND x_list_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, listID)
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to delete feed list: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// SyncListMembers stores the members of a list read from X and marks the list as synced
+// Members missing from authorIDs are removed only when complete is true, so a partial read never drops members
+func (r *FeedListRepository) SyncListMembers(ctx context.Context, userID uuid.UUID, listID int64, authorIDs []int64, complete bool, syncedAt time.Time) error {
+	ctx, span := feedListRepoTracer.Start(ctx, "SyncListMembers")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("list_id", listID),
+		attribute.Int("members_count", len(authorIDs)),
+		attribute.Bool("complete", complete),
+	)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if complete {
+		removeQuery := `
+			DELETE FROM feed_list_members
+			WHERE user_id = $1 AND x_list_id = $2
+			  AND NOT (x_author_id = ANY($3))
+		`
+		if _, err := tx.ExecContext(ctx, removeQuery, userID, listID, pq.Array(authorIDs)); err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to remove list members: %w", err)
+		}
+	}
+
+	addQuery := `
+		INSERT INTO feed_list_members (user_id, x_list_id, x_author_id, added_at)
+		SELECT $1, $2, author_id, $4
+		FROM unnest($3::bigint[]) AS author_id
+		ON CONFLICT (user_id, x_list_id, x_author_id) DO NOTHING
+	`
+	if _, err := tx.ExecContext(ctx, addQuery, userID, listID, pq.Array(authorIDs), syncedAt); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to add list members: %w", err)
+	}
+
+	syncedQuery := `
+		UPDATE feed_lists
+		SET last_synced_at = $3
+		WHERE user_id = $1 AND x_list_id = $2
+	`
+	if _, err := tx.ExecContext(ctx, syncedQuery, userID, listID, syncedAt); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to mark feed list synced: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyListAuthorLimit marks the user's listed authors beyond limit as excluded and clears the mark on the rest
+// Only authors the user does not follow within the following limit count against it, so ApplyFollowingLimit
+// must run first. They are ranked by activity (most recent authors.last_seen_at, authors without ingested tweets
+// last); the last sampleSlots places under the limit rotate among the authors ranked there or below, least
+// recently sampled first, so excluded authors get ingested now and then and can rank back in.
+// An author in several lists is marked on each of its member rows
+// Returns the number of excluded authors
+func (r *FeedListRepository) ApplyListAuthorLimit(ctx context.Context, userID uuid.UUID, limit int, sampleSlots int, excludedAt time.Time) (int, error) {
+	ctx, span := feedListRepoTracer.Start(ctx, "ApplyListAuthorLimit")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("limit", limit),
+		attribute.Int("sample_slots", sampleSlots),
+	)
+
+	sampleSlots = max(min(sampleSlots, limit), 0)
+
+	// excluded_at keeps the time the author was first left out
+	query := `
+		WITH ranked AS (
+			SELECT
+				flm.x_author_id,
+				MAX(flm.sampled_at) AS sampled_at,
+				ROW_NUMBER() OVER (
+					ORDER BY a.last_seen_at DESC NULLS LAST, flm.x_author_id DESC
+				) AS rank
+			FROM feed_list_members flm
+			INNER JOIN authors a ON flm.x_author_id = a.x_author_id
+			WHERE flm.user_id = $1
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM user_following uf
+				WHERE uf.user_id = $1 AND uf.x_author_id = flm.x_author_id
+				  AND uf.unfollowed_at IS NULL AND uf.excluded_at IS NULL
+			  )
+			GROUP BY flm.x_author_id, a.last_seen_at
+		), sampled AS (
+			SELECT x_author_id
+			FROM ranked
+			WHERE rank > $2::int - $3::int
+			ORDER BY sampled_at ASC NULLS FIRST, rank
+			LIMIT $3
+		), excluded AS (
+			SELECT x_author_id
+			FROM ranked
+			WHERE rank > $2::int - $3::int
+			  AND x_author_id NOT IN (SELECT x_author_id FROM sampled)
+		), updated AS (
+			UPDATE feed_list_members flm
+			SET excluded_at = CASE
+					WHEN flm.x_author_id IN (SELECT x_author_id FROM excluded) THEN COALESCE(flm.excluded_at, $4)
+					ELSE NULL
+				END,
+				sampled_at = CASE
+					WHEN flm.x_author_id IN (SELECT x_author_id FROM sampled) THEN $4
+					ELSE flm.sampled_at
+				END
+			WHERE flm.user_id = $1
+			RETURNING flm.x_author_id, flm.excluded_at
+		)
+		SELECT COUNT(DISTINCT x_author_id) FROM updated WHERE excluded_at IS NOT NULL
+	`
+
+	var excluded int
+	err := r.db.GetContext(ctx, &excluded, query, userID, limit, sampleSlots, excludedAt)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to apply list author limit: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("excluded_count", excluded))
+
+	return excluded, nil
+}
+
+// GetListAuthors retrieves the authors ingested through the user's lists, with the lists each belongs to
+// Authors excluded by the user's list author limit are left out
+// Returns items ordered by x_author_id DESC
+func (r *FeedListRepository) GetListAuthors(ctx context.Context, userID uuid.UUID) ([]db.ListAuthor, error) {
+	ctx, span := feedListRepoTracer.Start(ctx, "GetListAuthors")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	query := `
+		SELECT x_author_id, array_agg(x_list_id ORDER BY x_list_id) AS list_ids
+		FROM feed_list_members
+		WHERE user_id = $1 AND excluded_at IS NULL
+		GROUP BY x_author_id
+		ORDER BY x_author_id DESC
+	`
+
+	var items []db.ListAuthor
+	err := r.db.SelectContext(ctx, &items, query, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch list authors: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("authors_found", len(items)))
+
+	return items, nil
+}
+
+// TagPost records the lists a post was ingested through
+func (r *FeedListRepository) TagPost(ctx context.Context, userID uuid.UUID, postID int64, listIDs []int64) error {
+	ctx, span := feedListRepoTracer.Start(ctx, "TagPost")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("post_id", postID),
+		attribute.Int("lists_count", len(listIDs)),
+	)
+
+	if len(listIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO post_lists (user_id, x_post_id, x_list_id)
+		SELECT $1, $2, list_id
+		FROM unnest($3::bigint[]) AS list_id
+		ON CONFLICT (user_id, x_list_id, x_post_id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, postID, pq.Array(listIDs))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to tag post with lists: %w", err)
+	}
+
+	return nil
+}
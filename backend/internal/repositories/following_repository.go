@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/sopeal/AskYourFeed/internal/db"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -26,7 +27,7 @@ func NewFollowingRepository(database *sqlx.DB) *FollowingRepository {
 	}
 }
 
-// GetFollowing retrieves list of authors the user follows
+// GetFollowing retrieves list of authors the user follows, including authors over the following limit
 // Returns items ordered by x_author_id DESC
 func (r *FollowingRepository) GetFollowing(ctx context.Context, userID uuid.UUID) ([]db.FollowingItem, error) {
 	ctx, span := followingRepoTracer.Start(ctx, "GetFollowing")
@@ -42,10 +43,12 @@ func (r *FollowingRepository) GetFollowing(ctx context.Context, userID uuid.UUID
 			a.handle,
 			a.display_name,
 			a.last_seen_at,
-			uf.last_checked_at
+			uf.last_checked_at,
+			uf.pinned,
+			uf.excluded_at
 		FROM user_following uf
 		INNER JOIN authors a ON uf.x_author_id = a.x_author_id
-		WHERE uf.user_id = $1
+		WHERE uf.user_id = $1 AND uf.unfollowed_at IS NULL
 		ORDER BY a.x_author_id DESC
 	`
 	args := []interface{}{userID}
@@ -67,8 +70,9 @@ func (r *FollowingRepository) GetFollowing(ctx context.Context, userID uuid.UUID
 	return items, nil
 }
 
-// UpsertFollowing inserts or updates a following relationship
-func (r *FollowingRepository) UpsertFollowing(ctx context.Context, userID uuid.UUID, authorID int64, lastCheckedAt time.Time) error {
+// UpsertFollowing inserts or updates a following relationship, reactivating it if it was unfollowed
+// Returns true if the author was not followed before (new or previously unfollowed)
+func (r *FollowingRepository) UpsertFollowing(ctx context.Context, userID uuid.UUID, authorID int64, lastCheckedAt time.Time) (bool, error) {
 	ctx, span := followingRepoTracer.Start(ctx, "UpsertFollowing")
 	defer span.End()
 
@@ -77,22 +81,232 @@ func (r *FollowingRepository) UpsertFollowing(ctx context.Context, userID uuid.U
 		attribute.Int64("author_id", authorID),
 	)
 
+	// prev sees the row as it was before the upsert
 	query := `
+		WITH prev AS (
+			SELECT unfollowed_at FROM user_following
+			WHERE user_id = $1 AND x_author_id = $2
+		)
 		INSERT INTO user_following (user_id, x_author_id, last_checked_at)
 		VALUES ($1, $2, $3)
 		ON CONFLICT (user_id, x_author_id) DO UPDATE SET
-			last_checked_at = EXCLUDED.last_checked_at
+			last_checked_at = EXCLUDED.last_checked_at,
+			unfollowed_at = NULL
+		RETURNING NOT EXISTS (SELECT 1 FROM prev WHERE unfollowed_at IS NULL)
 	`
 
-	_, err := r.db.ExecContext(ctx, query, userID, authorID, lastCheckedAt)
+	var followed bool
+	err := r.db.GetContext(ctx, &followed, query, userID, authorID, lastCheckedAt)
 	if err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("failed to upsert following: %w", err)
+		return false, fmt.Errorf("failed to upsert following: %w", err)
+	}
+
+	span.SetAttributes(attribute.Bool("followed", followed))
+
+	return followed, nil
+}
+
+// ApplyFollowingLimit marks the user's followed authors beyond limit as excluded and clears the mark on the rest
+// Authors are ranked pinned first, then by activity (most recent authors.last_seen_at, authors without ingested
+// tweets last). The last sampleSlots places under the limit rotate among the authors ranked there or below,
+// pinned first and then least recently sampled, so excluded authors get ingested now and then and can rank back in
+// Returns the number of excluded authors
+func (r *FollowingRepository) ApplyFollowingLimit(ctx context.Context, userID uuid.UUID, limit int, sampleSlots int, excludedAt time.Time) (int, error) {
+	ctx, span := followingRepoTracer.Start(ctx, "ApplyFollowingLimit")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("limit", limit),
+		attribute.Int("sample_slots", sampleSlots),
+	)
+
+	sampleSlots = max(min(sampleSlots, limit), 0)
+
+	// excluded_at keeps the time the author was first left out
+	query := `
+		WITH ranked AS (
+			SELECT
+				uf.x_author_id,
+				uf.pinned,
+				uf.sampled_at,
+				ROW_NUMBER() OVER (
+					ORDER BY uf.pinned DESC, a.last_seen_at DESC NULLS LAST, uf.x_author_id DESC
+				) AS rank
+			FROM user_following uf
+			INNER JOIN authors a ON uf.x_author_id = a.x_author_id
+			WHERE uf.user_id = $1 AND uf.unfollowed_at IS NULL
+		), sampled AS (
+			SELECT x_author_id
+			FROM ranked
+			WHERE rank > $2::int - $3::int
+			ORDER BY pinned DESC, sampled_at ASC NULLS FIRST, rank
+			LIMIT $3
+		), updated AS (
+			UPDATE user_following uf
+			SET excluded_at = CASE
+					WHEN ranked.rank <= $2::int - $3::int OR sampled.x_author_id IS NOT NULL THEN NULL
+					ELSE COALESCE(uf.excluded_at, $4)
+				END,
+				sampled_at = CASE WHEN sampled.x_author_id IS NOT NULL THEN $4 ELSE uf.sampled_at END
+			FROM ranked
+			LEFT JOIN sampled ON sampled.x_author_id = ranked.x_author_id
+			WHERE uf.user_id = $1 AND uf.x_author_id = ranked.x_author_id
+			RETURNING uf.excluded_at
+		)
+		SELECT COUNT(*) FROM updated WHERE excluded_at IS NOT NULL
+	`
+
+	var excluded int
+	err := r.db.GetContext(ctx, &excluded, query, userID, limit, sampleSlots, excludedAt)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to apply following limit: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("excluded_count", excluded))
+
+	return excluded, nil
+}
+
+// SetFollowingPinned pins or unpins a followed author
+// Returns false if the user does not follow the author
+func (r *FollowingRepository) SetFollowingPinned(ctx context.Context, userID uuid.UUID, authorID int64, pinned bool) (bool, error) {
+	ctx, span := followingRepoTracer.Start(ctx, "SetFollowingPinned")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("author_id", authorID),
+		attribute.Bool("pinned", pinned),
+	)
+
+	query := `
+		UPDATE user_following
+		SET pinned = $3
+		WHERE user_id = $1 AND x_author_id = $2 AND unfollowed_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, authorID, pinned)
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to set following pinned: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeactivateUnfollowed soft-deactivates the user's followed authors that are not in activeAuthorIDs
+// Returns the IDs of the authors that were deactivated
+func (r *FollowingRepository) DeactivateUnfollowed(ctx context.Context, userID uuid.UUID, activeAuthorIDs []int64, unfollowedAt time.Time) ([]int64, error) {
+	ctx, span := followingRepoTracer.Start(ctx, "DeactivateUnfollowed")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("active_count", len(activeAuthorIDs)),
+	)
+
+	query := `
+		UPDATE user_following
+		SET unfollowed_at = $3
+		WHERE user_id = $1 AND unfollowed_at IS NULL
+		  AND NOT (x_author_id = ANY($2))
+		RETURNING x_author_id
+	`
+
+	var authorIDs []int64
+	err := r.db.SelectContext(ctx, &authorIDs, query, userID, pq.Array(activeAuthorIDs), unfollowedAt)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to deactivate unfollowed authors: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("deactivated_count", len(authorIDs)))
+
+	return authorIDs, nil
+}
+
+// InsertFollowingEvents records follow or unfollow events for the given authors
+func (r *FollowingRepository) InsertFollowingEvents(ctx context.Context, userID uuid.UUID, runID string, event string, authorIDs []int64) error {
+	ctx, span := followingRepoTracer.Start(ctx, "InsertFollowingEvents")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("run_id", runID),
+		attribute.String("event", event),
+		attribute.Int("authors_count", len(authorIDs)),
+	)
+
+	if len(authorIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO following_events (user_id, x_author_id, event, run_id)
+		SELECT $1, author_id, $3, $4
+		FROM unnest($2::bigint[]) AS author_id
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, pq.Array(authorIDs), event, runID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to insert following events: %w", err)
 	}
 
 	return nil
 }
 
+// GetFollowingEvents retrieves the user's most recent follow/unfollow events
+// Returns items ordered by occurred_at DESC
+func (r *FollowingRepository) GetFollowingEvents(ctx context.Context, userID uuid.UUID, limit int) ([]db.FollowingEventItem, error) {
+	ctx, span := followingRepoTracer.Start(ctx, "GetFollowingEvents")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("limit", limit),
+	)
+
+	query := `
+		SELECT
+			fe.x_author_id,
+			a.handle,
+			a.display_name,
+			fe.event,
+			fe.run_id,
+			fe.occurred_at
+		FROM following_events fe
+		INNER JOIN authors a ON fe.x_author_id = a.x_author_id
+		WHERE fe.user_id = $1
+		ORDER BY fe.occurred_at DESC, fe.id DESC
+		LIMIT $2
+	`
+
+	var items []db.FollowingEventItem
+	err := r.db.SelectContext(ctx, &items, query, userID, limit)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch following events: %w", err)
+	}
+
+	if items == nil {
+		items = []db.FollowingEventItem{}
+	}
+
+	span.SetAttributes(attribute.Int("items_found", len(items)))
+
+	return items, nil
+}
+
 // RemoveFollowing removes a following relationship
 func (r *FollowingRepository) RemoveFollowing(ctx context.Context, userID uuid.UUID, authorID int64) error {
 	ctx, span := followingRepoTracer.Start(ctx, "RemoveFollowing")
@@ -127,6 +341,39 @@ func (r *FollowingRepository) RemoveFollowing(ctx context.Context, userID uuid.U
 	return nil
 }
 
+// GetSharedAuthorIDs retrieves authors ingested for more than one user, through followings or lists
+// Runs across all users; used to plan fetches of timelines shared between users
+func (r *FollowingRepository) GetSharedAuthorIDs(ctx context.Context) ([]int64, error) {
+	ctx, span := followingRepoTracer.Start(ctx, "GetSharedAuthorIDs")
+	defer span.End()
+
+	query := `
+		SELECT x_author_id
+		FROM (
+			SELECT user_id, x_author_id
+			FROM user_following
+			WHERE unfollowed_at IS NULL AND excluded_at IS NULL
+			UNION
+			SELECT user_id, x_author_id
+			FROM feed_list_members
+			WHERE excluded_at IS NULL
+		) ingested
+		GROUP BY x_author_id
+		HAVING COUNT(*) > 1
+	`
+
+	var authorIDs []int64
+	err := r.db.SelectContext(ctx, &authorIDs, query)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch shared authors: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("shared_authors_count", len(authorIDs)))
+
+	return authorIDs, nil
+}
+
 // GetTotalFollowingCount retrieves total count of authors the user follows
 func (r *FollowingRepository) GetTotalFollowingCount(ctx context.Context, userID uuid.UUID) (int, error) {
 	ctx, span := followingRepoTracer.Start(ctx, "GetTotalFollowingCount")
@@ -137,7 +384,7 @@ func (r *FollowingRepository) GetTotalFollowingCount(ctx context.Context, userID
 	query := `
 		SELECT COUNT(*)
 		FROM user_following
-		WHERE user_id = $1
+		WHERE user_id = $1 AND unfollowed_at IS NULL
 	`
 
 	var count int
@@ -0,0 +1,292 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var ingestJobRepoTracer = otel.Tracer("ingest_job_repository")
+
+// Common ingest job repository errors
+var (
+	ErrIngestJobLeaseLost = errors.New("ingest job lease lost")
+)
+
+// ingestJobColumns lists the columns selected for db.IngestJob
+const ingestJobColumns = `id, user_id, run_id, backfill_hours, status, attempts, max_attempts, run_after,
+		       locked_by, locked_until, last_error, created_at, updated_at`
+
+// IngestJobRepository handles ingest_jobs data access operations
+type IngestJobRepository struct {
+	db *sqlx.DB
+}
+
+// NewIngestJobRepository creates a new IngestJobRepository instance
+func NewIngestJobRepository(database *sqlx.DB) *IngestJobRepository {
+	return &IngestJobRepository{
+		db: database,
+	}
+}
+
+// EnqueueJob inserts a new queued job for a user that executes the given ingest run
+// If the user already has a queued job, it is pointed at the new run and made due immediately.
+// If the user has a running job, that job is returned unchanged. In both cases created is false
+func (r *IngestJobRepository) EnqueueJob(ctx context.Context, jobID string, userID uuid.UUID, runID string, backfillHours int, maxAttempts int) (*db.IngestJob, bool, error) {
+	ctx, span := ingestJobRepoTracer.Start(ctx, "EnqueueJob")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("job_id", jobID),
+		attribute.String("user_id", userID.String()),
+		attribute.String("run_id", runID),
+		attribute.Int("backfill_hours", backfillHours),
+	)
+
+	query := `
+		INSERT INTO ingest_jobs (id, user_id, run_id, backfill_hours, status, attempts, max_attempts, run_after, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, 'queued', 0, $5, NOW(), NOW(), NOW())
+		ON CONFLICT (user_id) WHERE status IN ('queued','running')
+		DO UPDATE SET run_id = EXCLUDED.run_id,
+		              backfill_hours = GREATEST(ingest_jobs.backfill_hours, EXCLUDED.backfill_hours),
+		              run_after = NOW(),
+		              updated_at = NOW()
+		WHERE ingest_jobs.status = 'queued'
+		RETURNING ` + ingestJobColumns
+
+	var job db.IngestJob
+	err := r.db.GetContext(ctx, &job, query, jobID, userID, runID, backfillHours, maxAttempts)
+	if err == nil {
+		created := job.ID == jobID
+		span.SetAttributes(attribute.Bool("created", created))
+		return &job, created, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		return nil, false, fmt.Errorf("failed to enqueue ingest job: %w", err)
+	}
+
+	// Insert was skipped because a job is already running - return it instead
+	pendingQuery := `
+		SELECT ` + ingestJobColumns + `
+		FROM ingest_jobs
+		WHERE user_id = $1 AND status IN ('queued','running')
+	`
+
+	err = r.db.GetContext(ctx, &job, pendingQuery, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, false, fmt.Errorf("failed to fetch pending ingest job: %w", err)
+	}
+
+	span.SetAttributes(attribute.Bool("created", false))
+	return &job, false, nil
+}
+
+// ClaimJob atomically claims the next available job for a worker
+// A job is available when it is queued and due, or running with an expired lease (abandoned by another worker)
+// Returns nil if no job is available
+func (r *IngestJobRepository) ClaimJob(ctx context.Context, workerID string, lease time.Duration) (*db.IngestJob, error) {
+	ctx, span := ingestJobRepoTracer.Start(ctx, "ClaimJob")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("worker_id", workerID))
+
+	query := `
+		UPDATE ingest_jobs
+		SET status = 'running',
+		    attempts = attempts + 1,
+		    locked_by = $1,
+		    locked_until = NOW() + make_interval(secs => $2),
+		    updated_at = NOW()
+		WHERE id = (
+			SELECT id
+			FROM ingest_jobs
+			WHERE (status = 'queued' AND run_after <= NOW())
+			   OR (status = 'running' AND locked_until < NOW())
+			ORDER BY run_after ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + ingestJobColumns
+
+	var job db.IngestJob
+	err := r.db.GetContext(ctx, &job, query, workerID, lease.Seconds())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// No job available - this is not an error
+			return nil, nil
+		}
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to claim ingest job: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("job_id", job.ID),
+		attribute.Int("attempts", job.Attempts),
+	)
+
+	return &job, nil
+}
+
+// ExtendLease pushes the lease of a running job forward
+// Returns ErrIngestJobLeaseLost if the job is no longer held by the worker
+func (r *IngestJobRepository) ExtendLease(ctx context.Context, jobID string, workerID string, lease time.Duration) error {
+	ctx, span := ingestJobRepoTracer.Start(ctx, "ExtendLease")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("job_id", jobID),
+		attribute.String("worker_id", workerID),
+	)
+
+	query := `
+		UPDATE ingest_jobs
+		SET locked_until = NOW() + make_interval(secs => $3), updated_at = NOW()
+		WHERE id = $1 AND locked_by = $2 AND status = 'running'
+	`
+
+	return r.execHeldJobUpdate(ctx, "extend ingest job lease", query, jobID, workerID, lease.Seconds())
+}
+
+// AttachRun links a job held by the worker to the ingest run it is executing
+func (r *IngestJobRepository) AttachRun(ctx context.Context, jobID string, workerID string, runID string) error {
+	ctx, span := ingestJobRepoTracer.Start(ctx, "AttachRun")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("job_id", jobID),
+		attribute.String("worker_id", workerID),
+		attribute.String("run_id", runID),
+	)
+
+	query := `
+		UPDATE ingest_jobs
+		SET run_id = $3, updated_at = NOW()
+		WHERE id = $1 AND locked_by = $2 AND status = 'running'
+	`
+
+	return r.execHeldJobUpdate(ctx, "attach ingest run to job", query, jobID, workerID, runID)
+}
+
+// CancelQueuedJob marks the queued (not yet claimed) job executing a run as done
+// Returns false if no queued job exists for the run, e.g. because a worker is already executing it
+func (r *IngestJobRepository) CancelQueuedJob(ctx context.Context, runID string) (bool, error) {
+	ctx, span := ingestJobRepoTracer.Start(ctx, "CancelQueuedJob")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("run_id", runID))
+
+	query := `
+		UPDATE ingest_jobs
+		SET status = 'done', last_error = 'cancelled', updated_at = NOW()
+		WHERE run_id = $1 AND status = 'queued'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, runID)
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to cancel queued ingest job: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	span.SetAttributes(attribute.Bool("cancelled", rowsAffected > 0))
+
+	return rowsAffected > 0, nil
+}
+
+// CompleteJob marks a job held by the worker as done
+func (r *IngestJobRepository) CompleteJob(ctx context.Context, jobID string, workerID string) error {
+	ctx, span := ingestJobRepoTracer.Start(ctx, "CompleteJob")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("job_id", jobID),
+		attribute.String("worker_id", workerID),
+	)
+
+	query := `
+		UPDATE ingest_jobs
+		SET status = 'done', locked_by = NULL, locked_until = NULL, last_error = NULL, updated_at = NOW()
+		WHERE id = $1 AND locked_by = $2 AND status = 'running'
+	`
+
+	return r.execHeldJobUpdate(ctx, "complete ingest job", query, jobID, workerID)
+}
+
+// RetryJob releases a job held by the worker back to the queue to be retried at retryAt
+func (r *IngestJobRepository) RetryJob(ctx context.Context, jobID string, workerID string, errText string, retryAt time.Time) error {
+	ctx, span := ingestJobRepoTracer.Start(ctx, "RetryJob")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("job_id", jobID),
+		attribute.String("worker_id", workerID),
+		attribute.String("retry_at", retryAt.Format(time.RFC3339)),
+	)
+
+	query := `
+		UPDATE ingest_jobs
+		SET status = 'queued', run_after = $4, last_error = $3,
+		    locked_by = NULL, locked_until = NULL, updated_at = NOW()
+		WHERE id = $1 AND locked_by = $2 AND status = 'running'
+	`
+
+	return r.execHeldJobUpdate(ctx, "retry ingest job", query, jobID, workerID, errText, retryAt)
+}
+
+// FailJob marks a job held by the worker as permanently failed
+func (r *IngestJobRepository) FailJob(ctx context.Context, jobID string, workerID string, errText string) error {
+	ctx, span := ingestJobRepoTracer.Start(ctx, "FailJob")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("job_id", jobID),
+		attribute.String("worker_id", workerID),
+	)
+
+	query := `
+		UPDATE ingest_jobs
+		SET status = 'failed', last_error = $3, locked_by = NULL, locked_until = NULL, updated_at = NOW()
+		WHERE id = $1 AND locked_by = $2 AND status = 'running'
+	`
+
+	return r.execHeldJobUpdate(ctx, "fail ingest job", query, jobID, workerID, errText)
+}
+
+// execHeldJobUpdate executes an update that only applies while the worker still holds the job
+func (r *IngestJobRepository) execHeldJobUpdate(ctx context.Context, action string, query string, args ...interface{}) error {
+	span := trace.SpanFromContext(ctx)
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrIngestJobLeaseLost
+	}
+
+	return nil
+}
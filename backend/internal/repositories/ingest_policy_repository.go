@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var ingestPolicyRepoTracer = otel.Tracer("ingest_policy_repository")
+
+// IngestPolicyRepository handles ingest_policies data access operations
+type IngestPolicyRepository struct {
+	db *sqlx.DB
+}
+
+// NewIngestPolicyRepository creates a new IngestPolicyRepository instance
+func NewIngestPolicyRepository(database *sqlx.DB) *IngestPolicyRepository {
+	return &IngestPolicyRepository{
+		db: database,
+	}
+}
+
+// GetIngestPolicy retrieves the ingest policy of a user
+// Returns nil if the user never changed the defaults
+func (r *IngestPolicyRepository) GetIngestPolicy(ctx context.Context, userID uuid.UUID) (*db.IngestPolicy, error) {
+	ctx, span := ingestPolicyRepoTracer.Start(ctx, "GetIngestPolicy")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	query := `
+		SELECT user_id, include_retweets, include_quotes, include_replies, languages, include_mentions, updated_at
+		FROM ingest_policies
+		WHERE user_id = $1
+	`
+
+	var policy db.IngestPolicy
+	err := r.db.GetContext(ctx, &policy, query, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get ingest policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// UpsertIngestPolicy creates or replaces the ingest policy of a user
+// Returns the stored policy
+func (r *IngestPolicyRepository) UpsertIngestPolicy(ctx context.Context, policy db.IngestPolicy) (*db.IngestPolicy, error) {
+	ctx, span := ingestPolicyRepoTracer.Start(ctx, "UpsertIngestPolicy")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", policy.UserID.String()),
+		attribute.Bool("include_retweets", policy.IncludeRetweets),
+		attribute.Bool("include_quotes", policy.IncludeQuotes),
+		attribute.Bool("include_replies", policy.IncludeReplies),
+		attribute.Int("language_count", len(policy.Languages)),
+		attribute.Bool("include_mentions", policy.IncludeMentions),
+	)
+
+	query := `
+		INSERT INTO ingest_policies (user_id, include_retweets, include_quotes, include_replies, languages, include_mentions, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET include_retweets = EXCLUDED.include_retweets,
+			include_quotes = EXCLUDED.include_quotes,
+			include_replies = EXCLUDED.include_replies,
+			languages = EXCLUDED.languages,
+			include_mentions = EXCLUDED.include_mentions,
+			updated_at = NOW()
+		RETURNING user_id, include_retweets, include_quotes, include_replies, languages, include_mentions, updated_at
+	`
+
+	languages := policy.Languages
+	if languages == nil {
+		languages = pq.StringArray{}
+	}
+
+	var stored db.IngestPolicy
+	err := r.db.GetContext(ctx, &stored, query,
+		policy.UserID,
+		policy.IncludeRetweets,
+		policy.IncludeQuotes,
+		policy.IncludeReplies,
+		languages,
+		policy.IncludeMentions,
+	)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to upsert ingest policy: %w", err)
+	}
+
+	return &stored, nil
+}
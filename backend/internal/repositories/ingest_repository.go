@@ -3,11 +3,13 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/sopeal/AskYourFeed/internal/db"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -15,6 +17,46 @@ import (
 
 var ingestRepoTracer = otel.Tracer("ingest_repository")
 
+// Common ingest repository errors
+var (
+	ErrIngestRunNotFound   = errors.New("ingest run not found")
+	ErrIngestRunCompleted  = errors.New("ingest run already completed")
+	ErrIngestRunInProgress = errors.New("ingest run already in progress")
+)
+
+// uniqueOpenRunIndex is the partial unique index allowing one open ingest run per user
+const uniqueOpenRunIndex = "uq_ingest_runs_user_open"
+
+// ingestRunColumns lists the columns selected for db.IngestRun
+const ingestRunColumns = `id, user_id, started_at, completed_at, status, since_id,
+		       fetched_count, retried, rate_limit_hits, err_text,
+		       phase, following_count, tweets_count, cancel_requested_at, heartbeat_at`
+
+// IngestRunProgress holds the per-phase progress of an ongoing ingest run
+type IngestRunProgress struct {
+	Phase          string // 'following' or 'tweets'
+	FollowingCount int
+	TweetsCount    int
+	Retried        int
+	RateLimitHits  int
+}
+
+// Totals returns the run totals accumulated so far
+func (p IngestRunProgress) Totals() IngestRunTotals {
+	return IngestRunTotals{
+		FetchedCount:  p.FollowingCount + p.TweetsCount,
+		Retried:       p.Retried,
+		RateLimitHits: p.RateLimitHits,
+	}
+}
+
+// IngestRunTotals holds the counters written to an ingest run when it completes
+type IngestRunTotals struct {
+	FetchedCount  int
+	Retried       int
+	RateLimitHits int
+}
+
 // IngestRepository handles ingest_runs data access operations
 type IngestRepository struct {
 	db *sqlx.DB
@@ -66,8 +108,7 @@ func (r *IngestRepository) GetCurrentRun(ctx context.Context, userID uuid.UUID)
 	span.SetAttributes(attribute.String("user_id", userID.String()))
 
 	query := `
-		SELECT id, user_id, started_at, completed_at, status, since_id,
-		       fetched_count, retried, rate_limit_hits, err_text
+		SELECT ` + ingestRunColumns + `
 		FROM ingest_runs
 		WHERE user_id = $1 AND completed_at IS NULL
 		ORDER BY started_at DESC
@@ -88,6 +129,36 @@ func (r *IngestRepository) GetCurrentRun(ctx context.Context, userID uuid.UUID)
 	return &run, nil
 }
 
+// GetRunByID retrieves a single ingest run owned by the user
+// Returns ErrIngestRunNotFound if the run does not exist or belongs to another user
+func (r *IngestRepository) GetRunByID(ctx context.Context, userID uuid.UUID, runID string) (*db.IngestRun, error) {
+	ctx, span := ingestRepoTracer.Start(ctx, "GetRunByID")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("run_id", runID),
+	)
+
+	query := `
+		SELECT ` + ingestRunColumns + `
+		FROM ingest_runs
+		WHERE id = $1 AND user_id = $2
+	`
+
+	var run db.IngestRun
+	err := r.db.GetContext(ctx, &run, query, runID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrIngestRunNotFound
+		}
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch ingest run: %w", err)
+	}
+
+	return &run, nil
+}
+
 // GetRecentRuns retrieves recent completed ingest runs for a user
 // Returns runs ordered by started_at DESC, limited by the limit parameter
 func (r *IngestRepository) GetRecentRuns(ctx context.Context, userID uuid.UUID, limit int) ([]db.IngestRun, error) {
@@ -100,8 +171,7 @@ func (r *IngestRepository) GetRecentRuns(ctx context.Context, userID uuid.UUID,
 	)
 
 	query := `
-		SELECT id, user_id, started_at, completed_at, status, since_id,
-		       fetched_count, retried, rate_limit_hits, err_text
+		SELECT ` + ingestRunColumns + `
 		FROM ingest_runs
 		WHERE user_id = $1 AND completed_at IS NOT NULL
 		ORDER BY started_at DESC
@@ -125,8 +195,43 @@ func (r *IngestRepository) GetRecentRuns(ctx context.Context, userID uuid.UUID,
 	return runs, nil
 }
 
-// CreateIngestRun creates a new ingest run with since_id-based pagination
-func (r *IngestRepository) CreateIngestRun(ctx context.Context, userID uuid.UUID, runID string, sinceID int64) error {
+// GetDailyRateLimitStats aggregates rate limit hits and retries of runs started since the given time
+// Returns one row per UTC day that had runs, ordered by day DESC
+func (r *IngestRepository) GetDailyRateLimitStats(ctx context.Context, userID uuid.UUID, since time.Time) ([]db.IngestRunDailyStats, error) {
+	ctx, span := ingestRepoTracer.Start(ctx, "GetDailyRateLimitStats")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("since", since.Format(time.RFC3339)),
+	)
+
+	query := `
+		SELECT (started_at AT TIME ZONE 'UTC')::date AS day,
+		       COUNT(*) AS runs,
+		       COUNT(*) FILTER (WHERE status = 'rate_limited') AS rate_limited_runs,
+		       COALESCE(SUM(rate_limit_hits), 0) AS rate_limit_hits,
+		       COALESCE(SUM(retried), 0) AS retried
+		FROM ingest_runs
+		WHERE user_id = $1 AND started_at >= $2
+		GROUP BY day
+		ORDER BY day DESC
+	`
+
+	var stats []db.IngestRunDailyStats
+	err := r.db.SelectContext(ctx, &stats, query, userID, since)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get daily rate limit stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// CreateIngestRun creates a new ingest run with since_id-based pagination and returns it
+// Returns ErrIngestRunInProgress if the user already has an open run; the check is enforced by
+// the database, so it holds across concurrent requests and service replicas
+func (r *IngestRepository) CreateIngestRun(ctx context.Context, userID uuid.UUID, runID string, sinceID int64) (*db.IngestRun, error) {
 	ctx, span := ingestRepoTracer.Start(ctx, "CreateIngestRun")
 	defer span.End()
 
@@ -137,36 +242,48 @@ func (r *IngestRepository) CreateIngestRun(ctx context.Context, userID uuid.UUID
 	)
 
 	query := `
-		INSERT INTO ingest_runs (id, user_id, started_at, status, since_id, fetched_count, retried, rate_limit_hits)
-		VALUES ($1, $2, NOW(), 'ok', $3, 0, 0, 0)
-	`
+		INSERT INTO ingest_runs (id, user_id, started_at, status, since_id, fetched_count, retried, rate_limit_hits, heartbeat_at)
+		VALUES ($1, $2, NOW(), 'ok', $3, 0, 0, 0, NOW())
+		RETURNING ` + ingestRunColumns
 
-	_, err := r.db.ExecContext(ctx, query, runID, userID, sinceID)
+	var run db.IngestRun
+	err := r.db.GetContext(ctx, &run, query, runID, userID, sinceID)
 	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == uniqueOpenRunIndex {
+			return nil, ErrIngestRunInProgress
+		}
 		span.RecordError(err)
-		return fmt.Errorf("failed to create ingest run: %w", err)
+		return nil, fmt.Errorf("failed to create ingest run: %w", err)
 	}
 
-	return nil
+	return &run, nil
 }
 
-// UpdateIngestRunProgress updates the fetched count for an ongoing ingest run
-func (r *IngestRepository) UpdateIngestRunProgress(ctx context.Context, runID string, fetchedCount int) error {
+// UpdateIngestRunProgress updates the phase and per-phase counts for an ongoing ingest run
+// fetched_count is kept as the total of both phases
+func (r *IngestRepository) UpdateIngestRunProgress(ctx context.Context, runID string, progress IngestRunProgress) error {
 	ctx, span := ingestRepoTracer.Start(ctx, "UpdateIngestRunProgress")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("run_id", runID),
-		attribute.Int("fetched_count", fetchedCount),
+		attribute.String("phase", progress.Phase),
+		attribute.Int("following_count", progress.FollowingCount),
+		attribute.Int("tweets_count", progress.TweetsCount),
+		attribute.Int("retried", progress.Retried),
+		attribute.Int("rate_limit_hits", progress.RateLimitHits),
 	)
 
 	query := `
 		UPDATE ingest_runs
-		SET fetched_count = $2
+		SET phase = $2, following_count = $3, tweets_count = $4, fetched_count = $3 + $4,
+		    retried = $5, rate_limit_hits = $6, heartbeat_at = NOW()
 		WHERE id = $1
 	`
 
-	result, err := r.db.ExecContext(ctx, query, runID, fetchedCount)
+	result, err := r.db.ExecContext(ctx, query, runID, progress.Phase, progress.FollowingCount, progress.TweetsCount,
+		progress.Retried, progress.RateLimitHits)
 	if err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to update ingest run progress: %w", err)
@@ -185,24 +302,189 @@ func (r *IngestRepository) UpdateIngestRunProgress(ctx context.Context, runID st
 	return nil
 }
 
+// InsertIngestRunError records a non-fatal error that occurred during an ingest run
+func (r *IngestRepository) InsertIngestRunError(ctx context.Context, runID string, userID uuid.UUID, phase string, authorHandle *string, message string) error {
+	ctx, span := ingestRepoTracer.Start(ctx, "InsertIngestRunError")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("run_id", runID),
+		attribute.String("phase", phase),
+	)
+
+	query := `
+		INSERT INTO ingest_run_errors (run_id, user_id, phase, author_handle, message, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+	`
+
+	_, err := r.db.ExecContext(ctx, query, runID, userID, phase, authorHandle, message)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to insert ingest run error: %w", err)
+	}
+
+	return nil
+}
+
+// GetIngestRunErrors retrieves errors recorded for an ingest run, oldest first
+func (r *IngestRepository) GetIngestRunErrors(ctx context.Context, userID uuid.UUID, runID string, limit int) ([]db.IngestRunError, error) {
+	ctx, span := ingestRepoTracer.Start(ctx, "GetIngestRunErrors")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("run_id", runID),
+		attribute.Int("limit", limit),
+	)
+
+	query := `
+		SELECT id, run_id, user_id, phase, author_handle, message, created_at
+		FROM ingest_run_errors
+		WHERE run_id = $1 AND user_id = $2
+		ORDER BY created_at ASC, id ASC
+		LIMIT $3
+	`
+
+	var runErrors []db.IngestRunError
+	err := r.db.SelectContext(ctx, &runErrors, query, runID, userID, limit)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch ingest run errors: %w", err)
+	}
+
+	if runErrors == nil {
+		runErrors = []db.IngestRunError{}
+	}
+
+	span.SetAttributes(attribute.Int("errors_found", len(runErrors)))
+
+	return runErrors, nil
+}
+
+// RequestCancel flags an in-progress ingest run of the user for cancellation and returns it
+// Returns ErrIngestRunNotFound if the run does not exist or belongs to another user,
+// and ErrIngestRunCompleted if the run has already finished
+func (r *IngestRepository) RequestCancel(ctx context.Context, userID uuid.UUID, runID string) (*db.IngestRun, error) {
+	ctx, span := ingestRepoTracer.Start(ctx, "RequestCancel")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("run_id", runID),
+	)
+
+	query := `
+		UPDATE ingest_runs
+		SET cancel_requested_at = COALESCE(cancel_requested_at, NOW())
+		WHERE id = $1 AND user_id = $2 AND completed_at IS NULL
+		RETURNING ` + ingestRunColumns
+
+	var run db.IngestRun
+	err := r.db.GetContext(ctx, &run, query, runID, userID)
+	if err == nil {
+		return &run, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to request ingest run cancellation: %w", err)
+	}
+
+	// Nothing updated - tell apart a missing run from a finished one
+	if _, err := r.GetRunByID(ctx, userID, runID); err != nil {
+		return nil, err
+	}
+	return nil, ErrIngestRunCompleted
+}
+
+// HeartbeatIngestRun records that an in-progress run is still being executed
+// Returns whether cancellation was requested, or ErrIngestRunCompleted if the run is no longer open
+// (e.g. it was closed by the stale-run reaper)
+func (r *IngestRepository) HeartbeatIngestRun(ctx context.Context, runID string) (bool, error) {
+	ctx, span := ingestRepoTracer.Start(ctx, "HeartbeatIngestRun")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("run_id", runID))
+
+	query := `
+		UPDATE ingest_runs
+		SET heartbeat_at = NOW()
+		WHERE id = $1 AND completed_at IS NULL
+		RETURNING cancel_requested_at IS NOT NULL
+	`
+
+	var cancelRequested bool
+	err := r.db.GetContext(ctx, &cancelRequested, query, runID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrIngestRunCompleted
+		}
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to record ingest run heartbeat: %w", err)
+	}
+
+	return cancelRequested, nil
+}
+
+// ReapStaleRuns marks open runs whose heartbeat is older than staleAfter as abandoned ('error')
+// If userID is nil, runs of all users are reaped. Runs whose job is still queued are waiting, not abandoned,
+// and are left alone. Returns the IDs of the reaped runs
+func (r *IngestRepository) ReapStaleRuns(ctx context.Context, userID *uuid.UUID, staleAfter time.Duration) ([]string, error) {
+	ctx, span := ingestRepoTracer.Start(ctx, "ReapStaleRuns")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("stale_after", staleAfter.String()))
+	if userID != nil {
+		span.SetAttributes(attribute.String("user_id", userID.String()))
+	}
+
+	query := `
+		UPDATE ingest_runs r
+		SET completed_at = NOW(), status = 'error', err_text = 'abandoned: no heartbeat from the worker executing the run'
+		WHERE r.completed_at IS NULL
+		  AND COALESCE(r.heartbeat_at, r.started_at) < NOW() - make_interval(secs => $1)
+		  AND ($2::uuid IS NULL OR r.user_id = $2)
+		  AND NOT EXISTS (
+		      SELECT 1 FROM ingest_jobs j
+		      WHERE j.run_id = r.id AND j.status = 'queued'
+		  )
+		RETURNING r.id
+	`
+
+	var runIDs []string
+	err := r.db.SelectContext(ctx, &runIDs, query, staleAfter.Seconds(), userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to reap stale ingest runs: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("runs_reaped", len(runIDs)))
+
+	return runIDs, nil
+}
+
 // CompleteIngestRun marks an ingest run as completed
-func (r *IngestRepository) CompleteIngestRun(ctx context.Context, runID string, status string, finalFetchedCount int, errText *string) error {
+func (r *IngestRepository) CompleteIngestRun(ctx context.Context, runID string, status string, totals IngestRunTotals, errText *string) error {
 	ctx, span := ingestRepoTracer.Start(ctx, "CompleteIngestRun")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("run_id", runID),
 		attribute.String("status", status),
-		attribute.Int("final_fetched_count", finalFetchedCount),
+		attribute.Int("final_fetched_count", totals.FetchedCount),
+		attribute.Int("retried", totals.Retried),
+		attribute.Int("rate_limit_hits", totals.RateLimitHits),
 	)
 
+	// Retry counters never go down, so completing without known totals keeps the persisted progress
 	query := `
 		UPDATE ingest_runs
-		SET completed_at = NOW(), status = $2, fetched_count = $3, err_text = $4
-		WHERE id = $1
+		SET completed_at = NOW(), status = $2, fetched_count = $3, err_text = $4,
+		    retried = GREATEST(retried, $5), rate_limit_hits = GREATEST(rate_limit_hits, $6),
+		    phase = CASE WHEN $2 = 'ok' THEN 'done' ELSE phase END
+		WHERE id = $1 AND completed_at IS NULL
 	`
 
-	_, err := r.db.ExecContext(ctx, query, runID, status, finalFetchedCount, errText)
+	_, err := r.db.ExecContext(ctx, query, runID, status, totals.FetchedCount, errText, totals.Retried, totals.RateLimitHits)
 	if err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to complete ingest run: %w", err)
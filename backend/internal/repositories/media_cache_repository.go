@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var mediaCacheRepoTracer = otel.Tracer("media_cache_repository")
+
+// MediaCacheRepository handles media_description_cache data access operations
+type MediaCacheRepository struct {
+	db *sqlx.DB
+}
+
+// NewMediaCacheRepository creates a new MediaCacheRepository instance
+func NewMediaCacheRepository(database *sqlx.DB) *MediaCacheRepository {
+	return &MediaCacheRepository{
+		db: database,
+	}
+}
+
+// GetDescription looks up an unexpired description made by the given model under any of the cache keys
+// Keys are tried in order; returns nil if none of them is cached
+func (r *MediaCacheRepository) GetDescription(ctx context.Context, keys []string, model string) (*db.MediaDescriptionCacheEntry, error) {
+	ctx, span := mediaCacheRepoTracer.Start(ctx, "GetDescription")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("key_count", len(keys)),
+		attribute.String("model", model),
+	)
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT c.cache_key, c.model, c.description, c.created_at, c.expires_at
+		FROM unnest($1::text[]) WITH ORDINALITY AS k(cache_key, position)
+		JOIN media_description_cache c ON c.cache_key = k.cache_key
+		WHERE c.model = $2
+		  AND c.expires_at > now()
+		ORDER BY k.position
+		LIMIT 1
+	`
+
+	var entries []db.MediaDescriptionCacheEntry
+	err := r.db.SelectContext(ctx, &entries, query, pq.Array(keys), model)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch cached media description: %w", err)
+	}
+
+	span.SetAttributes(attribute.Bool("hit", len(entries) > 0))
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	return &entries[0], nil
+}
+
+// PutDescription caches a description under each of the cache keys until ttl has passed
+// An existing entry for the same key and model is replaced
+func (r *MediaCacheRepository) PutDescription(ctx context.Context, keys []string, model string, description string, ttl time.Duration) error {
+	ctx, span := mediaCacheRepoTracer.Start(ctx, "PutDescription")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("key_count", len(keys)),
+		attribute.String("model", model),
+	)
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO media_description_cache (cache_key, model, description, created_at, expires_at)
+		SELECT k.cache_key, $2, $3, now(), now() + make_interval(secs => $4)
+		FROM unnest($1::text[]) AS k(cache_key)
+		ON CONFLICT (cache_key, model) DO UPDATE
+		SET description = EXCLUDED.description,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, pq.Array(keys), model, description, ttl.Seconds())
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to cache media description: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes expired cache entries
+// Returns the number of entries removed
+func (r *MediaCacheRepository) DeleteExpired(ctx context.Context) (int, error) {
+	ctx, span := mediaCacheRepoTracer.Start(ctx, "DeleteExpired")
+	defer span.End()
+
+	res, err := r.db.ExecContext(ctx, `DELETE FROM media_description_cache WHERE expires_at <= now()`)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to delete expired media descriptions: %w", err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to count deleted media descriptions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("deleted_count", deleted))
+	return int(deleted), nil
+}
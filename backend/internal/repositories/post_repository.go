@@ -2,12 +2,14 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/sopeal/AskYourFeed/internal/db"
 	"github.com/sopeal/AskYourFeed/internal/dto"
 	"go.opentelemetry.io/otel"
@@ -16,6 +18,24 @@ import (
 
 var postRepoTracer = otel.Tracer("post_repository")
 
+// engagementScoreSQL ranks posts by traction; reposts and quotes spread a post further than likes
+// Views are left out as they are reported inconsistently
+const engagementScoreSQL = `(p.like_count + p.reply_count + p.bookmark_count + 2 * (p.retweet_count + p.quote_count))`
+
+// postWithAuthorColumnsSQL selects a db.PostWithAuthor from posts p joined with authors a
+// The retweeting author and the quoted tweet are looked up for retweets and quote tweets
+const postWithAuthorColumnsSQL = `
+	p.user_id, p.x_post_id, p.author_id, p.published_at, p.url, p.text,
+	p.conversation_id, p.in_reply_to_id, p.ingested_at, p.first_visible_at, p.edited_seen,
+	p.like_count, p.retweet_count, p.reply_count, p.quote_count, p.view_count, p.bookmark_count,
+	p.metrics_updated_at, p.kind, p.reposted_by_id, p.quoted_post_id, p.source,
+	a.handle, a.display_name,
+	(SELECT rb.handle FROM authors rb WHERE rb.x_author_id = p.reposted_by_id) AS reposted_by_handle,
+	(SELECT qa.handle FROM posts q JOIN authors qa ON q.author_id = qa.x_author_id
+	 WHERE q.user_id = p.user_id AND q.x_post_id = p.quoted_post_id) AS quoted_handle,
+	(SELECT q.text FROM posts q
+	 WHERE q.user_id = p.user_id AND q.x_post_id = p.quoted_post_id) AS quoted_text`
+
 // PostRepository handles post data access operations
 type PostRepository struct {
 	db *sqlx.DB
@@ -29,9 +49,11 @@ func NewPostRepository(database *sqlx.DB) *PostRepository {
 }
 
 // GetPostsByDateRange fetches posts within a specified date range for a user
+// Posts deleted on X are left out, as are quoted tweets, which come with the quote tweet. When the range holds more than 100 posts, the ones with the most engagement are kept
+// A non-nil listID keeps only the posts ingested through that list, a non-empty source only the posts of that source
 // Returns posts ordered chronologically (published_at ASC)
 // Uses RLS to ensure user can only access their own posts
-func (r *PostRepository) GetPostsByDateRange(ctx context.Context, userID uuid.UUID, dateFrom, dateTo time.Time) ([]db.PostWithAuthor, error) {
+func (r *PostRepository) GetPostsByDateRange(ctx context.Context, userID uuid.UUID, dateFrom, dateTo time.Time, listID *int64, source string) ([]db.PostWithAuthor, error) {
 	ctx, span := postRepoTracer.Start(ctx, "GetPostsByDateRange")
 	defer span.End()
 
@@ -40,32 +62,37 @@ func (r *PostRepository) GetPostsByDateRange(ctx context.Context, userID uuid.UU
 		attribute.String("date_from", dateFrom.Format(time.RFC3339)),
 		attribute.String("date_to", dateTo.Format(time.RFC3339)),
 	)
+	if listID != nil {
+		span.SetAttributes(attribute.Int64("list_id", *listID))
+	}
+	if source != "" {
+		span.SetAttributes(attribute.String("source", source))
+	}
 
 	query := `
-		SELECT 
-			p.user_id,
-			p.x_post_id,
-			p.author_id,
-			p.published_at,
-			p.url,
-			p.text,
-			p.conversation_id,
-			p.ingested_at,
-			p.first_visible_at,
-			p.edited_seen,
-			a.handle,
-			a.display_name
-		FROM posts p
-		JOIN authors a ON p.author_id = a.x_author_id
-		WHERE p.user_id = $1 
-		  AND p.published_at >= $2 
-		  AND p.published_at <= $3
-		ORDER BY p.published_at ASC
-		LIMIT 100
+		SELECT *
+		FROM (
+			SELECT ` + postWithAuthorColumnsSQL + `
+			FROM posts p
+			JOIN authors a ON p.author_id = a.x_author_id
+			WHERE p.user_id = $1
+			  AND p.published_at >= $2
+			  AND p.published_at <= $3
+			  AND p.deleted_at IS NULL
+			  AND p.kind <> 'quoted'
+			  AND ($4::bigint IS NULL OR EXISTS (
+				SELECT 1 FROM post_lists pl
+				WHERE pl.user_id = p.user_id AND pl.x_post_id = p.x_post_id AND pl.x_list_id = $4
+			  ))
+			  AND ($5 = '' OR p.source = $5)
+			ORDER BY ` + engagementScoreSQL + ` DESC, p.published_at ASC
+			LIMIT 100
+		) ranked
+		ORDER BY published_at ASC
 	`
 
 	var posts []db.PostWithAuthor
-	err := r.db.SelectContext(ctx, &posts, query, userID, dateFrom, dateTo)
+	err := r.db.SelectContext(ctx, &posts, query, userID, dateFrom, dateTo, listID, source)
 	if err != nil {
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to fetch posts by date range: %w", err)
@@ -103,6 +130,56 @@ func (r *PostRepository) PostExists(ctx context.Context, userID uuid.UUID, postI
 	return exists, nil
 }
 
+// MarkPostMention marks a stored post as a mention of the user
+// A post first ingested from the feed is found again by the mentions stage when a followed author mentions the user
+func (r *PostRepository) MarkPostMention(ctx context.Context, userID uuid.UUID, postID int64) error {
+	ctx, span := postRepoTracer.Start(ctx, "MarkPostMention")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("post_id", postID),
+	)
+
+	query := `
+		UPDATE posts
+		SET source = 'mention'
+		WHERE user_id = $1 AND x_post_id = $2 AND source <> 'mention'
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, postID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to mark post as mention: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatestMentionTime returns the publish time of the user's newest stored mention
+// Returns nil if no mention was stored yet
+func (r *PostRepository) GetLatestMentionTime(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	ctx, span := postRepoTracer.Start(ctx, "GetLatestMentionTime")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	query := `
+		SELECT MAX(published_at)
+		FROM posts
+		WHERE user_id = $1 AND source = 'mention'
+	`
+
+	var latest *time.Time
+	err := r.db.GetContext(ctx, &latest, query, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get latest mention time: %w", err)
+	}
+
+	return latest, nil
+}
+
 // InsertPost inserts a new post for a user
 func (r *PostRepository) InsertPost(ctx context.Context, userID uuid.UUID, tweetDTO *dto.TweetDTO) error {
 	ctx, span := postRepoTracer.Start(ctx, "InsertPost")
@@ -112,14 +189,19 @@ func (r *PostRepository) InsertPost(ctx context.Context, userID uuid.UUID, tweet
 		attribute.String("user_id", userID.String()),
 		attribute.Int64("post_id", tweetDTO.ID),
 		attribute.Int64("author_id", tweetDTO.AuthorID),
+		attribute.String("kind", tweetDTO.Kind),
 	)
 
 	query := `
 		INSERT INTO posts (
 			user_id, x_post_id, author_id, published_at, url, text,
-			conversation_id, ingested_at, first_visible_at, edited_seen
+			conversation_id, ingested_at, first_visible_at, edited_seen,
+			like_count, retweet_count, reply_count, quote_count, view_count, bookmark_count,
+			raw_text, in_reply_to_id, kind, reposted_by_id, quoted_post_id, source
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, NOW(), NOW(), false
+			$1, $2, $3, $4, $5, $6, $7, NOW(), NOW(), false,
+			$8, $9, $10, $11, $12, $13, $14, NULLIF($15, 0), COALESCE(NULLIF($16, ''), 'original'), NULLIF($17, 0), NULLIF($18, 0),
+			COALESCE(NULLIF($19, ''), 'feed')
 		)
 	`
 
@@ -131,6 +213,18 @@ func (r *PostRepository) InsertPost(ctx context.Context, userID uuid.UUID, tweet
 		tweetDTO.URL,
 		tweetDTO.Text,
 		tweetDTO.ConversationID,
+		tweetDTO.LikeCount,
+		tweetDTO.RetweetCount,
+		tweetDTO.ReplyCount,
+		tweetDTO.QuoteCount,
+		tweetDTO.ViewCount,
+		tweetDTO.BookmarkCount,
+		tweetDTO.RawText,
+		tweetDTO.InReplyToID,
+		tweetDTO.Kind,
+		tweetDTO.RepostedByID,
+		tweetDTO.QuotedPostID,
+		tweetDTO.Source,
 	)
 
 	if err != nil {
@@ -140,3 +234,697 @@ func (r *PostRepository) InsertPost(ctx context.Context, userID uuid.UUID, tweet
 
 	return nil
 }
+
+// ListPostIDsForEngagementRefresh retrieves IDs of posts published since the given time
+// Runs across all users; a tweet ingested by several users is returned once
+// Returns at most limit IDs, least recently refreshed first
+func (r *PostRepository) ListPostIDsForEngagementRefresh(ctx context.Context, since time.Time, limit int) ([]int64, error) {
+	ctx, span := postRepoTracer.Start(ctx, "ListPostIDsForEngagementRefresh")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("since", since.Format(time.RFC3339)),
+		attribute.Int("limit", limit),
+	)
+
+	query := `
+		SELECT x_post_id
+		FROM posts
+		WHERE published_at >= $1
+		GROUP BY x_post_id
+		ORDER BY MIN(COALESCE(metrics_updated_at, '-infinity'::timestamptz)) ASC, x_post_id DESC
+		LIMIT $2
+	`
+
+	var postIDs []int64
+	err := r.db.SelectContext(ctx, &postIDs, query, since, limit)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list posts for engagement refresh: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("post_count", len(postIDs)))
+
+	return postIDs, nil
+}
+
+// RecordPostEngagement stores engagement snapshots in the post_engagement time series
+// and updates the latest metrics on every user's copy of the posts
+// A post that was marked deleted but is returned again is restored
+func (r *PostRepository) RecordPostEngagement(ctx context.Context, snapshots []db.PostEngagement) error {
+	ctx, span := postRepoTracer.Start(ctx, "RecordPostEngagement")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("snapshot_count", len(snapshots)))
+
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query := `
+		WITH snapshot AS (
+			INSERT INTO post_engagement (
+				x_post_id, captured_at,
+				like_count, retweet_count, reply_count, quote_count, view_count, bookmark_count
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (x_post_id, captured_at) DO NOTHING
+		)
+		UPDATE posts
+		SET like_count = $3,
+			retweet_count = $4,
+			reply_count = $5,
+			quote_count = $6,
+			view_count = $7,
+			bookmark_count = $8,
+			metrics_updated_at = $2,
+			deleted_at = NULL
+		WHERE x_post_id = $1
+	`
+
+	for _, snapshot := range snapshots {
+		_, err := tx.ExecContext(ctx, query,
+			snapshot.XPostID,
+			snapshot.CapturedAt,
+			snapshot.LikeCount,
+			snapshot.RetweetCount,
+			snapshot.ReplyCount,
+			snapshot.QuoteCount,
+			snapshot.ViewCount,
+			snapshot.BookmarkCount,
+		)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to record post engagement: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyPostText compares a re-fetched tweet text with the stored one and records an edit
+// On an edit, every user's copy gets the new text (keeping media descriptions appended to posts ingested before post_media),
+// edited_seen is set and the previous text is kept in post_revisions.
+// Posts stored without raw_text adopt the re-fetched text without recording an edit
+// Returns true if an edit was recorded
+func (r *PostRepository) ApplyPostText(ctx context.Context, postID int64, rawText string, detectedAt time.Time) (bool, error) {
+	ctx, span := postRepoTracer.Start(ctx, "ApplyPostText")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("post_id", postID))
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	adoptQuery := `
+		UPDATE posts
+		SET raw_text = $2
+		WHERE x_post_id = $1 AND raw_text IS NULL
+	`
+	if _, err := tx.ExecContext(ctx, adoptQuery, postID, rawText); err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to adopt post raw text: %w", err)
+	}
+
+	// Rows are locked, so a concurrent check of the same tweet sees the new text and records nothing
+	editQuery := `
+		UPDATE posts p
+		SET text = CASE
+				WHEN left(p.text, length(old.raw_text)) = old.raw_text
+				THEN $2 || substr(p.text, length(old.raw_text) + 1)
+				ELSE $2
+			END,
+			raw_text = $2,
+			edited_seen = true
+		FROM (
+			SELECT user_id, x_post_id, raw_text
+			FROM posts
+			WHERE x_post_id = $1 AND raw_text <> $2
+			FOR UPDATE
+		) old
+		WHERE p.user_id = old.user_id AND p.x_post_id = old.x_post_id
+		RETURNING old.raw_text
+	`
+	var previousTexts []string
+	if err := tx.SelectContext(ctx, &previousTexts, editQuery, postID, rawText); err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to apply post edit: %w", err)
+	}
+
+	if len(previousTexts) == 0 {
+		if err := tx.Commit(); err != nil {
+			span.RecordError(err)
+			return false, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return false, nil
+	}
+
+	revisionQuery := `
+		INSERT INTO post_revisions (x_post_id, previous_text, text, detected_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := tx.ExecContext(ctx, revisionQuery, postID, previousTexts[0], rawText, detectedAt); err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to insert post revision: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	span.SetAttributes(attribute.Bool("edited", true))
+
+	return true, nil
+}
+
+// MarkPostsDeleted marks every user's copy of the given posts as deleted on X
+// Returns the number of distinct posts newly marked
+func (r *PostRepository) MarkPostsDeleted(ctx context.Context, postIDs []int64, deletedAt time.Time) (int, error) {
+	ctx, span := postRepoTracer.Start(ctx, "MarkPostsDeleted")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("post_count", len(postIDs)))
+
+	if len(postIDs) == 0 {
+		return 0, nil
+	}
+
+	query := `
+		WITH marked AS (
+			UPDATE posts
+			SET deleted_at = $2
+			WHERE x_post_id = ANY($1) AND deleted_at IS NULL
+			RETURNING x_post_id
+		)
+		SELECT COUNT(DISTINCT x_post_id) FROM marked
+	`
+
+	var marked int
+	err := r.db.GetContext(ctx, &marked, query, pq.Array(postIDs), deletedAt)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to mark posts deleted: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("marked_count", marked))
+
+	return marked, nil
+}
+
+// ListIncompleteThreads retrieves a user's threads with a self-reply whose parent was not ingested
+// Only threads that received a post since the given time are returned, so a gap that cannot be
+// filled (e.g. a deleted parent) is not looked up again until the thread grows
+// Returns at most limit threads, most recently ingested first
+func (r *PostRepository) ListIncompleteThreads(ctx context.Context, userID uuid.UUID, ingestedSince time.Time, limit int) ([]db.Thread, error) {
+	ctx, span := postRepoTracer.Start(ctx, "ListIncompleteThreads")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("ingested_since", ingestedSince.Format(time.RFC3339)),
+		attribute.Int("limit", limit),
+	)
+
+	query := `
+		SELECT user_id, conversation_id, author_id, post_count, post_ids,
+		       started_at, last_published_at, last_ingested_at, complete
+		FROM threads
+		WHERE user_id = $1
+		  AND NOT complete
+		  AND last_ingested_at >= $2
+		ORDER BY last_ingested_at DESC, conversation_id DESC
+		LIMIT $3
+	`
+
+	var threads []db.Thread
+	err := r.db.SelectContext(ctx, &threads, query, userID, ingestedSince, limit)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list incomplete threads: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("thread_count", len(threads)))
+
+	return threads, nil
+}
+
+// GetThreadPosts fetches every post of the user's threads in the given conversations
+// Conversations holding a single post are left out; posts deleted on X are left out
+// Returns posts grouped by conversation, each thread ordered chronologically
+func (r *PostRepository) GetThreadPosts(ctx context.Context, userID uuid.UUID, conversationIDs []int64) ([]db.PostWithAuthor, error) {
+	ctx, span := postRepoTracer.Start(ctx, "GetThreadPosts")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("conversation_count", len(conversationIDs)),
+	)
+
+	if len(conversationIDs) == 0 {
+		return []db.PostWithAuthor{}, nil
+	}
+
+	query := `
+		SELECT ` + postWithAuthorColumnsSQL + `
+		FROM threads t
+		JOIN posts p ON p.user_id = t.user_id AND p.x_post_id = ANY(t.post_ids)
+		JOIN authors a ON p.author_id = a.x_author_id
+		WHERE t.user_id = $1
+		  AND t.conversation_id = ANY($2)
+		  AND t.post_count > 1
+		ORDER BY t.conversation_id, t.author_id, p.published_at ASC, p.x_post_id ASC
+	`
+
+	var posts []db.PostWithAuthor
+	err := r.db.SelectContext(ctx, &posts, query, userID, pq.Array(conversationIDs))
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch thread posts: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("post_count", len(posts)))
+
+	return posts, nil
+}
+
+// InsertArticle stores the X article linked from a post, split into ordered chunks
+// An article already stored for the post is kept as is
+func (r *PostRepository) InsertArticle(ctx context.Context, userID uuid.UUID, postID int64, title *string, chunks []string) error {
+	ctx, span := postRepoTracer.Start(ctx, "InsertArticle")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("post_id", postID),
+		attribute.Int("chunk_count", len(chunks)),
+	)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	articleQuery := `
+		INSERT INTO post_articles (user_id, x_post_id, title, chunk_count)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, x_post_id) DO NOTHING
+	`
+	result, err := tx.ExecContext(ctx, articleQuery, userID, postID, title, len(chunks))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to insert post article: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil
+	}
+
+	chunkQuery := `
+		INSERT INTO post_article_chunks (user_id, x_post_id, chunk_index, text)
+		SELECT $1, $2, c.ordinality - 1, c.text
+		FROM unnest($3::text[]) WITH ORDINALITY AS c(text, ordinality)
+	`
+	if _, err := tx.ExecContext(ctx, chunkQuery, userID, postID, pq.Array(chunks)); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to insert post article chunks: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetArticles fetches the X articles linked from the given posts with at most maxChunks chunks each
+// Returns articles keyed by post ID; posts without an article are left out
+func (r *PostRepository) GetArticles(ctx context.Context, userID uuid.UUID, postIDs []int64, maxChunks int) (map[int64]*db.PostArticle, error) {
+	ctx, span := postRepoTracer.Start(ctx, "GetArticles")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("post_count", len(postIDs)),
+		attribute.Int("max_chunks", maxChunks),
+	)
+
+	articles := make(map[int64]*db.PostArticle)
+	if len(postIDs) == 0 {
+		return articles, nil
+	}
+
+	query := `
+		SELECT pa.x_post_id, pa.title, pa.chunk_count, c.text
+		FROM post_articles pa
+		LEFT JOIN post_article_chunks c
+			ON c.user_id = pa.user_id AND c.x_post_id = pa.x_post_id AND c.chunk_index < $3
+		WHERE pa.user_id = $1
+		  AND pa.x_post_id = ANY($2)
+		ORDER BY pa.x_post_id, c.chunk_index
+	`
+
+	var rows []struct {
+		db.PostArticle
+		Text *string `db:"text"`
+	}
+	err := r.db.SelectContext(ctx, &rows, query, userID, pq.Array(postIDs), maxChunks)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch post articles: %w", err)
+	}
+
+	for _, row := range rows {
+		article, ok := articles[row.XPostID]
+		if !ok {
+			article = &db.PostArticle{
+				XPostID:    row.XPostID,
+				Title:      row.Title,
+				ChunkCount: row.ChunkCount,
+			}
+			articles[row.XPostID] = article
+		}
+		if row.Text != nil {
+			article.Chunks = append(article.Chunks, *row.Text)
+		}
+	}
+
+	span.SetAttributes(attribute.Int("article_count", len(articles)))
+
+	return articles, nil
+}
+
+// InsertPostLink stores the readable content of a page linked from a post
+// A link already stored for the post is kept as is
+func (r *PostRepository) InsertPostLink(ctx context.Context, userID uuid.UUID, link db.PostLink) error {
+	ctx, span := postRepoTracer.Start(ctx, "InsertPostLink")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("post_id", link.XPostID),
+	)
+
+	query := `
+		INSERT INTO post_links (user_id, x_post_id, url, final_url, title, summary, content)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, x_post_id, url) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		userID,
+		link.XPostID,
+		link.URL,
+		link.FinalURL,
+		link.Title,
+		link.Summary,
+		link.Content,
+	)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to insert post link: %w", err)
+	}
+
+	return nil
+}
+
+// GetPostLinks fetches the pages linked from the given posts
+// Returns links keyed by post ID in the order they were stored; posts without links are left out
+func (r *PostRepository) GetPostLinks(ctx context.Context, userID uuid.UUID, postIDs []int64) (map[int64][]db.PostLink, error) {
+	ctx, span := postRepoTracer.Start(ctx, "GetPostLinks")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("post_count", len(postIDs)),
+	)
+
+	links := make(map[int64][]db.PostLink)
+	if len(postIDs) == 0 {
+		return links, nil
+	}
+
+	query := `
+		SELECT x_post_id, url, final_url, title, summary, content, fetched_at
+		FROM post_links
+		WHERE user_id = $1
+		  AND x_post_id = ANY($2)
+		ORDER BY x_post_id, fetched_at, url
+	`
+
+	var rows []db.PostLink
+	err := r.db.SelectContext(ctx, &rows, query, userID, pq.Array(postIDs))
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch post links: %w", err)
+	}
+
+	for _, link := range rows {
+		links[link.XPostID] = append(links[link.XPostID], link)
+	}
+
+	span.SetAttributes(attribute.Int("link_count", len(rows)))
+
+	return links, nil
+}
+
+// postMediaColumnsSQL lists the post_media columns read into db.PostMedia
+const postMediaColumnsSQL = `
+	user_id, x_post_id, media_index, media_type, url, url_hash, duration_ms,
+	description, model, status, error, attempts, described_at, created_at`
+
+// InsertPostMedia stores the images and videos of a post with their descriptions
+// Media already stored for the post is kept as is
+func (r *PostRepository) InsertPostMedia(ctx context.Context, userID uuid.UUID, media []db.PostMedia) error {
+	ctx, span := postRepoTracer.Start(ctx, "InsertPostMedia")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("media_count", len(media)),
+	)
+
+	if len(media) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO post_media (
+			user_id, x_post_id, media_index, media_type, url, url_hash, duration_ms,
+			description, model, status, error, attempts, described_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (user_id, x_post_id, media_index) DO NOTHING
+	`
+
+	for _, item := range media {
+		_, err := r.db.ExecContext(ctx, query,
+			userID,
+			item.XPostID,
+			item.MediaIndex,
+			item.MediaType,
+			item.URL,
+			item.URLHash,
+			item.DurationMs,
+			item.Description,
+			item.Model,
+			item.Status,
+			item.Error,
+			item.Attempts,
+			item.DescribedAt,
+		)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("failed to insert post media: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetPostMedia fetches the images and videos of the given posts
+// Returns media keyed by post ID in post order; posts without media are left out
+func (r *PostRepository) GetPostMedia(ctx context.Context, userID uuid.UUID, postIDs []int64) (map[int64][]db.PostMedia, error) {
+	ctx, span := postRepoTracer.Start(ctx, "GetPostMedia")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("post_count", len(postIDs)),
+	)
+
+	media := make(map[int64][]db.PostMedia)
+	if len(postIDs) == 0 {
+		return media, nil
+	}
+
+	query := `
+		SELECT ` + postMediaColumnsSQL + `
+		FROM post_media
+		WHERE user_id = $1
+		  AND x_post_id = ANY($2)
+		ORDER BY x_post_id, media_index
+	`
+
+	var rows []db.PostMedia
+	err := r.db.SelectContext(ctx, &rows, query, userID, pq.Array(postIDs))
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch post media: %w", err)
+	}
+
+	for _, item := range rows {
+		media[item.XPostID] = append(media[item.XPostID], item)
+	}
+
+	span.SetAttributes(attribute.Int("media_count", len(rows)))
+
+	return media, nil
+}
+
+// GetMediaDescription looks up a description of the same media made by the given model for any user
+// Returns nil if the media was not described by the model yet
+func (r *PostRepository) GetMediaDescription(ctx context.Context, urlHash string, model string) (*string, error) {
+	ctx, span := postRepoTracer.Start(ctx, "GetMediaDescription")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("model", model))
+
+	query := `
+		SELECT description
+		FROM post_media
+		WHERE url_hash = $1
+		  AND model = $2
+		  AND status = 'described'
+		LIMIT 1
+	`
+
+	var descriptions []string
+	err := r.db.SelectContext(ctx, &descriptions, query, urlHash, model)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch media description: %w", err)
+	}
+	if len(descriptions) == 0 {
+		return nil, nil
+	}
+
+	return &descriptions[0], nil
+}
+
+// ListMediaForReprocessing lists media of all users still to be described, oldest first
+// Images are described by imageModel and videos by videoModel; a type whose model is empty is not listed.
+// Pending media and failed media with fewer than maxAttempts attempts are listed; with redescribe,
+// media described by another model than the one for its type is listed too, unless describing it again failed
+func (r *PostRepository) ListMediaForReprocessing(ctx context.Context, imageModel string, videoModel string, redescribe bool, maxAttempts int, limit int) ([]db.PostMedia, error) {
+	ctx, span := postRepoTracer.Start(ctx, "ListMediaForReprocessing")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("image_model", imageModel),
+		attribute.String("video_model", videoModel),
+		attribute.Bool("redescribe", redescribe),
+		attribute.Int("limit", limit),
+	)
+
+	query := `
+		SELECT ` + postMediaColumnsSQL + `
+		FROM post_media
+		WHERE ((media_type = 'image' AND $1::text <> '') OR (media_type = 'video' AND $2::text <> ''))
+		  AND (status = 'pending'
+		   OR (status = 'failed' AND attempts < $4)
+		   OR ($3 AND status = 'described' AND error IS NULL
+		       AND model IS DISTINCT FROM CASE WHEN media_type = 'image' THEN $1 ELSE $2 END))
+		ORDER BY created_at ASC, user_id, x_post_id, media_index
+		LIMIT $5
+	`
+
+	var media []db.PostMedia
+	err := r.db.SelectContext(ctx, &media, query, imageModel, videoModel, redescribe, maxAttempts, limit)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to list media for reprocessing: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("media_count", len(media)))
+
+	return media, nil
+}
+
+// UpdateMediaDescription records the outcome of describing a media item on every copy of it
+// Copies are matched by url_hash, so media shared by several users is described once.
+// An unsuccessful attempt never replaces an existing description; it only records the error
+// Returns the number of rows updated
+func (r *PostRepository) UpdateMediaDescription(ctx context.Context, result db.PostMedia) (int, error) {
+	ctx, span := postRepoTracer.Start(ctx, "UpdateMediaDescription")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("status", result.Status))
+
+	var res sql.Result
+	var err error
+	if result.Status == db.MediaStatusDescribed {
+		query := `
+			UPDATE post_media
+			SET description = $2,
+				model = $3,
+				status = 'described',
+				error = NULL,
+				attempts = attempts + 1,
+				described_at = $4
+			WHERE url_hash = $1
+		`
+		res, err = r.db.ExecContext(ctx, query, result.URLHash, result.Description, result.Model, result.DescribedAt)
+	} else {
+		query := `
+			UPDATE post_media
+			SET status = CASE WHEN status = 'described' THEN status ELSE $2 END,
+				error = $3,
+				attempts = attempts + 1
+			WHERE url_hash = $1
+		`
+		res, err = r.db.ExecContext(ctx, query, result.URLHash, result.Status, result.Error)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to update media description: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("rows_updated", rowsAffected))
+
+	return int(rowsAffected), nil
+}
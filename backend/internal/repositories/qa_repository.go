@@ -45,11 +45,11 @@ func (r *QARepository) CreateQA(ctx context.Context, tx *sqlx.Tx, qa db.QAMessag
 	)
 
 	query := `
-		INSERT INTO qa_messages (id, user_id, question, answer, date_from, date_to, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO qa_messages (id, user_id, question, answer, date_from, date_to, list_id, source, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err := tx.ExecContext(ctx, query, qa.ID, qa.UserID, qa.Question, qa.Answer, qa.DateFrom, qa.DateTo, qa.CreatedAt)
+	_, err := tx.ExecContext(ctx, query, qa.ID, qa.UserID, qa.Question, qa.Answer, qa.DateFrom, qa.DateTo, qa.ListID, qa.Source, qa.CreatedAt)
 	if err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to insert Q&A message: %w", err)
@@ -96,7 +96,7 @@ func (r *QARepository) GetQAByID(ctx context.Context, userID uuid.UUID, qaID str
 	// First, get the Q&A message
 	var qa db.QAMessage
 	qaQuery := `
-		SELECT id, user_id, question, answer, date_from, date_to, created_at
+		SELECT id, user_id, question, answer, date_from, date_to, list_id, source, created_at
 		FROM qa_messages
 		WHERE id = $1 AND user_id = $2
 	`
@@ -118,21 +118,34 @@ func (r *QARepository) GetQAByID(ctx context.Context, userID uuid.UUID, qaID str
 			a.display_name,
 			p.published_at,
 			p.url,
-			p.text
+			p.text,
+			p.edited_seen,
+			p.deleted_at IS NOT NULL AS deleted,
+			p.author_id,
+			p.conversation_id,
+			p.kind,
+			rb.handle AS reposted_by_handle
 		FROM qa_sources qs
 		JOIN posts p ON qs.x_post_id = p.x_post_id AND qs.user_id = p.user_id
 		JOIN authors a ON p.author_id = a.x_author_id
+		LEFT JOIN authors rb ON p.reposted_by_id = rb.x_author_id
 		WHERE qs.qa_id = $1 AND qs.user_id = $2
 		ORDER BY p.published_at ASC
 	`
 
 	type SourceRow struct {
-		XPostID     int64   `db:"x_post_id"`
-		Handle      string  `db:"handle"`
-		DisplayName *string `db:"display_name"`
-		PublishedAt string  `db:"published_at"`
-		URL         string  `db:"url"`
-		Text        string  `db:"text"`
+		XPostID        int64   `db:"x_post_id"`
+		Handle         string  `db:"handle"`
+		DisplayName    *string `db:"display_name"`
+		PublishedAt    string  `db:"published_at"`
+		URL            string  `db:"url"`
+		Text           string  `db:"text"`
+		EditedSeen     bool    `db:"edited_seen"`
+		Deleted        bool    `db:"deleted"`
+		AuthorID       int64   `db:"author_id"`
+		ConversationID *int64  `db:"conversation_id"`
+		Kind           string  `db:"kind"`
+		RepostedBy     *string `db:"reposted_by_handle"`
 	}
 
 	var sourceRows []SourceRow
@@ -142,22 +155,96 @@ func (r *QARepository) GetQAByID(ctx context.Context, userID uuid.UUID, qaID str
 		return nil, fmt.Errorf("failed to fetch Q&A sources: %w", err)
 	}
 
-	// Build response DTO
-	sources := make([]dto.QASourceDTO, len(sourceRows))
-	for i, row := range sourceRows {
+	// Then, get the posts of the threads the sources belong to
+	threadsQuery := `
+		SELECT
+			t.author_id,
+			t.conversation_id,
+			p.x_post_id,
+			p.published_at,
+			p.url,
+			p.text
+		FROM threads t
+		JOIN posts p ON p.user_id = t.user_id AND p.x_post_id = ANY(t.post_ids)
+		WHERE t.user_id = $2
+		  AND t.post_count > 1
+		  AND (t.author_id, t.conversation_id) IN (
+			SELECT sp.author_id, sp.conversation_id
+			FROM qa_sources qs
+			JOIN posts sp ON qs.x_post_id = sp.x_post_id AND qs.user_id = sp.user_id
+			WHERE qs.qa_id = $1 AND qs.user_id = $2
+		  )
+		ORDER BY t.conversation_id, p.published_at ASC, p.x_post_id ASC
+	`
+
+	type ThreadPostRow struct {
+		AuthorID       int64  `db:"author_id"`
+		ConversationID int64  `db:"conversation_id"`
+		XPostID        int64  `db:"x_post_id"`
+		PublishedAt    string `db:"published_at"`
+		URL            string `db:"url"`
+		Text           string `db:"text"`
+	}
+
+	var threadRows []ThreadPostRow
+	err = r.db.SelectContext(ctx, &threadRows, threadsQuery, qaID, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch Q&A source threads: %w", err)
+	}
+
+	type threadKey struct {
+		authorID       int64
+		conversationID int64
+	}
+	threads := make(map[threadKey][]dto.QAThreadPostDTO)
+	for _, row := range threadRows {
+		key := threadKey{authorID: row.AuthorID, conversationID: row.ConversationID}
+		threads[key] = append(threads[key], dto.QAThreadPostDTO{
+			XPostID:     row.XPostID,
+			PublishedAt: mustParseTime(row.PublishedAt),
+			URL:         row.URL,
+			Text:        row.Text,
+		})
+	}
+
+	// Build response DTO; sources from the same thread are merged into the earliest one
+	sources := make([]dto.QASourceDTO, 0, len(sourceRows))
+	seenThreads := make(map[threadKey]bool)
+	for _, row := range sourceRows {
 		displayName := ""
 		if row.DisplayName != nil {
 			displayName = *row.DisplayName
 		}
 
-		sources[i] = dto.QASourceDTO{
+		source := dto.QASourceDTO{
 			XPostID:           row.XPostID,
 			AuthorHandle:      row.Handle,
 			AuthorDisplayName: displayName,
 			PublishedAt:       mustParseTime(row.PublishedAt),
 			URL:               row.URL,
 			Text:              row.Text,
+			Edited:            row.EditedSeen,
+			Deleted:           row.Deleted,
+			Kind:              row.Kind,
+		}
+		if row.RepostedBy != nil {
+			source.RepostedBy = *row.RepostedBy
 		}
+
+		if row.ConversationID != nil {
+			key := threadKey{authorID: row.AuthorID, conversationID: *row.ConversationID}
+			if thread, ok := threads[key]; ok {
+				if seenThreads[key] {
+					continue
+				}
+				seenThreads[key] = true
+				source.ConversationID = row.ConversationID
+				source.Thread = thread
+			}
+		}
+
+		sources = append(sources, source)
 	}
 
 	return &dto.QADetailDTO{
@@ -166,6 +253,8 @@ func (r *QARepository) GetQAByID(ctx context.Context, userID uuid.UUID, qaID str
 		Answer:    qa.Answer,
 		DateFrom:  qa.DateFrom,
 		DateTo:    qa.DateTo,
+		ListID:    qa.ListID,
+		Source:    qa.Source,
 		CreatedAt: qa.CreatedAt,
 		Sources:   sources,
 	}, nil
@@ -190,6 +279,8 @@ func (r *QARepository) ListQA(ctx context.Context, userID uuid.UUID, limit int,
 			qa.answer,
 			qa.date_from,
 			qa.date_to,
+			qa.list_id,
+			qa.source,
 			qa.created_at,
 			COALESCE(COUNT(qs.x_post_id), 0) as sources_count
 		FROM qa_messages qa
@@ -207,7 +298,7 @@ func (r *QARepository) ListQA(ctx context.Context, userID uuid.UUID, limit int,
 		argIndex++
 	}
 
-	query += " GROUP BY qa.id, qa.question, qa.answer, qa.date_from, qa.date_to, qa.created_at"
+	query += " GROUP BY qa.id, qa.question, qa.answer, qa.date_from, qa.date_to, qa.list_id, qa.source, qa.created_at"
 	query += " ORDER BY qa.created_at DESC"
 	query += fmt.Sprintf(" LIMIT $%d", argIndex)
 	args = append(args, limit+1) // Fetch one extra to determine if there are more
@@ -218,6 +309,8 @@ func (r *QARepository) ListQA(ctx context.Context, userID uuid.UUID, limit int,
 		Answer       string    `db:"answer"`
 		DateFrom     time.Time `db:"date_from"`
 		DateTo       time.Time `db:"date_to"`
+		ListID       *int64    `db:"list_id"`
+		Source       *string   `db:"source"`
 		CreatedAt    time.Time `db:"created_at"`
 		SourcesCount int       `db:"sources_count"`
 	}
@@ -250,6 +343,8 @@ func (r *QARepository) ListQA(ctx context.Context, userID uuid.UUID, limit int,
 			AnswerPreview: answerPreview,
 			DateFrom:      row.DateFrom,
 			DateTo:        row.DateTo,
+			ListID:        row.ListID,
+			Source:        row.Source,
 			CreatedAt:     row.CreatedAt,
 			SourcesCount:  row.SourcesCount,
 		}
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var searchSourceRepoTracer = otel.Tracer("search_source_repository")
+
+// SearchSourceRepository handles search_sources and post_search_sources data access operations
+type SearchSourceRepository struct {
+	db *sqlx.DB
+}
+
+// NewSearchSourceRepository creates a new SearchSourceRepository instance
+func NewSearchSourceRepository(database *sqlx.DB) *SearchSourceRepository {
+	return &SearchSourceRepository{
+		db: database,
+	}
+}
+
+// GetSearchSources retrieves the search queries the user saved as feed sources, with the number of posts each found
+// Returns items ordered by created_at ASC
+func (r *SearchSourceRepository) GetSearchSources(ctx context.Context, userID uuid.UUID) ([]db.SearchSourceItem, error) {
+	ctx, span := searchSourceRepoTracer.Start(ctx, "GetSearchSources")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	query := `
+		SELECT
+			ss.id,
+			ss.user_id,
+			ss.query,
+			ss.name,
+			ss.created_at,
+			ss.last_run_at,
+			ss.last_tweet_id,
+			COUNT(pss.x_post_id) AS post_count
+		FROM search_sources ss
+		LEFT JOIN post_search_sources pss ON pss.search_source_id = ss.id
+		WHERE ss.user_id = $1
+		GROUP BY ss.id, ss.user_id, ss.query, ss.name, ss.created_at, ss.last_run_at, ss.last_tweet_id
+		ORDER BY ss.created_at ASC, ss.id ASC
+	`
+
+	var items []db.SearchSourceItem
+	err := r.db.SelectContext(ctx, &items, query, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to fetch search sources: %w", err)
+	}
+
+	// Return empty slice if no items found (not an error)
+	if items == nil {
+		items = []db.SearchSourceItem{}
+	}
+
+	span.SetAttributes(attribute.Int("items_found", len(items)))
+
+	return items, nil
+}
+
+// InsertSearchSource saves a search query as a feed source
+// Returns nil if the user already saved the same query
+func (r *SearchSourceRepository) InsertSearchSource(ctx context.Context, id string, userID uuid.UUID, searchQuery string, name *string) (*db.SearchSource, error) {
+	ctx, span := searchSourceRepoTracer.Start(ctx, "InsertSearchSource")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("search_source_id", id),
+	)
+
+	query := `
+		INSERT INTO search_sources (id, user_id, query, name)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, query) DO NOTHING
+		RETURNING id, user_id, query, name, created_at, last_run_at, last_tweet_id
+	`
+
+	var source db.SearchSource
+	err := r.db.GetContext(ctx, &source, query, id, userID, searchQuery, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to insert search source: %w", err)
+	}
+
+	return &source, nil
+}
+
+// DeleteSearchSource removes a search source with its post tags; the posts are kept
+// Returns false if the user has no search source with the ID
+func (r *SearchSourceRepository) DeleteSearchSource(ctx context.Context, userID uuid.UUID, id string) (bool, error) {
+	ctx, span := searchSourceRepoTracer.Start(ctx, "DeleteSearchSource")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("search_source_id", id),
+	)
+
+	query := `
+		DELETE FROM search_sources
+		WHERE user_id = $1 AND id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, id)
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to delete search source: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// MarkSearchRun records that a search source ran and advances its newest seen result
+// lastTweetID of 0 keeps the stored value; it never moves backwards
+func (r *SearchSourceRepository) MarkSearchRun(ctx context.Context, userID uuid.UUID, id string, lastTweetID int64, ranAt time.Time) error {
+	ctx, span := searchSourceRepoTracer.Start(ctx, "MarkSearchRun")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("search_source_id", id),
+		attribute.Int64("last_tweet_id", lastTweetID),
+	)
+
+	query := `
+		UPDATE search_sources
+		SET last_run_at = $3,
+		    last_tweet_id = CASE
+		        WHEN $4::bigint > COALESCE(last_tweet_id, 0) THEN $4::bigint
+		        ELSE last_tweet_id
+		    END
+		WHERE user_id = $1 AND id = $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, id, ranAt, lastTweetID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to mark search source run: %w", err)
+	}
+
+	return nil
+}
+
+// TagPost records that a post was found by a search source
+func (r *SearchSourceRepository) TagPost(ctx context.Context, userID uuid.UUID, postID int64, id string) error {
+	ctx, span := searchSourceRepoTracer.Start(ctx, "TagPost")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("post_id", postID),
+		attribute.String("search_source_id", id),
+	)
+
+	query := `
+		INSERT INTO post_search_sources (user_id, x_post_id, search_source_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, search_source_id, x_post_id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, postID, id)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to tag post with search source: %w", err)
+	}
+
+	return nil
+}
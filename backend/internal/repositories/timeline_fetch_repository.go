@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var timelineFetchRepoTracer = otel.Tracer("timeline_fetch_repository")
+
+// TimelineFetchRepository handles timeline_fetches data access operations
+type TimelineFetchRepository struct {
+	db *sqlx.DB
+}
+
+// NewTimelineFetchRepository creates a new TimelineFetchRepository instance
+func NewTimelineFetchRepository(database *sqlx.DB) *TimelineFetchRepository {
+	return &TimelineFetchRepository{
+		db: database,
+	}
+}
+
+// ClaimFetch claims the fetch of a timeline page in a cycle for lease
+// A page not fetched yet in the cycle, a failed fetch and a fetch whose claim expired can be claimed
+// Returns false if another run holds the claim or the page was fetched already
+func (r *TimelineFetchRepository) ClaimFetch(ctx context.Context, authorID int64, cursor string, cycleStart time.Time, claimID string, lease time.Duration) (bool, error) {
+	ctx, span := timelineFetchRepoTracer.Start(ctx, "ClaimFetch")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("author_id", authorID),
+		attribute.String("cursor", cursor),
+		attribute.String("cycle_start", cycleStart.Format(time.RFC3339)),
+	)
+
+	query := `
+		INSERT INTO timeline_fetches (x_author_id, page_cursor, cycle_start, status, claim_id, claimed_until)
+		VALUES ($1, $2, $3, 'fetching', $4, NOW() + make_interval(secs => $5))
+		ON CONFLICT (x_author_id, page_cursor, cycle_start) DO UPDATE
+		SET status = 'fetching',
+			claim_id = EXCLUDED.claim_id,
+			claimed_until = EXCLUDED.claimed_until,
+			error = NULL,
+			error_status = NULL
+		WHERE timeline_fetches.status = 'failed'
+		   OR (timeline_fetches.status = 'fetching' AND timeline_fetches.claimed_until < NOW())
+	`
+
+	result, err := r.db.ExecContext(ctx, query, authorID, cursor, cycleStart, claimID, lease.Seconds())
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to claim timeline fetch: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	span.SetAttributes(attribute.Bool("claimed", rowsAffected > 0))
+
+	return rowsAffected > 0, nil
+}
+
+// GetFetch retrieves the fetch of a timeline page in a cycle
+// Returns nil if the page was not claimed in the cycle, or the claim was released
+func (r *TimelineFetchRepository) GetFetch(ctx context.Context, authorID int64, cursor string, cycleStart time.Time) (*db.TimelineFetch, error) {
+	ctx, span := timelineFetchRepoTracer.Start(ctx, "GetFetch")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("author_id", authorID),
+		attribute.String("cursor", cursor),
+	)
+
+	query := `
+		SELECT x_author_id, page_cursor, cycle_start, status, claim_id, claimed_until,
+			response, error, error_status, fetched_at
+		FROM timeline_fetches
+		WHERE x_author_id = $1 AND page_cursor = $2 AND cycle_start = $3
+	`
+
+	var fetch db.TimelineFetch
+	err := r.db.GetContext(ctx, &fetch, query, authorID, cursor, cycleStart)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get timeline fetch: %w", err)
+	}
+
+	return &fetch, nil
+}
+
+// CompleteFetch stores the fetched page of a claimed fetch
+// Does nothing if the claim was taken over by another run
+func (r *TimelineFetchRepository) CompleteFetch(ctx context.Context, authorID int64, cursor string, cycleStart time.Time, claimID string, response []byte) error {
+	ctx, span := timelineFetchRepoTracer.Start(ctx, "CompleteFetch")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("author_id", authorID),
+		attribute.String("cursor", cursor),
+	)
+
+	query := `
+		UPDATE timeline_fetches
+		SET status = 'done', response = $5, fetched_at = NOW()
+		WHERE x_author_id = $1 AND page_cursor = $2 AND cycle_start = $3 AND claim_id = $4
+	`
+
+	_, err := r.db.ExecContext(ctx, query, authorID, cursor, cycleStart, claimID, string(response))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to complete timeline fetch: %w", err)
+	}
+
+	return nil
+}
+
+// FailFetch records the error of a claimed fetch for the runs waiting for it
+// errorStatus is the HTTP status of an API error, 0 for other errors.
+// Does nothing if the claim was taken over by another run
+func (r *TimelineFetchRepository) FailFetch(ctx context.Context, authorID int64, cursor string, cycleStart time.Time, claimID string, errText string, errorStatus int) error {
+	ctx, span := timelineFetchRepoTracer.Start(ctx, "FailFetch")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("author_id", authorID),
+		attribute.String("cursor", cursor),
+		attribute.Int("error_status", errorStatus),
+	)
+
+	query := `
+		UPDATE timeline_fetches
+		SET status = 'failed', error = $5, error_status = NULLIF($6, 0), fetched_at = NOW()
+		WHERE x_author_id = $1 AND page_cursor = $2 AND cycle_start = $3 AND claim_id = $4
+	`
+
+	_, err := r.db.ExecContext(ctx, query, authorID, cursor, cycleStart, claimID, errText, errorStatus)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to record timeline fetch error: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseFetch drops a claimed fetch that was abandoned, so a waiting run claims it instead
+// Does nothing if the claim was taken over by another run
+func (r *TimelineFetchRepository) ReleaseFetch(ctx context.Context, authorID int64, cursor string, cycleStart time.Time, claimID string) error {
+	ctx, span := timelineFetchRepoTracer.Start(ctx, "ReleaseFetch")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("author_id", authorID),
+		attribute.String("cursor", cursor),
+	)
+
+	query := `
+		DELETE FROM timeline_fetches
+		WHERE x_author_id = $1 AND page_cursor = $2 AND cycle_start = $3 AND claim_id = $4
+		  AND status = 'fetching'
+	`
+
+	_, err := r.db.ExecContext(ctx, query, authorID, cursor, cycleStart, claimID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to release timeline fetch: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFetchesBefore removes fetches of cycles that started before cycleStart
+// Returns the number of fetches removed
+func (r *TimelineFetchRepository) DeleteFetchesBefore(ctx context.Context, cycleStart time.Time) (int, error) {
+	ctx, span := timelineFetchRepoTracer.Start(ctx, "DeleteFetchesBefore")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("cycle_start", cycleStart.Format(time.RFC3339)))
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM timeline_fetches WHERE cycle_start < $1", cycleStart)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to delete past timeline fetches: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("deleted_count", rowsAffected))
+
+	return int(rowsAffected), nil
+}
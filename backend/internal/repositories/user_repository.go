@@ -17,6 +17,10 @@ type UserRepository interface {
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*db.User, error)
 	EmailExists(ctx context.Context, email string) (bool, error)
 	GetFollowingCount(ctx context.Context, userID uuid.UUID) (int, error)
+	GetExcludedFollowingCount(ctx context.Context, userID uuid.UUID) (int, error)
+	GetExcludedFollowing(ctx context.Context, userID uuid.UUID, limit int) ([]db.FollowingItem, error)
+	GetExcludedListAuthorCount(ctx context.Context, userID uuid.UUID) (int, error)
+	ListUserIDs(ctx context.Context) ([]uuid.UUID, error)
 }
 
 type userRepository struct {
@@ -33,7 +37,7 @@ func (r *userRepository) CreateUser(ctx context.Context, email, passwordHash, xU
 	query := `
 		INSERT INTO users (email, password_hash, x_username, x_display_name, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, NOW(), NOW())
-		RETURNING id, email, password_hash, x_username, x_display_name, created_at, updated_at
+		RETURNING id, email, password_hash, x_username, x_display_name, plan, following_limit, created_at, updated_at
 	`
 
 	var user db.User
@@ -48,7 +52,7 @@ func (r *userRepository) CreateUser(ctx context.Context, email, passwordHash, xU
 // GetUserByEmail retrieves a user by email
 func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*db.User, error) {
 	query := `
-		SELECT id, email, password_hash, x_username, x_display_name, created_at, updated_at
+		SELECT id, email, password_hash, x_username, x_display_name, plan, following_limit, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -68,7 +72,7 @@ func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*db.
 // GetUserByID retrieves a user by ID
 func (r *userRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*db.User, error) {
 	query := `
-		SELECT id, email, password_hash, x_username, x_display_name, created_at, updated_at
+		SELECT id, email, password_hash, x_username, x_display_name, plan, following_limit, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -103,7 +107,7 @@ func (r *userRepository) GetFollowingCount(ctx context.Context, userID uuid.UUID
 	query := `
 		SELECT COUNT(*)
 		FROM user_following
-		WHERE user_id = $1
+		WHERE user_id = $1 AND unfollowed_at IS NULL
 	`
 
 	var count int
@@ -114,3 +118,87 @@ func (r *userRepository) GetFollowingCount(ctx context.Context, userID uuid.UUID
 
 	return count, nil
 }
+
+// GetExcludedFollowingCount returns the count of followed authors left out by the user's following limit
+func (r *userRepository) GetExcludedFollowingCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM user_following
+		WHERE user_id = $1 AND unfollowed_at IS NULL AND excluded_at IS NOT NULL
+	`
+
+	var count int
+	err := r.db.GetContext(ctx, &count, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get excluded following count: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetExcludedFollowing returns up to limit followed authors left out by the user's following limit
+// Returns the most active first (authors.last_seen_at DESC, authors without ingested tweets last)
+func (r *userRepository) GetExcludedFollowing(ctx context.Context, userID uuid.UUID, limit int) ([]db.FollowingItem, error) {
+	query := `
+		SELECT
+			a.x_author_id,
+			a.handle,
+			a.display_name,
+			a.last_seen_at,
+			uf.last_checked_at,
+			uf.pinned,
+			uf.excluded_at
+		FROM user_following uf
+		INNER JOIN authors a ON uf.x_author_id = a.x_author_id
+		WHERE uf.user_id = $1 AND uf.unfollowed_at IS NULL AND uf.excluded_at IS NOT NULL
+		ORDER BY a.last_seen_at DESC NULLS LAST, a.x_author_id DESC
+		LIMIT $2
+	`
+
+	var items []db.FollowingItem
+	err := r.db.SelectContext(ctx, &items, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excluded following: %w", err)
+	}
+
+	if items == nil {
+		items = []db.FollowingItem{}
+	}
+
+	return items, nil
+}
+
+// GetExcludedListAuthorCount returns the count of listed authors left out by the user's list author limit
+// An author in several of the user's lists is counted once
+func (r *userRepository) GetExcludedListAuthorCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT x_author_id)
+		FROM feed_list_members
+		WHERE user_id = $1 AND excluded_at IS NOT NULL
+	`
+
+	var count int
+	err := r.db.GetContext(ctx, &count, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get excluded list author count: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListUserIDs returns the IDs of all registered users ordered by creation time
+func (r *userRepository) ListUserIDs(ctx context.Context) ([]uuid.UUID, error) {
+	query := `
+		SELECT id
+		FROM users
+		ORDER BY created_at ASC
+	`
+
+	var ids []uuid.UUID
+	err := r.db.SelectContext(ctx, &ids, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user IDs: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var watermarkRepoTracer = otel.Tracer("watermark_repository")
+
+// WatermarkRepository handles author_watermarks data access operations
+type WatermarkRepository struct {
+	db *sqlx.DB
+}
+
+// NewWatermarkRepository creates a new WatermarkRepository instance
+func NewWatermarkRepository(database *sqlx.DB) *WatermarkRepository {
+	return &WatermarkRepository{
+		db: database,
+	}
+}
+
+// GetWatermark retrieves the watermark of a followed author
+// Returns nil if the author was never synced for this user
+func (r *WatermarkRepository) GetWatermark(ctx context.Context, userID uuid.UUID, authorID int64) (*db.AuthorWatermark, error) {
+	ctx, span := watermarkRepoTracer.Start(ctx, "GetWatermark")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("author_id", authorID),
+	)
+
+	query := `
+		SELECT user_id, x_author_id, last_tweet_id, last_tweet_at,
+			resume_cursor, pending_tweet_id, pending_tweet_at, updated_at
+		FROM author_watermarks
+		WHERE user_id = $1 AND x_author_id = $2
+	`
+
+	var watermark db.AuthorWatermark
+	err := r.db.GetContext(ctx, &watermark, query, userID, authorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get author watermark: %w", err)
+	}
+
+	return &watermark, nil
+}
+
+// AdvanceWatermark stores the newest tweet ingested for a followed author once paging reached the old watermark
+// The watermark only moves forward; an older tweet ID leaves it unchanged. Any resume point is cleared
+func (r *WatermarkRepository) AdvanceWatermark(ctx context.Context, userID uuid.UUID, authorID int64, lastTweetID int64, lastTweetAt time.Time) error {
+	ctx, span := watermarkRepoTracer.Start(ctx, "AdvanceWatermark")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("author_id", authorID),
+		attribute.Int64("last_tweet_id", lastTweetID),
+	)
+
+	query := `
+		INSERT INTO author_watermarks (user_id, x_author_id, last_tweet_id, last_tweet_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id, x_author_id) DO UPDATE
+		SET last_tweet_id = GREATEST(author_watermarks.last_tweet_id, EXCLUDED.last_tweet_id),
+			last_tweet_at = CASE
+				WHEN EXCLUDED.last_tweet_id > author_watermarks.last_tweet_id THEN EXCLUDED.last_tweet_at
+				ELSE author_watermarks.last_tweet_at
+			END,
+			resume_cursor = NULL,
+			pending_tweet_id = NULL,
+			pending_tweet_at = NULL,
+			updated_at = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, authorID, lastTweetID, lastTweetAt)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to advance author watermark: %w", err)
+	}
+
+	return nil
+}
+
+// SaveResumePoint records where a run catching up to the watermark stopped, leaving the watermark itself unchanged
+// cursor is the timeline cursor the next run continues from (empty clears it); the pending tweet only moves forward.
+// Authors without a watermark have nothing to catch up to and are left alone
+func (r *WatermarkRepository) SaveResumePoint(ctx context.Context, userID uuid.UUID, authorID int64, cursor string, pendingTweetID int64, pendingTweetAt time.Time) error {
+	ctx, span := watermarkRepoTracer.Start(ctx, "SaveResumePoint")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("author_id", authorID),
+		attribute.Int64("pending_tweet_id", pendingTweetID),
+		attribute.Bool("has_cursor", cursor != ""),
+	)
+
+	query := `
+		UPDATE author_watermarks
+		SET resume_cursor = NULLIF($3, ''),
+			pending_tweet_id = GREATEST(pending_tweet_id, $4),
+			pending_tweet_at = CASE
+				WHEN pending_tweet_id IS NULL OR $4 > pending_tweet_id THEN $5
+				ELSE pending_tweet_at
+			END,
+			updated_at = NOW()
+		WHERE user_id = $1 AND x_author_id = $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, authorID, cursor, pendingTweetID, pendingTweetAt)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to save author resume point: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserSinceID returns the newest tweet ID ingested across all authors the user follows
+// Returns 0 if the user has no watermarks yet
+func (r *WatermarkRepository) GetUserSinceID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	ctx, span := watermarkRepoTracer.Start(ctx, "GetUserSinceID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	query := `
+		SELECT COALESCE(MAX(last_tweet_id), 0)
+		FROM author_watermarks
+		WHERE user_id = $1
+	`
+
+	var sinceID int64
+	err := r.db.GetContext(ctx, &sinceID, query, userID)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("failed to get user since_id: %w", err)
+	}
+
+	return sinceID, nil
+}
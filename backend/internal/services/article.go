@@ -0,0 +1,154 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// ArticleChunkSize is the maximum length of a stored article chunk (in characters)
+	ArticleChunkSize = 2000
+
+	// MaxArticleChunks caps how many chunks of an article are stored; the rest of a longer article is dropped
+	MaxArticleChunks = 50
+
+	// ArticlePromptBudget is the number of characters of an article included in a Q&A prompt
+	// (about 2000 tokens); longer articles are cut down to the chunks most relevant to the question
+	ArticlePromptBudget = 8000
+)
+
+// articleStopWords are question words too common to tell article chunks apart
+var articleStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "was": true, "were": true, "what": true,
+	"which": true, "who": true, "whom": true, "why": true, "how": true, "when": true, "where": true,
+	"does": true, "did": true, "has": true, "have": true, "had": true, "about": true, "with": true,
+	"from": true, "that": true, "this": true, "these": true, "those": true, "there": true, "their": true,
+	"they": true, "them": true, "his": true, "her": true, "its": true, "into": true, "any": true,
+	"say": true, "said": true, "says": true, "tell": true, "you": true, "your": true, "can": true,
+	"could": true, "would": true, "should": true, "will": true, "not": true, "but": true, "all": true,
+}
+
+// ArticleBody joins the content blocks of an article into paragraphs, skipping empty blocks
+func ArticleBody(article ArticleData) string {
+	paragraphs := make([]string, 0, len(article.Contents))
+	for _, content := range article.Contents {
+		if text := strings.TrimSpace(content.Text); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// ChunkArticle splits an article body into chunks of at most size characters
+// Chunks end at paragraph boundaries; a paragraph longer than size is split between words,
+// and a word longer than size is cut
+func ChunkArticle(body string, size int) []string {
+	var chunks []string
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() > 0 {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+	}
+	// add appends text to the current chunk, starting a new one when it does not fit
+	add := func(text, separator string) {
+		if current.Len() > 0 && utf8.RuneCountInString(current.String())+utf8.RuneCountInString(separator+text) > size {
+			flush()
+		}
+		if current.Len() > 0 {
+			current.WriteString(separator)
+		}
+		current.WriteString(text)
+	}
+
+	for _, paragraph := range strings.Split(body, "\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		if utf8.RuneCountInString(paragraph) <= size {
+			add(paragraph, "\n\n")
+			continue
+		}
+
+		flush()
+		for _, word := range strings.Fields(paragraph) {
+			for utf8.RuneCountInString(word) > size {
+				flush()
+				runes := []rune(word)
+				chunks = append(chunks, string(runes[:size]))
+				word = string(runes[size:])
+			}
+			if word != "" {
+				add(word, " ")
+			}
+		}
+		flush()
+	}
+	flush()
+
+	return chunks
+}
+
+// SelectArticleChunks picks the chunks of an article to include in a Q&A prompt within budget characters
+// The first chunk is always kept as the article's introduction; the remaining budget goes to the chunks
+// sharing the most words with the question, or to the leading chunks if none does.
+// Returns the indexes of the selected chunks in article order
+func SelectArticleChunks(chunks []string, question string, budget int) []int {
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	terms := articleTerms(question)
+	scores := make([]int, len(chunks))
+	for i, chunk := range chunks {
+		chunkTerms := articleTerms(chunk)
+		for term := range terms {
+			if chunkTerms[term] {
+				scores[i]++
+			}
+		}
+	}
+
+	// Candidates after the introduction, best match first; equal matches keep article order
+	candidates := make([]int, 0, len(chunks)-1)
+	for i := 1; i < len(chunks); i++ {
+		candidates = append(candidates, i)
+	}
+	slices.SortStableFunc(candidates, func(a, b int) int {
+		return scores[b] - scores[a]
+	})
+
+	selected := []int{0}
+	used := utf8.RuneCountInString(chunks[0])
+	for _, i := range candidates {
+		length := utf8.RuneCountInString(chunks[i])
+		if used+length > budget {
+			continue
+		}
+		selected = append(selected, i)
+		used += length
+	}
+
+	slices.Sort(selected)
+	return selected
+}
+
+// articleTerms returns the distinct lowercased words of text, without short and stop words
+func articleTerms(text string) map[string]bool {
+	terms := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if utf8.RuneCountInString(word) < 3 || articleStopWords[word] {
+			continue
+		}
+		terms[word] = true
+	}
+	return terms
+}
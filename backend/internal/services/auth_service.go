@@ -47,6 +47,9 @@ func NewAuthService(
 const (
 	sessionDuration = 7 * 24 * time.Hour // 7 days
 	bcryptCost      = 12                 // bcrypt cost factor
+
+	// SessionExcludedAuthorsLimit caps the excluded authors listed in the session; GET /api/v1/following lists all
+	SessionExcludedAuthorsLimit = 20
 )
 
 // Register creates a new user account
@@ -198,15 +201,49 @@ func (s *authService) GetCurrentSession(ctx context.Context, userID uuid.UUID) (
 		return nil, fmt.Errorf("failed to get following count: %w", err)
 	}
 
+	// Get authors left out by the following limit at the last following sync
+	excludedCount, err := s.userRepo.GetExcludedFollowingCount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excluded following count: %w", err)
+	}
+
+	excluded, err := s.userRepo.GetExcludedFollowing(ctx, userID, SessionExcludedAuthorsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excluded following: %w", err)
+	}
+
+	// Get listed authors left out by the list author limit at the last list sync
+	excludedListAuthorCount, err := s.userRepo.GetExcludedListAuthorCount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excluded list author count: %w", err)
+	}
+
+	excludedAuthors := make([]dto.ExcludedAuthorDTO, len(excluded))
+	for i, item := range excluded {
+		excludedAuthors[i] = dto.ExcludedAuthorDTO{
+			XAuthorID:   item.XAuthorID,
+			Handle:      item.Handle,
+			DisplayName: convertStringPtr(item.DisplayName),
+			LastSeenAt:  item.LastSeenAt,
+			ExcludedAt:  *item.ExcludedAt,
+		}
+	}
+
 	return &dto.SessionDTO{
-		UserID:           user.ID,
-		Email:            user.Email,
-		XUsername:        user.XUsername,
-		XDisplayName:     user.XDisplayName,
-		AuthenticatedAt:  user.CreatedAt,
-		SessionExpiresAt: time.Now().Add(sessionDuration),
-		FollowingCount:   followingCount,
-		FollowingLimit:   150, // Fixed limit as per requirements
+		UserID:              user.ID,
+		Email:               user.Email,
+		XUsername:           user.XUsername,
+		XDisplayName:        user.XDisplayName,
+		AuthenticatedAt:     user.CreatedAt,
+		SessionExpiresAt:    time.Now().Add(sessionDuration),
+		FollowingCount:      followingCount,
+		FollowingLimit:      FollowingLimitFor(user),
+		Plan:                user.Plan,
+		ExcludedAuthorCount: excludedCount,
+		ExcludedAuthors:     excludedAuthors,
+
+		ListAuthorLimit:         ListAuthorLimitFor(user),
+		ExcludedListAuthorCount: excludedListAuthorCount,
 	}, nil
 }
 
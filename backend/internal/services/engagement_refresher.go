@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var engagementRefresherTracer = otel.Tracer("engagement_refresher")
+
+const (
+	// DefaultEngagementRefreshInterval is how often engagement metrics of recent posts are re-read
+	DefaultEngagementRefreshInterval = 6 * time.Hour
+
+	// DefaultEngagementRefreshWindow is how far back posts are refreshed; traction settles within a few days
+	DefaultEngagementRefreshWindow = 72 * time.Hour
+
+	// DefaultEngagementBatchSize is the number of tweet IDs looked up per batch request
+	DefaultEngagementBatchSize = 100
+
+	// DefaultEngagementMaxPosts caps the posts refreshed per cycle; the least recently refreshed go first
+	DefaultEngagementMaxPosts = 5000
+)
+
+// EngagementRefresherConfig holds configuration for the periodic engagement refresher
+type EngagementRefresherConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	Window    time.Duration
+	BatchSize int
+	MaxPosts  int
+}
+
+// DefaultEngagementRefresherConfig returns the default engagement refresher configuration
+func DefaultEngagementRefresherConfig() EngagementRefresherConfig {
+	return EngagementRefresherConfig{
+		Enabled:   true,
+		Interval:  DefaultEngagementRefreshInterval,
+		Window:    DefaultEngagementRefreshWindow,
+		BatchSize: DefaultEngagementBatchSize,
+		MaxPosts:  DefaultEngagementMaxPosts,
+	}
+}
+
+// EngagementPostStore reads and updates the posts whose engagement is refreshed
+// It is implemented by repositories.PostRepository
+type EngagementPostStore interface {
+	// ListPostIDsForEngagementRefresh returns IDs of posts published since the given time, least recently refreshed first
+	ListPostIDsForEngagementRefresh(ctx context.Context, since time.Time, limit int) ([]int64, error)
+
+	// ApplyPostText records an edit if the re-fetched text differs; returns true if an edit was recorded
+	ApplyPostText(ctx context.Context, postID int64, rawText string, detectedAt time.Time) (bool, error)
+
+	// RecordPostEngagement stores the latest metrics of posts and adds them to the engagement time series
+	RecordPostEngagement(ctx context.Context, snapshots []db.PostEngagement) error
+
+	// MarkPostsDeleted marks posts deleted on X; returns the number of posts newly marked
+	MarkPostsDeleted(ctx context.Context, postIDs []int64, deletedAt time.Time) (int, error)
+}
+
+// EngagementRefresher periodically re-reads engagement metrics of recent posts
+// Each refresh updates the posts' latest metrics and adds a point to the post_engagement time series.
+// The same batch lookup records edits and marks posts deleted on X.
+// Every process runs its own refresher; disable it on all but one replica
+type EngagementRefresher struct {
+	twitterClient *TwitterClient
+	postRepo      EngagementPostStore
+	config        EngagementRefresherConfig
+}
+
+// NewEngagementRefresher creates a new EngagementRefresher instance
+func NewEngagementRefresher(
+	twitterClient *TwitterClient,
+	postRepo EngagementPostStore,
+	config EngagementRefresherConfig,
+) *EngagementRefresher {
+	if config.Interval <= 0 {
+		config.Interval = DefaultEngagementRefreshInterval
+	}
+	if config.Window <= 0 {
+		config.Window = DefaultEngagementRefreshWindow
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultEngagementBatchSize
+	}
+	if config.MaxPosts <= 0 {
+		config.MaxPosts = DefaultEngagementMaxPosts
+	}
+
+	return &EngagementRefresher{
+		twitterClient: twitterClient,
+		postRepo:      postRepo,
+		config:        config,
+	}
+}
+
+// Run starts the refresh loop and blocks until ctx is cancelled
+func (r *EngagementRefresher) Run(ctx context.Context) {
+	if !r.config.Enabled {
+		logger.Info("engagement refresher disabled")
+		return
+	}
+
+	logger.Info("engagement refresher started",
+		"interval", r.config.Interval.String(),
+		"window", r.config.Window.String(),
+		"max_posts", r.config.MaxPosts)
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("engagement refresher stopped")
+			return
+		case <-ticker.C:
+			r.Refresh(ctx)
+		}
+	}
+}
+
+// engagementBatchResult counts the outcome of one batch lookup
+type engagementBatchResult struct {
+	refreshed int
+	edited    int
+	deleted   int
+}
+
+// Refresh re-reads engagement metrics of posts published within the refresh window
+// Returns the number of posts refreshed; failed batches are logged and skipped
+func (r *EngagementRefresher) Refresh(ctx context.Context) int {
+	ctx, span := engagementRefresherTracer.Start(ctx, "Refresh")
+	defer span.End()
+
+	postIDs, err := r.postRepo.ListPostIDsForEngagementRefresh(ctx, time.Now().Add(-r.config.Window), r.config.MaxPosts)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("engagement refresher failed to list posts", err)
+		return 0
+	}
+
+	var totals engagementBatchResult
+	failedBatches := 0
+	for start := 0; start < len(postIDs); start += r.config.BatchSize {
+		if ctx.Err() != nil {
+			break
+		}
+
+		batch := postIDs[start:min(start+r.config.BatchSize, len(postIDs))]
+		result, err := r.refreshBatch(ctx, batch)
+		if err != nil {
+			span.RecordError(err)
+			logger.Warn("failed to refresh engagement batch, skipping",
+				"error", err,
+				"batch_size", len(batch))
+			failedBatches++
+			continue
+		}
+		totals.refreshed += result.refreshed
+		totals.edited += result.edited
+		totals.deleted += result.deleted
+	}
+
+	span.SetAttributes(
+		attribute.Int("posts_listed", len(postIDs)),
+		attribute.Int("posts_refreshed", totals.refreshed),
+		attribute.Int("posts_edited", totals.edited),
+		attribute.Int("posts_deleted", totals.deleted),
+		attribute.Int("failed_batches", failedBatches),
+	)
+
+	logger.Info("engagement refresh completed",
+		"posts_listed", len(postIDs),
+		"posts_refreshed", totals.refreshed,
+		"posts_edited", totals.edited,
+		"posts_deleted", totals.deleted,
+		"failed_batches", failedBatches)
+
+	return totals.refreshed
+}
+
+// refreshBatch looks up one batch of tweets, records their engagement and edits,
+// and marks the tweets missing from the response as deleted
+func (r *EngagementRefresher) refreshBatch(ctx context.Context, postIDs []int64) (engagementBatchResult, error) {
+	ctx, span := engagementRefresherTracer.Start(ctx, "refreshBatch")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("batch_size", len(postIDs)))
+
+	tweetIDs := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		tweetIDs[i] = strconv.FormatInt(id, 10)
+	}
+
+	resp, _, _, err := withRetry(ctx, "tweets by IDs", "", func() (*TweetsByIDsResponse, error) {
+		return r.twitterClient.GetTweetsByIDs(ctx, tweetIDs)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return engagementBatchResult{}, err
+	}
+
+	var result engagementBatchResult
+	capturedAt := time.Now()
+	returned := make(map[int64]struct{}, len(resp.Tweets))
+	snapshots := make([]db.PostEngagement, 0, len(resp.Tweets))
+	for _, tweet := range resp.Tweets {
+		tweetID, err := strconv.ParseInt(tweet.ID, 10, 64)
+		if err != nil || tweetID <= 0 {
+			continue
+		}
+		returned[tweetID] = struct{}{}
+
+		edited, err := r.postRepo.ApplyPostText(ctx, tweetID, tweet.Text, capturedAt)
+		if err != nil {
+			logger.Warn("failed to check post for edits",
+				"error", err,
+				"post_id", tweetID)
+		} else if edited {
+			result.edited++
+		}
+
+		snapshots = append(snapshots, db.PostEngagement{
+			XPostID:    tweetID,
+			CapturedAt: capturedAt,
+			PostEngagementCounts: db.PostEngagementCounts{
+				LikeCount:     int64(max(tweet.LikeCount, 0)),
+				RetweetCount:  int64(max(tweet.RetweetCount, 0)),
+				ReplyCount:    int64(max(tweet.ReplyCount, 0)),
+				QuoteCount:    int64(max(tweet.QuoteCount, 0)),
+				ViewCount:     int64(max(tweet.ViewCount, 0)),
+				BookmarkCount: int64(max(tweet.BookmarkCount, 0)),
+			},
+		})
+	}
+
+	if err := r.postRepo.RecordPostEngagement(ctx, snapshots); err != nil {
+		span.RecordError(err)
+		return engagementBatchResult{}, err
+	}
+	result.refreshed = len(snapshots)
+
+	// An empty response is more likely an API glitch than every tweet of the batch being deleted
+	if len(returned) > 0 {
+		missing := make([]int64, 0, len(postIDs))
+		for _, id := range postIDs {
+			if _, ok := returned[id]; !ok {
+				missing = append(missing, id)
+			}
+		}
+
+		deleted, err := r.postRepo.MarkPostsDeleted(ctx, missing, capturedAt)
+		if err != nil {
+			span.RecordError(err)
+			logger.Warn("failed to mark deleted posts",
+				"error", err,
+				"missing_count", len(missing))
+		}
+		result.deleted = deleted
+	}
+
+	span.SetAttributes(
+		attribute.Int("tweets_returned", len(resp.Tweets)),
+		attribute.Int("posts_edited", result.edited),
+		attribute.Int("posts_deleted", result.deleted),
+	)
+
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var feedListServiceTracer = otel.Tracer("feed_list_service")
+
+// MaxFeedLists is the maximum number of X lists a user can register as feed sources
+const MaxFeedLists = 10
+
+// Common errors
+var (
+	ErrFeedListNotFound = errors.New("feed list not found")
+	ErrFeedListExists   = errors.New("feed list already registered")
+	ErrTooManyFeedLists = errors.New("too many feed lists")
+)
+
+// FeedListService handles business logic for X lists registered as feed sources
+type FeedListService struct {
+	feedListRepo *repositories.FeedListRepository
+}
+
+// NewFeedListService creates a new FeedListService instance
+func NewFeedListService(feedListRepo *repositories.FeedListRepository) *FeedListService {
+	return &FeedListService{
+		feedListRepo: feedListRepo,
+	}
+}
+
+// GetLists retrieves the lists the user registered as feed sources
+func (s *FeedListService) GetLists(ctx context.Context, userID uuid.UUID) (*dto.FeedListsResponseDTO, error) {
+	ctx, span := feedListServiceTracer.Start(ctx, "GetLists")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	items, err := s.feedListRepo.GetFeedLists(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get feed lists: %w", err)
+	}
+
+	dtoItems := make([]dto.FeedListDTO, len(items))
+	for i, item := range items {
+		dtoItems[i] = convertFeedList(item.FeedList, item.MemberCount, item.ExcludedMemberCount)
+	}
+
+	span.SetAttributes(attribute.Int("items_returned", len(dtoItems)))
+
+	return &dto.FeedListsResponseDTO{Items: dtoItems}, nil
+}
+
+// AddList registers an X list as a feed source
+// Its members are read at the next ingestion run; returns ErrFeedListExists or ErrTooManyFeedLists
+func (s *FeedListService) AddList(ctx context.Context, userID uuid.UUID, cmd dto.AddFeedListCommand) (*dto.FeedListDTO, error) {
+	ctx, span := feedListServiceTracer.Start(ctx, "AddList")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("list_id", cmd.XListID),
+	)
+
+	lists, err := s.feedListRepo.GetFeedLists(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get feed lists: %w", err)
+	}
+	if len(lists) >= MaxFeedLists {
+		return nil, ErrTooManyFeedLists
+	}
+
+	var name *string
+	if trimmed := strings.TrimSpace(cmd.Name); trimmed != "" {
+		name = &trimmed
+	}
+
+	list, err := s.feedListRepo.InsertFeedList(ctx, userID, cmd.XListID, name)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to add feed list: %w", err)
+	}
+	if list == nil {
+		return nil, ErrFeedListExists
+	}
+
+	result := convertFeedList(*list, 0, 0)
+	return &result, nil
+}
+
+// RemoveList unregisters a list; posts ingested through it are kept but no longer tagged with it
+// Returns ErrFeedListNotFound if the user did not register the list
+func (s *FeedListService) RemoveList(ctx context.Context, userID uuid.UUID, listID int64) error {
+	ctx, span := feedListServiceTracer.Start(ctx, "RemoveList")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("list_id", listID),
+	)
+
+	found, err := s.feedListRepo.DeleteFeedList(ctx, userID, listID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to remove feed list: %w", err)
+	}
+	if !found {
+		return ErrFeedListNotFound
+	}
+
+	return nil
+}
+
+// convertFeedList converts a stored feed list to its DTO
+func convertFeedList(list db.FeedList, memberCount int, excludedMemberCount int) dto.FeedListDTO {
+	return dto.FeedListDTO{
+		XListID:             list.XListID,
+		Name:                convertStringPtr(list.Name),
+		MemberCount:         memberCount,
+		ExcludedMemberCount: excludedMemberCount,
+		CreatedAt:           list.CreatedAt,
+		LastSyncedAt:        list.LastSyncedAt,
+	}
+}
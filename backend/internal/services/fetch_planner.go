@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var fetchPlannerTracer = otel.Tracer("fetch_planner")
+
+const (
+	// DefaultFetchCycle is the window in which each page of a shared author's timeline is fetched once
+	// A follower synced late in a cycle gets a page up to one cycle old; the next cycle fetches it again
+	DefaultFetchCycle = time.Hour
+
+	// DefaultFetchLease is how long a run holds the fetch of a shared page before another run may take it over
+	DefaultFetchLease = time.Minute
+
+	// DefaultFetchPollInterval is how often a run waiting for a page fetched by another run checks on it
+	DefaultFetchPollInterval = 500 * time.Millisecond
+)
+
+// FetchPlannerConfig holds configuration for sharing author timeline fetches between users
+type FetchPlannerConfig struct {
+	Cycle        time.Duration // 0 disables sharing
+	Lease        time.Duration
+	PollInterval time.Duration
+}
+
+// DefaultFetchPlannerConfig returns the default fetch planner configuration
+func DefaultFetchPlannerConfig() FetchPlannerConfig {
+	return FetchPlannerConfig{
+		Cycle:        DefaultFetchCycle,
+		Lease:        DefaultFetchLease,
+		PollInterval: DefaultFetchPollInterval,
+	}
+}
+
+// FetchPlanner fetches each page of a shared author's timeline once per cycle across all users and replicas
+// Cycles are fixed windows of time aligned to the epoch. Authors ingested for more than one user are fetched
+// by the first run that claims the page in timeline_fetches; runs of the other followers, in this process or
+// another one, wait for that fetch and read the stored page, or get its error. Each run applies its own
+// filters (watermark, backfill cutoff) before writing the follower's posts. Authors with a single follower
+// are fetched directly
+type FetchPlanner struct {
+	twitterClient *TwitterClient
+	followingRepo *repositories.FollowingRepository
+	fetchRepo     *repositories.TimelineFetchRepository
+	config        FetchPlannerConfig
+
+	sharedMu      sync.Mutex
+	sharedAuthors map[int64]struct{}
+	sharedCycle   time.Time
+}
+
+// sharedTimelinePage is a fetched timeline page as stored in timeline_fetches.response
+type sharedTimelinePage struct {
+	Tweets      []TweetData `json:"tweets"`
+	HasNextPage bool        `json:"has_next_page"`
+	NextCursor  string      `json:"next_cursor"`
+}
+
+// NewFetchPlanner creates a new FetchPlanner instance
+func NewFetchPlanner(
+	twitterClient *TwitterClient,
+	followingRepo *repositories.FollowingRepository,
+	fetchRepo *repositories.TimelineFetchRepository,
+	config FetchPlannerConfig,
+) *FetchPlanner {
+	if config.Cycle < 0 {
+		config.Cycle = 0
+	}
+	if config.Lease <= 0 {
+		config.Lease = DefaultFetchLease
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultFetchPollInterval
+	}
+
+	return &FetchPlanner{
+		twitterClient: twitterClient,
+		followingRepo: followingRepo,
+		fetchRepo:     fetchRepo,
+		config:        config,
+	}
+}
+
+// GetAuthorTweets returns a page of an author's tweets, reusing the fetch of the current cycle when the author
+// is ingested for more than one user. A failed fetch is returned to every run waiting for it
+func (p *FetchPlanner) GetAuthorTweets(ctx context.Context, authorID int64, handle string, cursor string) (*TweetResponse, error) {
+	if p.config.Cycle == 0 {
+		return p.twitterClient.GetUserTweets(ctx, handle, cursor)
+	}
+
+	cycleStart := time.Now().UTC().Truncate(p.config.Cycle)
+	if !p.isShared(ctx, authorID, cycleStart) {
+		return p.twitterClient.GetUserTweets(ctx, handle, cursor)
+	}
+
+	ctx, span := fetchPlannerTracer.Start(ctx, "GetAuthorTweets")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("author_id", authorID),
+		attribute.String("cursor", cursor),
+		attribute.String("cycle_start", cycleStart.Format(time.RFC3339)),
+	)
+
+	claimID := ulid.Make().String()
+	for {
+		claimed, err := p.fetchRepo.ClaimFetch(ctx, authorID, cursor, cycleStart, claimID, p.config.Lease)
+		if err != nil {
+			if isContextError(err) {
+				return nil, err
+			}
+			logger.Warn("failed to claim shared timeline fetch, fetching for this user only",
+				"error", err,
+				"author_id", authorID)
+			return p.twitterClient.GetUserTweets(ctx, handle, cursor)
+		}
+
+		if claimed {
+			span.SetAttributes(attribute.Bool("shared", false))
+			return p.fetch(ctx, authorID, handle, cursor, cycleStart, claimID)
+		}
+
+		resp, retry, err := p.wait(ctx, authorID, cursor, cycleStart)
+		if retry {
+			// The fetching run gave up; claim the page again
+			continue
+		}
+		if err == nil {
+			span.SetAttributes(attribute.Bool("shared", true))
+		} else {
+			span.RecordError(err)
+		}
+		return resp, err
+	}
+}
+
+// fetch fetches a claimed page and stores the outcome for the runs waiting for it
+// A fetch abandoned because ctx ended is released, so a waiting run fetches the page itself
+func (p *FetchPlanner) fetch(ctx context.Context, authorID int64, handle string, cursor string, cycleStart time.Time, claimID string) (*TweetResponse, error) {
+	resp, err := p.twitterClient.GetUserTweets(ctx, handle, cursor)
+
+	// The outcome is stored even when the run was cancelled meanwhile
+	storeCtx := context.WithoutCancel(ctx)
+
+	if err != nil {
+		if isContextError(err) {
+			if releaseErr := p.fetchRepo.ReleaseFetch(storeCtx, authorID, cursor, cycleStart, claimID); releaseErr != nil {
+				logger.Warn("failed to release shared timeline fetch",
+					"error", releaseErr,
+					"author_id", authorID)
+			}
+			return nil, err
+		}
+
+		errorStatus := 0
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			errorStatus = apiErr.StatusCode
+		}
+		if failErr := p.fetchRepo.FailFetch(storeCtx, authorID, cursor, cycleStart, claimID, err.Error(), errorStatus); failErr != nil {
+			logger.Warn("failed to record shared timeline fetch error",
+				"error", failErr,
+				"author_id", authorID)
+		}
+		return nil, err
+	}
+
+	response, err := json.Marshal(sharedTimelinePage{
+		Tweets:      resp.Tweets,
+		HasNextPage: resp.HasNextPage,
+		NextCursor:  resp.NextCursor,
+	})
+	if err == nil {
+		err = p.fetchRepo.CompleteFetch(storeCtx, authorID, cursor, cycleStart, claimID, response)
+	}
+	if err != nil {
+		// Waiting runs take over once the claim expires
+		logger.Warn("failed to store shared timeline page",
+			"error", err,
+			"author_id", authorID)
+	}
+
+	return resp, nil
+}
+
+// wait polls a page fetched by another run until that fetch finished
+// Returns retry=true when the fetch was released or its claim expired, so the caller claims the page itself.
+// A database error ends the wait with that error
+func (p *FetchPlanner) wait(ctx context.Context, authorID int64, cursor string, cycleStart time.Time) (*TweetResponse, bool, error) {
+	for {
+		fetch, err := p.fetchRepo.GetFetch(ctx, authorID, cursor, cycleStart)
+		if err != nil {
+			return nil, false, err
+		}
+		if fetch == nil {
+			return nil, true, nil
+		}
+
+		switch fetch.Status {
+		case db.TimelineFetchStatusDone:
+			var page sharedTimelinePage
+			if err := json.Unmarshal(fetch.Response, &page); err != nil {
+				return nil, false, fmt.Errorf("failed to read shared timeline page: %w", err)
+			}
+			return &TweetResponse{
+				Data:        TweetDataWrapper{Tweets: page.Tweets},
+				HasNextPage: page.HasNextPage,
+				NextCursor:  page.NextCursor,
+				Status:      "success",
+				Tweets:      page.Tweets,
+			}, false, nil
+
+		case db.TimelineFetchStatusFailed:
+			return nil, false, sharedFetchError(fetch)
+
+		default:
+			if fetch.ClaimedUntil.Before(time.Now()) {
+				return nil, true, nil
+			}
+		}
+
+		if err := sleepCtx(ctx, p.config.PollInterval); err != nil {
+			return nil, false, err
+		}
+	}
+}
+
+// sharedFetchError rebuilds the error of a failed shared fetch
+// API errors keep their status, so retries and rate limit accounting treat them like a direct fetch
+func sharedFetchError(fetch *db.TimelineFetch) error {
+	message := "shared timeline fetch failed"
+	if fetch.Error != nil {
+		message = *fetch.Error
+	}
+	if fetch.ErrorStatus != nil {
+		return &APIError{StatusCode: *fetch.ErrorStatus, Message: message}
+	}
+	return errors.New(message)
+}
+
+// isShared reports whether an author is ingested for more than one user
+// The list is reloaded once per cycle, when past cycles' fetches are also deleted;
+// until it can be loaded timelines are fetched per user
+func (p *FetchPlanner) isShared(ctx context.Context, authorID int64, cycleStart time.Time) bool {
+	p.sharedMu.Lock()
+	defer p.sharedMu.Unlock()
+
+	if !p.sharedCycle.Equal(cycleStart) {
+		authorIDs, err := p.followingRepo.GetSharedAuthorIDs(ctx)
+		if err != nil {
+			if isContextError(err) {
+				return false
+			}
+			// Keep the previous list and try again next cycle
+			logger.Warn("failed to load shared authors, keeping previous list",
+				"error", err)
+		} else {
+			p.sharedAuthors = make(map[int64]struct{}, len(authorIDs))
+			for _, id := range authorIDs {
+				p.sharedAuthors[id] = struct{}{}
+			}
+		}
+		p.sharedCycle = cycleStart
+
+		if _, err := p.fetchRepo.DeleteFetchesBefore(ctx, cycleStart); err != nil {
+			logger.Warn("failed to delete past timeline fetches",
+				"error", err)
+		}
+	}
+
+	_, ok := p.sharedAuthors[authorID]
+	return ok
+}
+
+// isContextError reports whether err was caused by a cancelled or expired context
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
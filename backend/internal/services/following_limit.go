@@ -0,0 +1,42 @@
+package services
+
+import "github.com/sopeal/AskYourFeed/internal/db"
+
+// DefaultFollowingLimit is the number of followed authors ingested for users whose plan is unknown
+const DefaultFollowingLimit = 150
+
+// PlanFollowingLimits maps users.plan to the number of followed authors ingested
+var PlanFollowingLimits = map[string]int{
+	"free": 150,
+	"pro":  500,
+}
+
+// FollowingLimitFor returns how many of the user's followed authors are ingested
+// A per-user following_limit overrides the plan's limit
+func FollowingLimitFor(user *db.User) int {
+	if user.FollowingLimit != nil && *user.FollowingLimit > 0 {
+		return *user.FollowingLimit
+	}
+	if limit, ok := PlanFollowingLimits[user.Plan]; ok {
+		return limit
+	}
+	return DefaultFollowingLimit
+}
+
+// DefaultListAuthorLimit is the number of listed authors ingested for users whose plan is unknown
+const DefaultListAuthorLimit = 50
+
+// PlanListAuthorLimits maps users.plan to the number of authors ingested through the user's lists
+// Listed authors the user also follows within the following limit do not count against it
+var PlanListAuthorLimits = map[string]int{
+	"free": 50,
+	"pro":  500,
+}
+
+// ListAuthorLimitFor returns how many authors are ingested through the user's lists
+func ListAuthorLimitFor(user *db.User) int {
+	if limit, ok := PlanListAuthorLimits[user.Plan]; ok {
+		return limit
+	}
+	return DefaultListAuthorLimit
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -13,6 +14,11 @@ import (
 
 var followingServiceTracer = otel.Tracer("following_service")
 
+// Common errors
+var (
+	ErrFollowingNotFound = errors.New("following relationship not found")
+)
+
 // FollowingService handles business logic for following-related operations
 type FollowingService struct {
 	followingRepo *repositories.FollowingRepository
@@ -50,6 +56,8 @@ func (s *FollowingService) GetFollowing(ctx context.Context, userID uuid.UUID) (
 			DisplayName:   convertStringPtr(item.DisplayName),
 			LastSeenAt:    item.LastSeenAt,
 			LastCheckedAt: item.LastCheckedAt,
+			Pinned:        item.Pinned,
+			Excluded:      item.ExcludedAt != nil,
 		}
 	}
 
@@ -65,6 +73,64 @@ func (s *FollowingService) GetFollowing(ctx context.Context, userID uuid.UUID) (
 	return response, nil
 }
 
+// SetPinned pins or unpins a followed author
+// Pinned authors are kept first when the user follows more authors than their following limit;
+// the change takes effect at the next following sync
+func (s *FollowingService) SetPinned(ctx context.Context, userID uuid.UUID, authorID int64, pinned bool) error {
+	ctx, span := followingServiceTracer.Start(ctx, "SetPinned")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int64("author_id", authorID),
+		attribute.Bool("pinned", pinned),
+	)
+
+	found, err := s.followingRepo.SetFollowingPinned(ctx, userID, authorID, pinned)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to set following pinned: %w", err)
+	}
+	if !found {
+		return ErrFollowingNotFound
+	}
+
+	return nil
+}
+
+// GetFollowingEvents retrieves the user's most recent follow/unfollow events
+func (s *FollowingService) GetFollowingEvents(ctx context.Context, userID uuid.UUID, limit int) (*dto.FollowingEventsResponseDTO, error) {
+	ctx, span := followingServiceTracer.Start(ctx, "GetFollowingEvents")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("limit", limit),
+	)
+
+	items, err := s.followingRepo.GetFollowingEvents(ctx, userID, limit)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get following events: %w", err)
+	}
+
+	dtoItems := make([]dto.FollowingEventDTO, len(items))
+	for i, item := range items {
+		dtoItems[i] = dto.FollowingEventDTO{
+			XAuthorID:   item.XAuthorID,
+			Handle:      item.Handle,
+			DisplayName: convertStringPtr(item.DisplayName),
+			Event:       item.Event,
+			IngestRunID: convertStringPtr(item.RunID),
+			OccurredAt:  item.OccurredAt,
+		}
+	}
+
+	span.SetAttributes(attribute.Int("items_returned", len(dtoItems)))
+
+	return &dto.FollowingEventsResponseDTO{Items: dtoItems}, nil
+}
+
 // convertStringPtr converts *string to string, returning empty string if nil
 func convertStringPtr(s *string) string {
 	if s == nil {
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var imageDescriberTracer = otel.Tracer("image_describer")
+
+const (
+	// DefaultImageDescribeConcurrency caps the images described at once across the process
+	DefaultImageDescribeConcurrency = 4
+
+	// DefaultImageDescriptionCacheTTL is how long a cached image description is reused
+	DefaultImageDescriptionCacheTTL = 30 * 24 * time.Hour
+
+	// ImageDownloadTimeout bounds downloading an image before describing it
+	ImageDownloadTimeout = 30 * time.Second
+)
+
+// ImageDescriberConfig holds configuration for describing images
+type ImageDescriberConfig struct {
+	Concurrency int
+	CacheTTL    time.Duration
+	// DownloadImages downloads each image to key the cache by its content, so the same image
+	// uploaded by several authors is described once; the model gets the downloaded bytes
+	DownloadImages bool
+}
+
+// DefaultImageDescriberConfig returns the default image describer configuration
+func DefaultImageDescriberConfig() ImageDescriberConfig {
+	return ImageDescriberConfig{
+		Concurrency:    DefaultImageDescribeConcurrency,
+		CacheTTL:       DefaultImageDescriptionCacheTTL,
+		DownloadImages: true,
+	}
+}
+
+// ImageDescription is the outcome of describing one image
+type ImageDescription struct {
+	Description string
+	Model       string
+	Cached      bool // Taken from the media description cache
+	Err         error
+}
+
+// ImageDescriber describes images through OpenRouter with a content-addressed cache
+// Descriptions are cached per model under the normalized image URL and, when the image was
+// downloaded, under the hash of its bytes. The number of images described at once is capped
+// for all callers sharing the describer
+type ImageDescriber struct {
+	client     *OpenRouterClient
+	cacheRepo  *repositories.MediaCacheRepository
+	httpClient *http.Client
+	config     ImageDescriberConfig
+	slots      chan struct{}
+}
+
+// NewImageDescriber creates a new ImageDescriber instance
+// A nil cacheRepo disables caching; a nil httpClient uses NewSafeHTTPClient for downloads
+func NewImageDescriber(
+	client *OpenRouterClient,
+	cacheRepo *repositories.MediaCacheRepository,
+	httpClient *http.Client,
+	config ImageDescriberConfig,
+) *ImageDescriber {
+	if config.Concurrency <= 0 {
+		config.Concurrency = DefaultImageDescribeConcurrency
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = DefaultImageDescriptionCacheTTL
+	}
+	if httpClient == nil {
+		httpClient = NewSafeHTTPClient(ImageDownloadTimeout)
+	}
+
+	return &ImageDescriber{
+		client:     client,
+		cacheRepo:  cacheRepo,
+		httpClient: httpClient,
+		config:     config,
+		slots:      make(chan struct{}, config.Concurrency),
+	}
+}
+
+// Model returns the vision model describing images
+func (d *ImageDescriber) Model() string {
+	return MediaDescriptionModel
+}
+
+// DescribeImages describes images concurrently, at most Concurrency at a time
+// Results are in the order of imageURLs; a failed image does not stop the others
+func (d *ImageDescriber) DescribeImages(ctx context.Context, imageURLs []string) []ImageDescription {
+	ctx, span := imageDescriberTracer.Start(ctx, "DescribeImages")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("image_count", len(imageURLs)))
+
+	results := make([]ImageDescription, len(imageURLs))
+	var wg sync.WaitGroup
+	for i, imageURL := range imageURLs {
+		wg.Add(1)
+		go func(i int, imageURL string) {
+			defer wg.Done()
+			results[i] = d.DescribeImage(ctx, imageURL)
+		}(i, imageURL)
+	}
+	wg.Wait()
+
+	cached, failed := 0, 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		} else if result.Cached {
+			cached++
+		}
+	}
+	span.SetAttributes(
+		attribute.Int("cached_descriptions", cached),
+		attribute.Int("failed_descriptions", failed),
+	)
+
+	return results
+}
+
+// DescribeImage describes one image, reusing a cached description when there is one
+func (d *ImageDescriber) DescribeImage(ctx context.Context, imageURL string) ImageDescription {
+	ctx, span := imageDescriberTracer.Start(ctx, "DescribeImage")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("image_url", imageURL))
+
+	model := d.Model()
+	keys := []string{ImageURLCacheKey(imageURL)}
+	if description, ok := d.lookup(ctx, keys); ok {
+		span.SetAttributes(attribute.Bool("cached", true))
+		return ImageDescription{Description: description, Model: model, Cached: true}
+	}
+
+	select {
+	case d.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ImageDescription{Model: model, Err: ctx.Err()}
+	}
+	defer func() { <-d.slots }()
+
+	source := imageURL
+	if d.config.DownloadImages {
+		data, contentType, err := d.download(ctx, imageURL)
+		if err != nil {
+			// The model may still reach the image, so it gets the URL instead
+			logger.Debug("failed to download image, describing it by URL",
+				"error", err,
+				"image_url", imageURL)
+		} else {
+			contentKey := ImageContentCacheKey(data)
+			if description, ok := d.lookup(ctx, []string{contentKey}); ok {
+				// Cache the URL too, so the next post with this URL skips the download
+				d.store(ctx, keys, description)
+				span.SetAttributes(attribute.Bool("cached", true))
+				return ImageDescription{Description: description, Model: model, Cached: true}
+			}
+			keys = append(keys, contentKey)
+			source = "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)
+		}
+	}
+
+	description, err := d.client.DescribeImage(ctx, source)
+	if err != nil {
+		span.RecordError(err)
+		return ImageDescription{Model: model, Err: err}
+	}
+
+	description = strings.TrimSpace(description)
+	d.store(ctx, keys, description)
+
+	span.SetAttributes(attribute.Bool("cached", false))
+	return ImageDescription{Description: description, Model: model}
+}
+
+// PurgeExpiredCache removes expired cached descriptions
+// Returns the number of entries removed
+func (d *ImageDescriber) PurgeExpiredCache(ctx context.Context) int {
+	if d.cacheRepo == nil {
+		return 0
+	}
+
+	deleted, err := d.cacheRepo.DeleteExpired(ctx)
+	if err != nil {
+		logger.Warn("failed to purge expired media descriptions", "error", err)
+		return 0
+	}
+	return deleted
+}
+
+// lookup returns a cached description under any of the keys; lookup errors count as misses
+func (d *ImageDescriber) lookup(ctx context.Context, keys []string) (string, bool) {
+	if d.cacheRepo == nil {
+		return "", false
+	}
+
+	entry, err := d.cacheRepo.GetDescription(ctx, keys, d.Model())
+	if err != nil {
+		logger.Warn("failed to look up cached media description", "error", err)
+		return "", false
+	}
+	if entry == nil {
+		return "", false
+	}
+	return entry.Description, true
+}
+
+// store caches a description under the keys; failures are logged only
+func (d *ImageDescriber) store(ctx context.Context, keys []string, description string) {
+	if d.cacheRepo == nil {
+		return
+	}
+
+	if err := d.cacheRepo.PutDescription(ctx, keys, d.Model(), description, d.config.CacheTTL); err != nil {
+		logger.Warn("failed to cache media description", "error", err)
+	}
+}
+
+// download reads an image of at most MaxImageSize bytes and returns it with its content type
+func (d *ImageDescriber) download(ctx context.Context, imageURL string) ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, ImageDownloadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", imageURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create download request: %w", err)
+	}
+	req.Header.Set("User-Agent", "AskYourFeed/1.0")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download image: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("image download returned status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > MaxImageSize {
+		return nil, "", fmt.Errorf("image size %d MB exceeds limit of %d MB: %w", resp.ContentLength/(1024*1024), MaxImageSize/(1024*1024), ErrMediaExceedsLimit)
+	}
+
+	contentType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(contentType, "image/") {
+		return nil, "", fmt.Errorf("unexpected content type %q", resp.Header.Get("Content-Type"))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxImageSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download image: %w", err)
+	}
+	if len(data) > MaxImageSize {
+		return nil, "", fmt.Errorf("image size exceeds limit of %d MB: %w", MaxImageSize/(1024*1024), ErrMediaExceedsLimit)
+	}
+
+	return data, contentType, nil
+}
+
+// ImageURLCacheKey returns the cache key of an image by its normalized URL
+func ImageURLCacheKey(imageURL string) string {
+	sum := sha256.Sum256([]byte(NormalizeImageURL(imageURL)))
+	return "url:" + hex.EncodeToString(sum[:])
+}
+
+// ImageContentCacheKey returns the cache key of an image by its bytes
+func ImageContentCacheKey(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// NormalizeImageURL returns a canonical form of an image URL so variants of one image share a cache key
+// The scheme and host are lowercased, the fragment dropped and query parameters sorted. X image URLs
+// (pbs.twimg.com) also drop the size: media/ID?format=jpg&name=small becomes https://pbs.twimg.com/media/ID.jpg
+func NormalizeImageURL(imageURL string) string {
+	imageURL = strings.TrimSpace(imageURL)
+	u, err := url.Parse(imageURL)
+	if err != nil || u.Host == "" {
+		return imageURL
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	query := u.Query()
+	if u.Host == "pbs.twimg.com" {
+		u.Scheme = "https"
+		if format := query.Get("format"); format != "" && path.Ext(u.Path) == "" {
+			u.Path += "." + strings.ToLower(format)
+		}
+		query.Del("format")
+		query.Del("name")
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sopeal/AskYourFeed/internal/db"
+)
+
+// undeterminedLanguages are X language codes of tweets without linguistic content
+// (media, links, hashtags, mentions or emoji only); a language allow-list never drops them
+var undeterminedLanguages = map[string]bool{
+	"":    true,
+	"und": true,
+	"zxx": true,
+	"qme": true,
+	"qam": true,
+	"qct": true,
+	"qht": true,
+	"qst": true,
+}
+
+// DefaultIngestPolicy returns the policy of users who never changed it
+// Only original posts and self-replies are ingested, in any language
+func DefaultIngestPolicy(userID uuid.UUID) db.IngestPolicy {
+	return db.IngestPolicy{
+		UserID:    userID,
+		Languages: pq.StringArray{},
+	}
+}
+
+// AllowsTweet checks if a tweet of a followed author passes the user's ingest policy
+// Self-replies are always allowed; a retweet is judged by the language of the retweeted tweet
+func AllowsTweet(policy db.IngestPolicy, tweet TweetData) bool {
+	lang := tweet.Lang
+	switch {
+	case tweet.RetweetedTweet != nil:
+		if !policy.IncludeRetweets {
+			return false
+		}
+		lang = tweet.RetweetedTweet.Lang
+	case tweet.QuotedTweet != nil:
+		if !policy.IncludeQuotes {
+			return false
+		}
+	case tweet.IsReply && !isSelfReply(tweet):
+		if !policy.IncludeReplies {
+			return false
+		}
+	}
+
+	return allowsLanguage(policy, lang)
+}
+
+// allowsLanguage checks a tweet language against the policy's allow-list
+func allowsLanguage(policy db.IngestPolicy, lang string) bool {
+	if len(policy.Languages) == 0 || undeterminedLanguages[lang] {
+		return true
+	}
+
+	for _, allowed := range policy.Languages {
+		if allowed == lang {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var ingestPolicyServiceTracer = otel.Tracer("ingest_policy_service")
+
+// MaxPolicyLanguages is the maximum number of languages in an ingest policy allow-list
+const MaxPolicyLanguages = 20
+
+// languageCodePattern matches X language codes (ISO 639-1, or three letters for X's special codes)
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// Common errors
+var (
+	ErrInvalidLanguage  = errors.New("invalid language code")
+	ErrTooManyLanguages = errors.New("too many languages")
+)
+
+// IngestPolicyService handles business logic for per-user ingest policies
+type IngestPolicyService struct {
+	ingestPolicyRepo *repositories.IngestPolicyRepository
+}
+
+// NewIngestPolicyService creates a new IngestPolicyService instance
+func NewIngestPolicyService(ingestPolicyRepo *repositories.IngestPolicyRepository) *IngestPolicyService {
+	return &IngestPolicyService{
+		ingestPolicyRepo: ingestPolicyRepo,
+	}
+}
+
+// GetPolicy retrieves the user's ingest policy, or the defaults if it was never changed
+func (s *IngestPolicyService) GetPolicy(ctx context.Context, userID uuid.UUID) (*dto.IngestPolicyDTO, error) {
+	ctx, span := ingestPolicyServiceTracer.Start(ctx, "GetPolicy")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	policy, err := s.ingestPolicyRepo.GetIngestPolicy(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get ingest policy: %w", err)
+	}
+
+	if policy == nil {
+		defaults := DefaultIngestPolicy(userID)
+		return convertIngestPolicy(&defaults, false), nil
+	}
+
+	return convertIngestPolicy(policy, true), nil
+}
+
+// UpdatePolicy replaces the user's ingest policy
+// Language codes are lowercased and deduplicated; returns ErrInvalidLanguage or ErrTooManyLanguages
+// The policy applies from the next ingest run; posts already stored are kept
+func (s *IngestPolicyService) UpdatePolicy(ctx context.Context, userID uuid.UUID, cmd dto.UpdateIngestPolicyCommand) (*dto.IngestPolicyDTO, error) {
+	ctx, span := ingestPolicyServiceTracer.Start(ctx, "UpdatePolicy")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	languages, err := normalizeLanguages(cmd.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.ingestPolicyRepo.UpsertIngestPolicy(ctx, db.IngestPolicy{
+		UserID:          userID,
+		IncludeRetweets: cmd.IncludeRetweets,
+		IncludeQuotes:   cmd.IncludeQuotes,
+		IncludeReplies:  cmd.IncludeReplies,
+		Languages:       languages,
+		IncludeMentions: cmd.IncludeMentions,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to update ingest policy: %w", err)
+	}
+
+	return convertIngestPolicy(policy, true), nil
+}
+
+// normalizeLanguages validates, lowercases and deduplicates a language allow-list
+func normalizeLanguages(languages []string) (pq.StringArray, error) {
+	normalized := make(pq.StringArray, 0, len(languages))
+	seen := make(map[string]bool, len(languages))
+	for _, lang := range languages {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if !languageCodePattern.MatchString(lang) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidLanguage, lang)
+		}
+		if seen[lang] {
+			continue
+		}
+		seen[lang] = true
+		normalized = append(normalized, lang)
+	}
+
+	if len(normalized) > MaxPolicyLanguages {
+		return nil, ErrTooManyLanguages
+	}
+
+	return normalized, nil
+}
+
+// convertIngestPolicy converts a stored policy to its DTO; UpdatedAt is left out for the defaults
+func convertIngestPolicy(policy *db.IngestPolicy, stored bool) *dto.IngestPolicyDTO {
+	result := &dto.IngestPolicyDTO{
+		IncludeRetweets: policy.IncludeRetweets,
+		IncludeQuotes:   policy.IncludeQuotes,
+		IncludeReplies:  policy.IncludeReplies,
+		Languages:       []string(policy.Languages),
+		IncludeMentions: policy.IncludeMentions,
+	}
+	if result.Languages == nil {
+		result.Languages = []string{}
+	}
+	if stored {
+		updatedAt := policy.UpdatedAt
+		result.UpdatedAt = &updatedAt
+	}
+	return result
+}
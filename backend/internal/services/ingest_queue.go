@@ -0,0 +1,529 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var ingestQueueTracer = otel.Tracer("ingest_queue")
+
+const (
+	// DefaultIngestWorkers is the number of workers processing ingest jobs per process
+	DefaultIngestWorkers = 2
+
+	// DefaultIngestJobPollInterval is how often idle workers look for new jobs
+	DefaultIngestJobPollInterval = 5 * time.Second
+
+	// DefaultIngestJobLease is how long a claimed job stays locked without a lease extension
+	DefaultIngestJobLease = 2 * time.Minute
+
+	// DefaultIngestJobMaxAttempts is the number of attempts before a job is marked as failed
+	DefaultIngestJobMaxAttempts = 5
+
+	// DefaultIngestJobRetryDelay is the base delay for exponential backoff between job attempts
+	DefaultIngestJobRetryDelay = 30 * time.Second
+
+	// DefaultStaleRunThreshold is how long an open run may go without a heartbeat before it is reaped as abandoned
+	DefaultStaleRunThreshold = 10 * time.Minute
+
+	// DefaultReaperInterval is how often the reaper looks for abandoned runs
+	DefaultReaperInterval = time.Minute
+
+	// maxIngestJobRetryDelay caps the backoff between job attempts
+	maxIngestJobRetryDelay = time.Hour
+)
+
+// IngestQueueConfig holds configuration for the durable ingest job queue
+type IngestQueueConfig struct {
+	Workers        int
+	PollInterval   time.Duration
+	LeaseDuration  time.Duration
+	MaxAttempts    int
+	BaseRetryDelay time.Duration
+
+	// StaleRunThreshold must be well above LeaseDuration, since a run of a crashed worker is
+	// normally resumed by another worker once the job lease expires
+	StaleRunThreshold time.Duration
+	ReaperInterval    time.Duration
+}
+
+// DefaultIngestQueueConfig returns the default ingest job queue configuration
+func DefaultIngestQueueConfig() IngestQueueConfig {
+	return IngestQueueConfig{
+		Workers:        DefaultIngestWorkers,
+		PollInterval:   DefaultIngestJobPollInterval,
+		LeaseDuration:  DefaultIngestJobLease,
+		MaxAttempts:    DefaultIngestJobMaxAttempts,
+		BaseRetryDelay: DefaultIngestJobRetryDelay,
+
+		StaleRunThreshold: DefaultStaleRunThreshold,
+		ReaperInterval:    DefaultReaperInterval,
+	}
+}
+
+// IngestQueue is a Postgres-backed queue of ingestion jobs
+// Jobs survive restarts, are retried with backoff, and are picked up by another
+// worker (possibly on another replica) when their lease expires
+type IngestQueue struct {
+	ingestService *IngestService
+	jobRepo       *repositories.IngestJobRepository
+	config        IngestQueueConfig
+	workerPrefix  string
+
+	// running maps IDs of runs executing in this process to their cancel functions
+	mu      sync.Mutex
+	running map[string]context.CancelCauseFunc
+}
+
+// NewIngestQueue creates a new IngestQueue instance
+func NewIngestQueue(
+	ingestService *IngestService,
+	jobRepo *repositories.IngestJobRepository,
+	config IngestQueueConfig,
+) *IngestQueue {
+	defaults := DefaultIngestQueueConfig()
+	if config.Workers <= 0 {
+		config.Workers = defaults.Workers
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	if config.LeaseDuration <= 0 {
+		config.LeaseDuration = defaults.LeaseDuration
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.BaseRetryDelay <= 0 {
+		config.BaseRetryDelay = defaults.BaseRetryDelay
+	}
+	if config.StaleRunThreshold <= config.LeaseDuration {
+		config.StaleRunThreshold = max(defaults.StaleRunThreshold, 2*config.LeaseDuration)
+	}
+	if config.ReaperInterval <= 0 {
+		config.ReaperInterval = defaults.ReaperInterval
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+
+	return &IngestQueue{
+		ingestService: ingestService,
+		jobRepo:       jobRepo,
+		config:        config,
+		workerPrefix:  fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), ulid.Make().String()[20:]),
+		running:       make(map[string]context.CancelCauseFunc),
+	}
+}
+
+// Submit creates a new ingest run for a user and enqueues a job to execute it
+// The run is returned immediately; a worker executes it asynchronously
+// Returns ErrIngestInProgress if the user already has a run in progress
+func (q *IngestQueue) Submit(ctx context.Context, userID uuid.UUID, backfillHours int) (*db.IngestRun, *db.IngestJob, error) {
+	ctx, span := ingestQueueTracer.Start(ctx, "Submit")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("backfill_hours", backfillHours),
+	)
+
+	// A run abandoned by a crashed process must not block the new one
+	q.reapStaleRuns(ctx, &userID)
+
+	run, err := q.ingestService.StartRun(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, ErrIngestInProgress) {
+			span.RecordError(err)
+		}
+		return nil, nil, err
+	}
+
+	span.SetAttributes(attribute.String("run_id", run.ID))
+
+	job, created, err := q.jobRepo.EnqueueJob(ctx, ulid.Make().String(), userID, run.ID, backfillHours, q.config.MaxAttempts)
+	if err != nil {
+		span.RecordError(err)
+		q.failOrphanedRun(ctx, run.ID, "failed to enqueue ingest job")
+		return nil, nil, fmt.Errorf("failed to enqueue ingest job: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("job_id", job.ID),
+		attribute.Bool("created", created),
+	)
+
+	// A job that is already running keeps its own run - the new one would never execute
+	if job.RunID == nil || *job.RunID != run.ID {
+		q.failOrphanedRun(ctx, run.ID, "ingest job already running")
+		return nil, nil, ErrIngestInProgress
+	}
+
+	return run, job, nil
+}
+
+// failOrphanedRun closes a run that no job will execute so it does not block future ingestions
+func (q *IngestQueue) failOrphanedRun(ctx context.Context, runID string, reason string) {
+	if err := q.ingestService.FailRun(context.WithoutCancel(ctx), runID, reason); err != nil {
+		logger.Warn("failed to close orphaned ingest run",
+			"error", err,
+			"run_id", runID)
+	}
+}
+
+// Run starts the worker pool and blocks until ctx is cancelled and all workers have stopped
+func (q *IngestQueue) Run(ctx context.Context) {
+	logger.Info("ingest workers started",
+		"workers", q.config.Workers,
+		"poll_interval", q.config.PollInterval.String(),
+		"lease", q.config.LeaseDuration.String(),
+		"stale_run_threshold", q.config.StaleRunThreshold.String())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		q.runReaper(ctx)
+	}()
+
+	for i := 0; i < q.config.Workers; i++ {
+		wg.Add(1)
+		go func(workerID string) {
+			defer wg.Done()
+			q.runWorker(ctx, workerID)
+		}(fmt.Sprintf("%s-%d", q.workerPrefix, i))
+	}
+
+	wg.Wait()
+	logger.Info("ingest workers stopped")
+}
+
+// runReaper periodically closes runs abandoned by crashed processes until ctx is cancelled
+func (q *IngestQueue) runReaper(ctx context.Context) {
+	ticker := time.NewTicker(q.config.ReaperInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q.reapStaleRuns(ctx, nil)
+		}
+	}
+}
+
+// reapStaleRuns closes abandoned runs of a user (or of all users if userID is nil)
+// Failures are logged; a run that could not be reaped is retried on the next pass
+func (q *IngestQueue) reapStaleRuns(ctx context.Context, userID *uuid.UUID) {
+	if _, err := q.ingestService.ReapStaleRuns(ctx, userID, q.config.StaleRunThreshold); err != nil && ctx.Err() == nil {
+		logger.Warn("failed to reap stale ingest runs", "error", err)
+	}
+}
+
+// runWorker claims and processes jobs until ctx is cancelled
+func (q *IngestQueue) runWorker(ctx context.Context, workerID string) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		job, err := q.jobRepo.ClaimJob(ctx, workerID, q.config.LeaseDuration)
+		if err != nil && ctx.Err() == nil {
+			logger.Error("failed to claim ingest job", err, "worker_id", workerID)
+		}
+
+		if job == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(q.config.PollInterval):
+			}
+			continue
+		}
+
+		q.processJob(ctx, workerID, job)
+	}
+}
+
+// processJob runs a claimed job while keeping its lease alive
+func (q *IngestQueue) processJob(ctx context.Context, workerID string, job *db.IngestJob) {
+	ctx, span := ingestQueueTracer.Start(ctx, "processJob")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("job_id", job.ID),
+		attribute.String("user_id", job.UserID.String()),
+		attribute.Int("attempt", job.Attempts),
+	)
+
+	// Job bookkeeping must outlive worker shutdown so the job can be handed back
+	bookkeepingCtx := context.WithoutCancel(ctx)
+
+	// A job abandoned too many times (e.g. by crashing replicas) is not retried again;
+	// its run is closed as well so it does not block the user's next ingestion
+	if job.Attempts > job.MaxAttempts {
+		q.failJob(bookkeepingCtx, workerID, job, "max attempts exceeded")
+		if job.RunID != nil {
+			q.failOrphanedRun(bookkeepingCtx, *job.RunID, "max attempts exceeded")
+		}
+		return
+	}
+
+	logger.Info("ingest job started",
+		"job_id", job.ID,
+		"user_id", job.UserID,
+		"attempt", job.Attempts,
+		"worker_id", workerID)
+
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	run, err := q.prepareRun(jobCtx, workerID, job)
+	if err == nil {
+		span.SetAttributes(attribute.String("run_id", run.ID))
+		// A run completed by a previous attempt only needs its job marked as done
+		if run.CompletedAt == nil {
+			unregister := q.registerRun(run.ID, cancel)
+
+			heartbeatDone := make(chan struct{})
+			go func() {
+				defer close(heartbeatDone)
+				q.keepLeaseAlive(jobCtx, cancel, workerID, job.ID, run.ID)
+			}()
+
+			err = q.ingestService.ExecuteRun(jobCtx, run, job.BackfillHours)
+			cancel(nil)
+			<-heartbeatDone
+			unregister()
+
+			if errors.Is(err, ErrIngestRunCancelled) {
+				err = nil
+			}
+		}
+	}
+	cancel(nil)
+
+	switch {
+	case err == nil:
+		if completeErr := q.jobRepo.CompleteJob(bookkeepingCtx, job.ID, workerID); completeErr != nil {
+			span.RecordError(completeErr)
+			logger.Warn("failed to mark ingest job as done",
+				"error", completeErr,
+				"job_id", job.ID)
+			return
+		}
+		logger.Info("ingest job completed",
+			"job_id", job.ID,
+			"user_id", job.UserID)
+
+	case ctx.Err() != nil:
+		// Worker is shutting down - hand the job back so another worker can resume it right away
+		q.retryJob(bookkeepingCtx, workerID, job, "worker shutdown", time.Now())
+
+	default:
+		span.RecordError(err)
+		if job.Attempts >= job.MaxAttempts {
+			q.failJob(bookkeepingCtx, workerID, job, err.Error())
+			return
+		}
+		q.retryJob(bookkeepingCtx, workerID, job, err.Error(), time.Now().Add(q.retryDelay(job.Attempts)))
+	}
+}
+
+// prepareRun returns the run the job should execute
+// An open run linked to the job is resumed and a successfully completed or cancelled one is returned as-is;
+// otherwise (no linked run, or a retry after the previous run failed) a new run is started and linked to the job
+func (q *IngestQueue) prepareRun(ctx context.Context, workerID string, job *db.IngestJob) (*db.IngestRun, error) {
+	if job.RunID != nil {
+		run, err := q.ingestService.GetRun(ctx, job.UserID, *job.RunID)
+		if err != nil && !errors.Is(err, ErrIngestRunNotFound) {
+			return nil, err
+		}
+		if run != nil && (run.CompletedAt == nil || run.Status == "ok" || run.Status == "cancelled") {
+			return run, nil
+		}
+	}
+
+	q.reapStaleRuns(ctx, &job.UserID)
+
+	run, err := q.ingestService.StartRun(ctx, job.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start ingest run: %w", err)
+	}
+
+	if err := q.jobRepo.AttachRun(ctx, job.ID, workerID, run.ID); err != nil {
+		q.failOrphanedRun(ctx, run.ID, "failed to attach ingest run to job")
+		return nil, fmt.Errorf("failed to attach ingest run: %w", err)
+	}
+	job.RunID = &run.ID
+
+	return run, nil
+}
+
+// keepLeaseAlive periodically extends the job lease and the run heartbeat until ctx is done
+// If the lease is lost (another worker took over), the run was closed or cancelled, the job context is cancelled
+func (q *IngestQueue) keepLeaseAlive(ctx context.Context, cancel context.CancelCauseFunc, workerID string, jobID string, runID string) {
+	ticker := time.NewTicker(q.config.LeaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := q.jobRepo.ExtendLease(ctx, jobID, workerID, q.config.LeaseDuration)
+			if errors.Is(err, repositories.ErrIngestJobLeaseLost) {
+				logger.Warn("ingest job lease lost, stopping job",
+					"job_id", jobID,
+					"worker_id", workerID)
+				cancel(err)
+				return
+			}
+			if err != nil && ctx.Err() == nil {
+				logger.Warn("failed to extend ingest job lease",
+					"error", err,
+					"job_id", jobID,
+					"worker_id", workerID)
+			}
+
+			// Keep the run alive for the reaper; cancellation may have been requested on another replica
+			requested, err := q.ingestService.Heartbeat(ctx, runID)
+			if errors.Is(err, ErrIngestRunCompleted) {
+				logger.Warn("ingest run closed while executing, stopping job",
+					"job_id", jobID,
+					"run_id", runID)
+				cancel(err)
+				return
+			}
+			if err != nil && ctx.Err() == nil {
+				logger.Warn("failed to record ingest run heartbeat",
+					"error", err,
+					"run_id", runID)
+			}
+			if requested {
+				cancel(ErrIngestRunCancelled)
+				return
+			}
+		}
+	}
+}
+
+// registerRun makes a run executing in this process cancellable via Cancel
+// The returned function must be called once the run stops executing
+func (q *IngestQueue) registerRun(runID string, cancel context.CancelCauseFunc) func() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.running[runID] = cancel
+	return func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		delete(q.running, runID)
+	}
+}
+
+// Cancel stops an in-progress ingest run of the user
+// A run executing in this process is stopped immediately, a run still waiting in the queue is
+// marked as cancelled right away, and a run executing on another replica stops on its next heartbeat.
+// The returned run reflects its state after the request (completed_at is set if it is already cancelled)
+func (q *IngestQueue) Cancel(ctx context.Context, userID uuid.UUID, runID string) (*db.IngestRun, error) {
+	ctx, span := ingestQueueTracer.Start(ctx, "Cancel")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("run_id", runID),
+	)
+
+	run, err := q.ingestService.RequestCancel(ctx, userID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	cancel, ok := q.running[runID]
+	q.mu.Unlock()
+	if ok {
+		span.SetAttributes(attribute.Bool("cancelled_locally", true))
+		cancel(ErrIngestRunCancelled)
+		return run, nil
+	}
+
+	dequeued, err := q.jobRepo.CancelQueuedJob(ctx, runID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to cancel queued ingest job: %w", err)
+	}
+	if !dequeued {
+		// Executing elsewhere - the worker picks up the flag on its next heartbeat
+		return run, nil
+	}
+
+	if err := q.ingestService.MarkCancelled(ctx, runID, runTotals(run)); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return q.ingestService.GetRun(ctx, userID, runID)
+}
+
+// retryJob puts a job back in the queue to run at retryAt
+func (q *IngestQueue) retryJob(ctx context.Context, workerID string, job *db.IngestJob, errText string, retryAt time.Time) {
+	if err := q.jobRepo.RetryJob(ctx, job.ID, workerID, errText, retryAt); err != nil {
+		logger.Warn("failed to requeue ingest job",
+			"error", err,
+			"job_id", job.ID)
+		return
+	}
+
+	logger.Warn("ingest job will be retried",
+		"job_id", job.ID,
+		"user_id", job.UserID,
+		"attempt", job.Attempts,
+		"max_attempts", job.MaxAttempts,
+		"retry_at", retryAt,
+		"reason", errText)
+}
+
+// failJob marks a job as permanently failed
+func (q *IngestQueue) failJob(ctx context.Context, workerID string, job *db.IngestJob, errText string) {
+	if err := q.jobRepo.FailJob(ctx, job.ID, workerID, errText); err != nil {
+		logger.Warn("failed to mark ingest job as failed",
+			"error", err,
+			"job_id", job.ID)
+		return
+	}
+
+	logger.Error("ingest job failed permanently",
+		errors.New(errText),
+		"job_id", job.ID,
+		"user_id", job.UserID,
+		"attempts", job.Attempts)
+}
+
+// retryDelay returns the exponential backoff delay after the given attempt
+func (q *IngestQueue) retryDelay(attempt int) time.Duration {
+	delay := q.config.BaseRetryDelay
+	for i := 1; i < attempt && delay < maxIngestJobRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxIngestJobRetryDelay {
+		delay = maxIngestJobRetryDelay
+	}
+	return delay
+}
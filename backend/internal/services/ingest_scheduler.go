@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var ingestSchedulerTracer = otel.Tracer("ingest_scheduler")
+
+const (
+	// DefaultIngestInterval is the regular ingest interval (PRD: every 4h)
+	DefaultIngestInterval = 4 * time.Hour
+
+	// DefaultIngestJitter is the maximum random offset applied to each user's interval (PRD: ±15 min)
+	DefaultIngestJitter = 15 * time.Minute
+
+	// DefaultSchedulerCheckInterval is how often the scheduler looks for users that are due
+	DefaultSchedulerCheckInterval = time.Minute
+)
+
+// IngestSchedulerConfig holds configuration for the periodic ingestion scheduler
+type IngestSchedulerConfig struct {
+	Enabled       bool
+	Interval      time.Duration
+	Jitter        time.Duration
+	CheckInterval time.Duration
+	BackfillHours int // 0 means regular ingest (first page per author)
+}
+
+// DefaultIngestSchedulerConfig returns the scheduler configuration described in the PRD
+func DefaultIngestSchedulerConfig() IngestSchedulerConfig {
+	return IngestSchedulerConfig{
+		Enabled:       true,
+		Interval:      DefaultIngestInterval,
+		Jitter:        DefaultIngestJitter,
+		CheckInterval: DefaultSchedulerCheckInterval,
+		BackfillHours: 0,
+	}
+}
+
+// IngestScheduler periodically enqueues ingestion jobs for every user
+// Each user gets their own next-run time (interval ± jitter) so syncs are spread out
+type IngestScheduler struct {
+	ingestQueue *IngestQueue
+	ingestRepo  *repositories.IngestRepository
+	userRepo    repositories.UserRepository
+	config      IngestSchedulerConfig
+
+	mu        sync.Mutex
+	nextRunAt map[uuid.UUID]time.Time
+}
+
+// NewIngestScheduler creates a new IngestScheduler instance
+func NewIngestScheduler(
+	ingestQueue *IngestQueue,
+	ingestRepo *repositories.IngestRepository,
+	userRepo repositories.UserRepository,
+	config IngestSchedulerConfig,
+) *IngestScheduler {
+	if config.Interval <= 0 {
+		config.Interval = DefaultIngestInterval
+	}
+	if config.Jitter < 0 || config.Jitter >= config.Interval {
+		config.Jitter = 0
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = DefaultSchedulerCheckInterval
+	}
+
+	return &IngestScheduler{
+		ingestQueue: ingestQueue,
+		ingestRepo:  ingestRepo,
+		userRepo:    userRepo,
+		config:      config,
+		nextRunAt:   make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Run starts the scheduling loop and blocks until ctx is cancelled
+func (s *IngestScheduler) Run(ctx context.Context) {
+	if !s.config.Enabled {
+		logger.Info("ingest scheduler disabled")
+		return
+	}
+
+	logger.Info("ingest scheduler started",
+		"interval", s.config.Interval.String(),
+		"jitter", s.config.Jitter.String(),
+		"check_interval", s.config.CheckInterval.String())
+
+	ticker := time.NewTicker(s.config.CheckInterval)
+	defer ticker.Stop()
+
+	s.ScheduleDueUsers(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("ingest scheduler stopped")
+			return
+		case <-ticker.C:
+			s.ScheduleDueUsers(ctx)
+		}
+	}
+}
+
+// ScheduleDueUsers evaluates all users and enqueues ingestion jobs for those that are due
+// Returns the number of jobs enqueued
+func (s *IngestScheduler) ScheduleDueUsers(ctx context.Context) int {
+	ctx, span := ingestSchedulerTracer.Start(ctx, "ScheduleDueUsers")
+	defer span.End()
+
+	userIDs, err := s.userRepo.ListUserIDs(ctx)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("scheduler failed to list users", err)
+		return 0
+	}
+
+	s.pruneRemovedUsers(userIDs)
+
+	now := time.Now()
+	enqueued := 0
+
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return enqueued
+		}
+
+		nextRunAt, err := s.getNextRunAt(ctx, userID, now)
+		if err != nil {
+			span.RecordError(err)
+			logger.Warn("scheduler failed to determine next run, skipping user",
+				"error", err,
+				"user_id", userID)
+			continue
+		}
+
+		if now.Before(nextRunAt) {
+			continue
+		}
+
+		// Skip users that already have an ingestion in progress (manual trigger or previous cycle);
+		// the next run is due one interval after that run started
+		currentRun, err := s.ingestRepo.GetCurrentRun(ctx, userID)
+		if err != nil {
+			span.RecordError(err)
+			logger.Warn("scheduler failed to check current run, skipping user",
+				"error", err,
+				"user_id", userID)
+			continue
+		}
+		if currentRun != nil {
+			s.setNextRunAt(userID, currentRun.StartedAt.Add(s.jitteredInterval()))
+			logger.Debug("ingestion already running, skipping scheduled run",
+				"user_id", userID,
+				"run_id", currentRun.ID)
+			continue
+		}
+
+		// A run completed since the schedule was set (manual trigger or another replica) moves it
+		lastSyncAt, err := s.ingestRepo.GetLastSyncTime(ctx, userID)
+		if err != nil {
+			span.RecordError(err)
+			logger.Warn("scheduler failed to check last sync, skipping user",
+				"error", err,
+				"user_id", userID)
+			continue
+		}
+		if lastSyncAt != nil && now.Before(lastSyncAt.Add(s.config.Interval-s.config.Jitter)) {
+			s.setNextRunAt(userID, lastSyncAt.Add(s.jitteredInterval()))
+			logger.Debug("ingestion completed recently, skipping scheduled run",
+				"user_id", userID,
+				"last_sync_at", lastSyncAt.Format(time.RFC3339))
+			continue
+		}
+
+		run, job, err := s.ingestQueue.Submit(ctx, userID, s.config.BackfillHours)
+		if errors.Is(err, ErrIngestInProgress) {
+			// Another ingestion started between the check and the submit
+			s.setNextRunAt(userID, now.Add(s.jitteredInterval()))
+			continue
+		}
+		if err != nil {
+			span.RecordError(err)
+			logger.Warn("scheduler failed to enqueue ingest job, will retry on next tick",
+				"error", err,
+				"user_id", userID)
+			continue
+		}
+
+		s.setNextRunAt(userID, now.Add(s.jitteredInterval()))
+		enqueued++
+		logger.Info("scheduled ingest job enqueued",
+			"user_id", userID,
+			"run_id", run.ID,
+			"job_id", job.ID)
+	}
+
+	span.SetAttributes(
+		attribute.Int("users_count", len(userIDs)),
+		attribute.Int("jobs_enqueued", enqueued),
+	)
+
+	return enqueued
+}
+
+// getNextRunAt returns the next scheduled run time for a user, initializing it on first sight
+// Users that were synced before are scheduled relative to their last completed run;
+// users that were never synced are spread over the first jitter window
+func (s *IngestScheduler) getNextRunAt(ctx context.Context, userID uuid.UUID, now time.Time) (time.Time, error) {
+	s.mu.Lock()
+	nextRunAt, ok := s.nextRunAt[userID]
+	s.mu.Unlock()
+	if ok {
+		return nextRunAt, nil
+	}
+
+	lastSyncAt, err := s.ingestRepo.GetLastSyncTime(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if lastSyncAt == nil {
+		nextRunAt = now.Add(s.randomDuration(0, s.config.Jitter))
+	} else {
+		nextRunAt = lastSyncAt.Add(s.jitteredInterval())
+	}
+
+	s.setNextRunAt(userID, nextRunAt)
+	return nextRunAt, nil
+}
+
+// setNextRunAt stores the next scheduled run time for a user
+func (s *IngestScheduler) setNextRunAt(userID uuid.UUID, nextRunAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextRunAt[userID] = nextRunAt
+}
+
+// pruneRemovedUsers drops schedule entries for users that no longer exist
+func (s *IngestScheduler) pruneRemovedUsers(userIDs []uuid.UUID) {
+	active := make(map[uuid.UUID]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		active[id] = struct{}{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id := range s.nextRunAt {
+		if _, ok := active[id]; !ok {
+			delete(s.nextRunAt, id)
+		}
+	}
+}
+
+// jitteredInterval returns the configured interval shifted by a random offset in [-jitter, +jitter]
+func (s *IngestScheduler) jitteredInterval() time.Duration {
+	return s.config.Interval + s.randomDuration(-s.config.Jitter, s.config.Jitter)
+}
+
+// randomDuration returns a uniformly distributed duration in [lo, hi]
+func (s *IngestScheduler) randomDuration(lo, hi time.Duration) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + rand.N(hi-lo+1)
+}
@@ -2,13 +2,19 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math"
+	"net"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/oklog/ulid/v2"
+	"github.com/sopeal/AskYourFeed/internal/db"
 	"github.com/sopeal/AskYourFeed/internal/dto"
 	"github.com/sopeal/AskYourFeed/internal/repositories"
 	"github.com/sopeal/AskYourFeed/pkg/logger"
@@ -19,67 +25,235 @@ import (
 var ingestionServiceTracer = otel.Tracer("ingestion_service")
 
 const (
-	// MaxFollowingLimit is the maximum number of followed users to sync (MVP limit)
-	MaxFollowingLimit = 150
+	// MaxFollowingFetch caps how many followed accounts a following sync reads from the API
+	// The whole list is read so the following limit can prioritize across it; see FollowingLimitFor
+	MaxFollowingFetch = 1000
 
 	// MaxRetries is the maximum number of retries for rate-limited requests
 	MaxRetries = 3
 
 	// BaseBackoffDelay is the base delay for exponential backoff (in seconds)
 	BaseBackoffDelay = 2
+
+	// MaxRetryDelay caps the wait taken from an API Retry-After hint
+	MaxRetryDelay = time.Minute
+
+	// MaxConcurrentAuthors is the number of followed authors whose tweets are fetched in parallel
+	MaxConcurrentAuthors = 8
+
+	// MaxListMembersFetch caps how many members a list sync reads from the API per list
+	MaxListMembersFetch = 1000
+
+	// MaxSearchPages caps how many result pages a saved search reads per run
+	MaxSearchPages = 5
+
+	// MaxMentionPages caps how many pages of mentions of the user are read per run
+	MaxMentionPages = 5
+
+	// FollowingLimitSampleSlots is how many places under the following limit rotate among the authors over it,
+	// so an excluded author is ingested now and then and can rank back in by activity
+	FollowingLimitSampleSlots = 5
+
+	// MaxIncrementalPages caps how many pages a regular ingest reads per author while catching up to its watermark
+	MaxIncrementalPages = 10
+
+	// DefaultSinceID is the since_id recorded for runs of users that were never synced
+	DefaultSinceID = 1
 )
 
 // IngestService handles the actual ingestion of Twitter data
 type IngestService struct {
 	twitterClient    *TwitterClient
+	fetchPlanner     *FetchPlanner
 	openRouterClient *OpenRouterClient
 	ingestRepo       *repositories.IngestRepository
 	followingRepo    *repositories.FollowingRepository
 	postRepo         *repositories.PostRepository
 	authorRepo       *repositories.AuthorRepository
+	watermarkRepo    *repositories.WatermarkRepository
 	userRepo         repositories.UserRepository
+	ingestPolicyRepo *repositories.IngestPolicyRepository
+	feedListRepo     *repositories.FeedListRepository
+	searchSourceRepo *repositories.SearchSourceRepository
+	linkFetcher      *LinkFetcher
+	imageDescriber   *ImageDescriber
+	transcriber      Transcriber
 }
 
 // NewIngestService creates a new IngestService instance
 func NewIngestService(
 	twitterClient *TwitterClient,
+	fetchPlanner *FetchPlanner,
 	openRouterClient *OpenRouterClient,
 	ingestRepo *repositories.IngestRepository,
 	followingRepo *repositories.FollowingRepository,
 	postRepo *repositories.PostRepository,
 	authorRepo *repositories.AuthorRepository,
+	watermarkRepo *repositories.WatermarkRepository,
 	userRepo repositories.UserRepository,
+	ingestPolicyRepo *repositories.IngestPolicyRepository,
+	feedListRepo *repositories.FeedListRepository,
+	searchSourceRepo *repositories.SearchSourceRepository,
+	linkFetcher *LinkFetcher,
+	imageDescriber *ImageDescriber,
+	transcriber Transcriber,
 ) *IngestService {
 	return &IngestService{
 		twitterClient:    twitterClient,
+		fetchPlanner:     fetchPlanner,
 		openRouterClient: openRouterClient,
 		ingestRepo:       ingestRepo,
 		followingRepo:    followingRepo,
 		postRepo:         postRepo,
 		authorRepo:       authorRepo,
+		watermarkRepo:    watermarkRepo,
 		userRepo:         userRepo,
+		ingestPolicyRepo: ingestPolicyRepo,
+		feedListRepo:     feedListRepo,
+		searchSourceRepo: searchSourceRepo,
+		linkFetcher:      linkFetcher,
+		imageDescriber:   imageDescriber,
+		transcriber:      transcriber,
 	}
 }
 
-// IngestUserData performs a complete ingestion for a user with backfill support
-func (s *IngestService) IngestUserData(ctx context.Context, userID uuid.UUID, backfillHours int) error {
-	ctx, span := ingestionServiceTracer.Start(ctx, "IngestUserData")
+// Common ingest service errors
+var (
+	ErrIngestInProgress   = errors.New("ingestion already running")
+	ErrIngestRunNotFound  = errors.New("ingest run not found")
+	ErrIngestRunCompleted = errors.New("ingest run already completed")
+	ErrIngestRunCancelled = errors.New("ingest run cancelled")
+)
+
+// StartRun creates a new ingest run for a user without executing it
+// The run is created synchronously so callers get its real ID before a worker picks it up
+// Returns ErrIngestInProgress if the user already has a run in progress
+func (s *IngestService) StartRun(ctx context.Context, userID uuid.UUID) (*db.IngestRun, error) {
+	ctx, span := ingestionServiceTracer.Start(ctx, "StartRun")
 	defer span.End()
 
-	span.SetAttributes(
-		attribute.String("user_id", userID.String()),
-		attribute.Int("backfill_hours", backfillHours),
-	)
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	// since_id records the newest tweet ingested so far across all followed authors
+	sinceID, err := s.watermarkRepo.GetUserSinceID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to determine since_id: %w", err)
+	}
+	if sinceID == 0 {
+		sinceID = DefaultSinceID
+	}
 
-	// Check if there's already a running ingest
-	currentRun, err := s.ingestRepo.GetCurrentRun(ctx, userID)
+	// Create a new ingest run; the database rejects it if the user already has one in progress
+	runID := ulid.Make().String()
+	run, err := s.ingestRepo.CreateIngestRun(ctx, userID, runID, sinceID)
 	if err != nil {
+		if errors.Is(err, repositories.ErrIngestRunInProgress) {
+			return nil, ErrIngestInProgress
+		}
 		span.RecordError(err)
-		return fmt.Errorf("failed to check current run: %w", err)
+		return nil, fmt.Errorf("failed to create ingest run: %w", err)
+	}
+
+	span.SetAttributes(attribute.String("run_id", run.ID))
+
+	return run, nil
+}
+
+// GetRun retrieves an ingest run owned by the user
+func (s *IngestService) GetRun(ctx context.Context, userID uuid.UUID, runID string) (*db.IngestRun, error) {
+	run, err := s.ingestRepo.GetRunByID(ctx, userID, runID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrIngestRunNotFound) {
+			return nil, ErrIngestRunNotFound
+		}
+		return nil, fmt.Errorf("failed to get ingest run: %w", err)
+	}
+	return run, nil
+}
+
+// FailRun marks a run that will never be executed as failed
+func (s *IngestService) FailRun(ctx context.Context, runID string, errText string) error {
+	return s.ingestRepo.CompleteIngestRun(ctx, runID, "error", repositories.IngestRunTotals{}, &errText)
+}
+
+// RequestCancel flags an in-progress run of the user for cancellation
+func (s *IngestService) RequestCancel(ctx context.Context, userID uuid.UUID, runID string) (*db.IngestRun, error) {
+	run, err := s.ingestRepo.RequestCancel(ctx, userID, runID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrIngestRunNotFound):
+			return nil, ErrIngestRunNotFound
+		case errors.Is(err, repositories.ErrIngestRunCompleted):
+			return nil, ErrIngestRunCompleted
+		}
+		return nil, fmt.Errorf("failed to request ingest run cancellation: %w", err)
+	}
+	return run, nil
+}
+
+// Heartbeat records that a run is still being executed and reports whether its cancellation was requested
+// Returns ErrIngestRunCompleted if the run was closed in the meantime (e.g. reaped as abandoned)
+func (s *IngestService) Heartbeat(ctx context.Context, runID string) (bool, error) {
+	cancelRequested, err := s.ingestRepo.HeartbeatIngestRun(ctx, runID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrIngestRunCompleted) {
+			return false, ErrIngestRunCompleted
+		}
+		return false, err
+	}
+	return cancelRequested, nil
+}
+
+// ReapStaleRuns closes open runs (of one user, or all users if userID is nil) whose heartbeat is older than staleAfter
+func (s *IngestService) ReapStaleRuns(ctx context.Context, userID *uuid.UUID, staleAfter time.Duration) ([]string, error) {
+	runIDs, err := s.ingestRepo.ReapStaleRuns(ctx, userID, staleAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, runID := range runIDs {
+		logger.Warn("ingest run abandoned, marked as error",
+			"run_id", runID,
+			"stale_after", staleAfter.String())
+	}
+
+	return runIDs, nil
+}
+
+// MarkCancelled completes a run with the 'cancelled' status
+// The update is written even if ctx is already cancelled
+func (s *IngestService) MarkCancelled(ctx context.Context, runID string, totals repositories.IngestRunTotals) error {
+	errText := "cancelled by user"
+	if err := s.ingestRepo.CompleteIngestRun(context.WithoutCancel(ctx), runID, "cancelled", totals, &errText); err != nil {
+		return fmt.Errorf("failed to mark ingest run as cancelled: %w", err)
 	}
+	return nil
+}
+
+// ExecuteRun performs the ingestion for a previously started run with backfill support
+// If the run is cancelled (ctx cancelled with ErrIngestRunCancelled as cause, or cancellation
+// requested before it started) it is marked 'cancelled' and ErrIngestRunCancelled is returned.
+// If ctx is cancelled for any other reason, the run is left open so the job executing it can resume it
+func (s *IngestService) ExecuteRun(ctx context.Context, run *db.IngestRun, backfillHours int) error {
+	ctx, span := ingestionServiceTracer.Start(ctx, "ExecuteRun")
+	defer span.End()
+
+	userID := run.UserID
+	runID := run.ID
 
-	if currentRun != nil {
-		return fmt.Errorf("ingestion already running for user %s", userID.String())
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("run_id", runID),
+		attribute.Int("backfill_hours", backfillHours),
+	)
+
+	if run.CancelRequestedAt != nil {
+		if err := s.MarkCancelled(ctx, runID, runTotals(run)); err != nil {
+			span.RecordError(err)
+			return err
+		}
+		return ErrIngestRunCancelled
 	}
 
 	// Get user's X username
@@ -89,157 +263,204 @@ func (s *IngestService) IngestUserData(ctx context.Context, userID uuid.UUID, ba
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return fmt.Errorf("user not found: %s", userID.String())
+		errText := fmt.Sprintf("user not found: %s", userID.String())
+		if completeErr := s.ingestRepo.CompleteIngestRun(ctx, runID, "error", runTotals(run), &errText); completeErr != nil {
+			logger.Warn("failed to mark ingest run as failed",
+				"error", completeErr,
+				"run_id", runID)
+		}
+		return errors.New(errText)
 	}
 
-	// Create a new ingest run
-	runID := ulid.Make().String()
-	sinceID := int64(1000000000) // Default starting point
-	err = s.ingestRepo.CreateIngestRun(ctx, userID, runID, sinceID)
+	// Progress carries the run totals; retry counters continue from a resumed run's persisted values
+	progress := &repositories.IngestRunProgress{
+		Phase:         "following",
+		Retried:       run.Retried,
+		RateLimitHits: run.RateLimitHits,
+	}
+
+	// Perform the ingestion
+	err = s.performIngestion(ctx, userID, user.XUsername, FollowingLimitFor(user), ListAuthorLimitFor(user), runID, run.StartedAt, backfillHours, progress)
+	totals := progress.Totals()
 	if err != nil {
 		span.RecordError(err)
-		return fmt.Errorf("failed to create ingest run: %w", err)
-	}
 
-	span.SetAttributes(attribute.String("run_id", runID))
+		if errors.Is(context.Cause(ctx), ErrIngestRunCancelled) {
+			if markErr := s.MarkCancelled(ctx, runID, totals); markErr != nil {
+				logger.Warn("failed to mark ingest run as cancelled",
+					"error", markErr,
+					"run_id", runID)
+			}
+			logger.Info("ingestion cancelled",
+				"user_id", userID,
+				"run_id", runID,
+				"total_fetched", totals.FetchedCount)
+			return ErrIngestRunCancelled
+		}
 
-	// Track rate limiting metrics
-	rateLimitHits := 0
-	retried := 0
+		if ctx.Err() != nil {
+			// Interrupted (e.g. worker shutdown) - leave the run open to be resumed
+			return fmt.Errorf("ingestion interrupted: %w", err)
+		}
 
-	// Perform the ingestion
-	totalFetched, rateLimitHits, retried, err := s.performIngestion(ctx, userID, user.XUsername, runID, backfillHours)
-	if err != nil {
 		// Mark run as failed
 		errText := err.Error()
 
 		// Determine status based on error type
 		status := "error"
-		if strings.Contains(errText, "429") || strings.Contains(errText, "rate limit") {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.IsRateLimited() {
 			status = "rate_limited"
 		}
 
-		if completeErr := s.ingestRepo.CompleteIngestRun(ctx, runID, status, totalFetched, &errText); completeErr != nil {
+		if completeErr := s.ingestRepo.CompleteIngestRun(ctx, runID, status, totals, &errText); completeErr != nil {
 			logger.Warn("failed to mark ingest run as failed",
 				"error", completeErr,
 				"run_id", runID)
 		}
-		span.RecordError(err)
 		return fmt.Errorf("ingestion failed: %w", err)
 	}
 
-	// Update run with rate limit metrics before completing
-	if rateLimitHits > 0 || retried > 0 {
-		// Note: We'd need to add a method to update these metrics
-		logger.Info("ingestion completed with rate limiting",
-			"run_id", runID,
-			"rate_limit_hits", rateLimitHits,
-			"retried", retried)
-	}
-
 	// Mark run as completed
-	err = s.ingestRepo.CompleteIngestRun(ctx, runID, "ok", totalFetched, nil)
+	err = s.ingestRepo.CompleteIngestRun(ctx, runID, "ok", totals, nil)
 	if err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("failed to complete ingest run: %w", err)
 	}
 
 	span.SetAttributes(
-		attribute.Int("total_fetched", totalFetched),
-		attribute.Int("rate_limit_hits", rateLimitHits),
-		attribute.Int("retried", retried),
+		attribute.Int("total_fetched", totals.FetchedCount),
+		attribute.Int("rate_limit_hits", totals.RateLimitHits),
+		attribute.Int("retried", totals.Retried),
 	)
 
 	logger.Info("ingestion completed successfully",
 		"user_id", userID,
 		"run_id", runID,
-		"total_fetched", totalFetched)
+		"total_fetched", totals.FetchedCount,
+		"rate_limit_hits", totals.RateLimitHits,
+		"retried", totals.Retried)
 
 	return nil
 }
 
 // performIngestion executes the actual ingestion logic
-func (s *IngestService) performIngestion(ctx context.Context, userID uuid.UUID, xUsername string, runID string, backfillHours int) (int, int, int, error) {
+// Progress is updated as the run goes, including when it fails part-way
+func (s *IngestService) performIngestion(ctx context.Context, userID uuid.UUID, xUsername string, followingLimit int, listAuthorLimit int, runID string, startedAt time.Time, backfillHours int, progress *repositories.IngestRunProgress) error {
 	ctx, span := ingestionServiceTracer.Start(ctx, "performIngestion")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("user_id", userID.String()),
 		attribute.String("x_username", xUsername),
+		attribute.Int("following_limit", followingLimit),
+		attribute.Int("list_author_limit", listAuthorLimit),
 		attribute.String("run_id", runID),
 		attribute.Int("backfill_hours", backfillHours),
 	)
 
-	totalFetched := 0
-	totalRateLimitHits := 0
-	totalRetried := 0
+	s.saveProgress(ctx, runID, progress)
 
-	// Step 1: Update following list (max 150 users)
-	followingFetched, rateLimitHits, retried, err := s.ingestFollowing(ctx, userID, xUsername, runID)
-	if err != nil {
+	// Step 1: Update following list and select the authors within the user's following limit
+	if _, _, _, err := s.ingestFollowing(ctx, userID, xUsername, followingLimit, runID, progress); err != nil {
 		span.RecordError(err)
-		return totalFetched, totalRateLimitHits, totalRetried, fmt.Errorf("failed to ingest following: %w", err)
+		return fmt.Errorf("failed to ingest following: %w", err)
 	}
-	totalFetched += followingFetched
-	totalRateLimitHits += rateLimitHits
-	totalRetried += retried
 
-	// Step 2: Ingest tweets from followed users
-	tweetsFetched, rateLimitHits, retried, err := s.ingestTweets(ctx, userID, runID, backfillHours)
-	if err != nil {
+	// Members of the user's lists are synced with the following list; a list that fails keeps its previous members
+	s.ingestLists(ctx, userID, runID, progress)
+	if err := ctx.Err(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to ingest lists: %w", err)
+	}
+
+	// Listed authors not followed within the following limit count against the list author limit
+	if err := s.applyListAuthorLimit(ctx, userID, listAuthorLimit); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	progress.Phase = "tweets"
+	s.saveProgress(ctx, runID, progress)
+
+	// Step 2: Ingest tweets from followed users and list members
+	if _, _, _, err := s.ingestTweets(ctx, userID, runID, backfillHours, progress); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to ingest tweets: %w", err)
+	}
+
+	// Saved searches run after the authors' tweets; a search that fails is retried from the same point next run
+	s.ingestSearches(ctx, userID, runID, backfillHours, progress)
+	if err := ctx.Err(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to ingest searches: %w", err)
+	}
+
+	// Mentions of the user's own account are an optional last stage of the tweets phase
+	s.ingestMentions(ctx, userID, xUsername, runID, backfillHours, progress)
+	if err := ctx.Err(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to ingest mentions: %w", err)
+	}
+
+	// Step 3: Fill gaps of self-reply threads that received posts during this run
+	s.assembleThreads(ctx, userID, runID, startedAt, progress)
+	if err := ctx.Err(); err != nil {
 		span.RecordError(err)
-		return totalFetched, totalRateLimitHits, totalRetried, fmt.Errorf("failed to ingest tweets: %w", err)
+		return fmt.Errorf("failed to assemble threads: %w", err)
 	}
-	totalFetched += tweetsFetched
-	totalRateLimitHits += rateLimitHits
-	totalRetried += retried
 
+	totals := progress.Totals()
 	span.SetAttributes(
-		attribute.Int("total_fetched", totalFetched),
-		attribute.Int("total_rate_limit_hits", totalRateLimitHits),
-		attribute.Int("total_retried", totalRetried),
+		attribute.Int("total_fetched", totals.FetchedCount),
+		attribute.Int("total_rate_limit_hits", totals.RateLimitHits),
+		attribute.Int("total_retried", totals.Retried),
 	)
 
-	return totalFetched, totalRateLimitHits, totalRetried, nil
+	return nil
 }
 
-// ingestFollowing updates the user's following list (max 150 users)
-func (s *IngestService) ingestFollowing(ctx context.Context, userID uuid.UUID, xUsername string, runID string) (int, int, int, error) {
+// ingestFollowing updates the user's following list and applies the following limit
+// The whole list is synced (up to MaxFollowingFetch) so that, when the user follows more authors than
+// followingLimit, pinned and most active authors are kept rather than whichever the API returned first
+func (s *IngestService) ingestFollowing(ctx context.Context, userID uuid.UUID, xUsername string, followingLimit int, runID string, progress *repositories.IngestRunProgress) (int, int, int, error) {
 	ctx, span := ingestionServiceTracer.Start(ctx, "ingestFollowing")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("run_id", runID),
 		attribute.String("x_username", xUsername),
+		attribute.Int("following_limit", followingLimit),
 	)
 
 	cursor := ""
 	fetched := 0
 	rateLimitHits := 0
 	retried := 0
+	fetchCap := max(MaxFollowingFetch, followingLimit)
+
+	// Authors seen in this sync; unfollows are reconciled only if every author was stored
+	seen := make([]int64, 0, followingLimit)
+	followed := make([]int64, 0)
+	complete := true
 
 	for {
-		// Check if we've reached the limit
-		if fetched >= MaxFollowingLimit {
-			logger.Info("reached following limit",
-				"user_id", userID,
-				"limit", MaxFollowingLimit,
-				"fetched", fetched)
-			break
-		}
 
 		// Get following from Twitter API with retry logic
 		resp, hits, retries, err := s.getFollowingsWithRetry(ctx, xUsername, cursor)
+		rateLimitHits += hits
+		retried += retries
+		progress.RateLimitHits += hits
+		progress.Retried += retries
 		if err != nil {
 			span.RecordError(err)
+			s.saveProgress(ctx, runID, progress)
 			return fetched, rateLimitHits, retried, fmt.Errorf("failed to get user followings: %w", err)
 		}
-		rateLimitHits += hits
-		retried += retries
 
 		// Process each following
 		for _, user := range resp.Users {
-			if fetched >= MaxFollowingLimit {
+			if fetched >= fetchCap {
 				break
 			}
 
@@ -249,35 +470,71 @@ func (s *IngestService) ingestFollowing(ctx context.Context, userID uuid.UUID, x
 				logger.Warn("failed to ensure author exists, skipping",
 					"error", err,
 					"handle", user.UserName)
+				s.recordRunError(ctx, runID, userID, "following", user.UserName, err)
+				complete = false
 				continue
 			}
 
 			// Update or insert following relationship with last_checked_at
-			err = s.followingRepo.UpsertFollowing(ctx, userID, authorID, time.Now())
+			isNew, err := s.followingRepo.UpsertFollowing(ctx, userID, authorID, time.Now())
 			if err != nil {
 				span.RecordError(err)
 				logger.Warn("failed to upsert following, skipping",
 					"error", err,
 					"author_id", authorID)
+				s.recordRunError(ctx, runID, userID, "following", user.UserName, err)
+				complete = false
 				continue
 			}
 
+			seen = append(seen, authorID)
+			if isNew {
+				followed = append(followed, authorID)
+			}
 			fetched++
 		}
 
-		// Check if we have more pages and haven't reached limit
-		if !resp.HasNextPage || fetched >= MaxFollowingLimit {
+		progress.FollowingCount = fetched
+		s.saveProgress(ctx, runID, progress)
+
+		if !resp.HasNextPage {
+			break
+		}
+
+		// Authors beyond the cap are unknown, so they can't be told apart from unfollows
+		if fetched >= fetchCap {
+			logger.Warn("reached following fetch cap, skipping remaining followings",
+				"user_id", userID,
+				"cap", fetchCap,
+				"fetched", fetched)
+			complete = false
 			break
 		}
 
 		cursor = resp.NextCursor
+	}
+
+	s.reconcileFollowing(ctx, userID, runID, seen, followed, complete)
 
-		// Add a small delay to avoid rate limiting
-		time.Sleep(100 * time.Millisecond)
+	// Rank the followed authors and mark the ones over the limit as excluded from tweet ingestion;
+	// small limits give up at most a tenth of their places to sampling
+	sampleSlots := min(FollowingLimitSampleSlots, followingLimit/10)
+	excluded, err := s.followingRepo.ApplyFollowingLimit(ctx, userID, followingLimit, sampleSlots, time.Now())
+	if err != nil {
+		span.RecordError(err)
+		return fetched, rateLimitHits, retried, fmt.Errorf("failed to apply following limit: %w", err)
+	}
+	if excluded > 0 {
+		logger.Info("following limit exceeded, excluded least active authors",
+			"user_id", userID,
+			"limit", followingLimit,
+			"sample_slots", sampleSlots,
+			"excluded", excluded)
 	}
 
 	span.SetAttributes(
 		attribute.Int("following_fetched", fetched),
+		attribute.Int("following_excluded", excluded),
 		attribute.Int("rate_limit_hits", rateLimitHits),
 		attribute.Int("retried", retried),
 	)
@@ -290,196 +547,1019 @@ func (s *IngestService) ingestFollowing(ctx context.Context, userID uuid.UUID, x
 	return fetched, rateLimitHits, retried, nil
 }
 
-// ingestTweets ingests tweets from followed users
-func (s *IngestService) ingestTweets(ctx context.Context, userID uuid.UUID, runID string, backfillHours int) (int, int, int, error) {
-	ctx, span := ingestionServiceTracer.Start(ctx, "ingestTweets")
+// reconcileFollowing soft-deactivates authors the user no longer follows and records follow/unfollow events
+// Unfollows are only derived from a complete sync, so an author that failed to store is never dropped
+// Failures are logged and do not fail the run
+func (s *IngestService) reconcileFollowing(ctx context.Context, userID uuid.UUID, runID string, seen []int64, followed []int64, complete bool) {
+	ctx, span := ingestionServiceTracer.Start(ctx, "reconcileFollowing")
 	defer span.End()
 
 	span.SetAttributes(
-		attribute.String("run_id", runID),
-		attribute.Int("backfill_hours", backfillHours),
+		attribute.String("user_id", userID.String()),
+		attribute.Int("followed_count", len(followed)),
+		attribute.Bool("complete", complete),
 	)
 
-	// Get all followed authors
-	following, err := s.followingRepo.GetFollowing(ctx, userID)
+	if err := s.followingRepo.InsertFollowingEvents(ctx, userID, runID, "follow", followed); err != nil {
+		span.RecordError(err)
+		logger.Warn("failed to record follow events",
+			"error", err,
+			"user_id", userID,
+			"run_id", runID)
+	}
+
+	// An empty list is more likely an API glitch than the user unfollowing everyone
+	if !complete || len(seen) == 0 {
+		logger.Warn("following sync incomplete, skipping unfollow reconciliation",
+			"user_id", userID,
+			"run_id", runID,
+			"seen", len(seen))
+		return
+	}
+
+	unfollowed, err := s.followingRepo.DeactivateUnfollowed(ctx, userID, seen, time.Now())
 	if err != nil {
 		span.RecordError(err)
-		return 0, 0, 0, fmt.Errorf("failed to get following list: %w", err)
+		logger.Warn("failed to deactivate unfollowed authors",
+			"error", err,
+			"user_id", userID,
+			"run_id", runID)
+		return
 	}
 
-	fetched := 0
-	rateLimitHits := 0
-	retried := 0
+	if err := s.followingRepo.InsertFollowingEvents(ctx, userID, runID, "unfollow", unfollowed); err != nil {
+		span.RecordError(err)
+		logger.Warn("failed to record unfollow events",
+			"error", err,
+			"user_id", userID,
+			"run_id", runID)
+	}
 
-	// Calculate backfill cutoff time
-	backfillCutoff := time.Now().Add(-time.Duration(backfillHours) * time.Hour)
-	isBackfill := backfillHours > 0
+	span.SetAttributes(attribute.Int("unfollowed_count", len(unfollowed)))
 
-	for _, follow := range following {
-		// Get author details
-		author, err := s.authorRepo.GetAuthor(ctx, follow.XAuthorID)
-		if err != nil {
-			span.RecordError(err)
-			logger.Warn("failed to get author details, skipping",
-				"error", err,
-				"author_id", follow.XAuthorID,
-				"user_id", userID)
-			continue
-		}
+	if len(followed) > 0 || len(unfollowed) > 0 {
+		logger.Info("following list changed",
+			"user_id", userID,
+			"run_id", runID,
+			"followed", len(followed),
+			"unfollowed", len(unfollowed))
+	}
+}
 
-		if author == nil || author.Handle == "" {
-			logger.Debug("skipping author with no handle",
-				"author_id", follow.XAuthorID,
-				"user_id", userID)
-			continue
+// ingestLists syncs the members of the lists the user registered as feed sources
+// Failures are recorded against the run and do not fail it; a list keeps its previous members until read in full
+func (s *IngestService) ingestLists(ctx context.Context, userID uuid.UUID, runID string, progress *repositories.IngestRunProgress) {
+	ctx, span := ingestionServiceTracer.Start(ctx, "ingestLists")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("run_id", runID))
+
+	lists, err := s.feedListRepo.GetFeedLists(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		logger.Warn("failed to get feed lists, skipping list sync",
+			"error", err,
+			"user_id", userID,
+			"run_id", runID)
+		s.recordRunError(ctx, runID, userID, "following", "", err)
+		return
+	}
+
+	for _, list := range lists {
+		if ctx.Err() != nil {
+			return
 		}
 
-		// Get tweets for this author
-		authorTweetsFetched, hits, retries, err := s.ingestTweetsForAuthor(
-			ctx, userID, author.Handle, author.XAuthorID, backfillCutoff, isBackfill)
+		members, hits, retries, err := s.ingestListMembers(ctx, userID, runID, list.XListID)
+		progress.RateLimitHits += hits
+		progress.Retried += retries
+		s.saveProgress(ctx, runID, progress)
 		if err != nil {
 			span.RecordError(err)
-			logger.Warn("failed to ingest tweets for author, continuing with others",
+			logger.Warn("failed to sync list members, continuing with other lists",
 				"error", err,
-				"author_handle", author.Handle,
-				"author_id", follow.XAuthorID,
+				"list_id", list.XListID,
 				"user_id", userID)
+			s.recordRunError(ctx, runID, userID, "following", "", err)
 			continue
 		}
 
-		fetched += authorTweetsFetched
-		rateLimitHits += hits
-		retried += retries
-
-		// Update progress
-		err = s.ingestRepo.UpdateIngestRunProgress(ctx, runID, fetched)
-		if err != nil {
-			span.RecordError(err)
-			logger.Warn("failed to update progress",
-				"error", err,
-				"run_id", runID)
-		}
-
-		// Add delay between authors to avoid rate limiting
-		time.Sleep(200 * time.Millisecond)
+		logger.Debug("list members synced",
+			"list_id", list.XListID,
+			"user_id", userID,
+			"members", members)
 	}
 
-	span.SetAttributes(
-		attribute.Int("tweets_fetched", fetched),
-		attribute.Int("rate_limit_hits", rateLimitHits),
-		attribute.Int("retried", retried),
-	)
-
-	logger.Info("tweets ingestion completed",
-		"user_id", userID,
-		"fetched", fetched,
-		"authors_processed", len(following),
-		"rate_limit_hits", rateLimitHits)
+	span.SetAttributes(attribute.Int("lists_count", len(lists)))
+}
 
-	return fetched, rateLimitHits, retried, nil
+// applyListAuthorLimit marks the listed authors over the user's list author limit as excluded from tweet ingestion;
+// like the following limit, small limits give up at most a tenth of their places to sampling
+func (s *IngestService) applyListAuthorLimit(ctx context.Context, userID uuid.UUID, listAuthorLimit int) error {
+	sampleSlots := min(FollowingLimitSampleSlots, listAuthorLimit/10)
+	excluded, err := s.feedListRepo.ApplyListAuthorLimit(ctx, userID, listAuthorLimit, sampleSlots, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to apply list author limit: %w", err)
+	}
+	if excluded > 0 {
+		logger.Info("list author limit exceeded, excluded least active list authors",
+			"user_id", userID,
+			"limit", listAuthorLimit,
+			"sample_slots", sampleSlots,
+			"excluded", excluded)
+	}
+	return nil
 }
 
-// ingestTweetsForAuthor ingests tweets for a specific author with pagination and temporal filtering
-func (s *IngestService) ingestTweetsForAuthor(
-	ctx context.Context,
-	userID uuid.UUID,
-	authorHandle string,
-	authorID int64,
-	backfillCutoff time.Time,
-	isBackfill bool,
-) (int, int, int, error) {
-	ctx, span := ingestionServiceTracer.Start(ctx, "ingestTweetsForAuthor")
+// ingestListMembers reads the members of a list (up to MaxListMembersFetch) and stores them
+// Members read before an error are added, but missing members are only removed after a complete read
+func (s *IngestService) ingestListMembers(ctx context.Context, userID uuid.UUID, runID string, listID int64) (int, int, int, error) {
+	ctx, span := ingestionServiceTracer.Start(ctx, "ingestListMembers")
 	defer span.End()
 
 	span.SetAttributes(
-		attribute.String("author_handle", authorHandle),
-		attribute.Int64("author_id", authorID),
-		attribute.Bool("is_backfill", isBackfill),
+		attribute.String("run_id", runID),
+		attribute.Int64("list_id", listID),
 	)
 
-	fetched := 0
+	cursor := ""
 	rateLimitHits := 0
 	retried := 0
-	cursor := ""
-	latestSeenAt := time.Time{}
+	members := make([]int64, 0)
+	complete := true
+	var fetchErr error
 
-	// Fetch and process tweets page by page
 	for {
-		// Get tweets from Twitter API with retry logic
-		resp, hits, retries, err := s.getTweetsWithRetry(ctx, authorHandle, cursor)
+		resp, hits, retries, err := s.getListMembersWithRetry(ctx, listID, cursor)
+		rateLimitHits += hits
+		retried += retries
 		if err != nil {
 			span.RecordError(err)
-			return fetched, rateLimitHits, retried, fmt.Errorf("failed to get user tweets: %w", err)
+			fetchErr = fmt.Errorf("failed to get members of list %d: %w", listID, err)
+			complete = false
+			break
 		}
-		rateLimitHits += hits
-		retried += retries
 
-		// Process each tweet in the current page
-		tweetsInPage, reachedCutoff := s.processTweetPage(
-			ctx, userID, authorHandle, resp.Tweets,
-			backfillCutoff, isBackfill, &latestSeenAt, &fetched,
-		)
+		for _, user := range resp.Members {
+			if len(members) >= MaxListMembersFetch {
+				break
+			}
 
-		// Stop conditions for pagination
-		if !isBackfill {
-			// For regular ingest (not backfill), only fetch first page
-			logger.Debug("regular ingest: stopping after first page",
-				"author_handle", authorHandle,
-				"tweets_fetched", tweetsInPage)
-			break
+			authorID, err := s.ensureAuthorExists(ctx, &user)
+			if err != nil {
+				span.RecordError(err)
+				logger.Warn("failed to ensure list member exists, skipping",
+					"error", err,
+					"handle", user.UserName,
+					"list_id", listID)
+				s.recordRunError(ctx, runID, userID, "following", user.UserName, err)
+				complete = false
+				continue
+			}
+			members = append(members, authorID)
 		}
 
-		if reachedCutoff {
-			// Reached backfill cutoff time
+		if !resp.HasNextPage {
 			break
 		}
 
-		if !resp.HasNextPage {
-			// No more pages available
+		if len(members) >= MaxListMembersFetch {
+			logger.Warn("reached list members fetch cap, skipping remaining members",
+				"list_id", listID,
+				"user_id", userID,
+				"cap", MaxListMembersFetch)
+			complete = false
 			break
 		}
 
-		// Move to next page
 		cursor = resp.NextCursor
-		time.Sleep(100 * time.Millisecond)
 	}
 
-	// Update author's last_seen_at if we found any tweets
-	if !latestSeenAt.IsZero() {
-		err := s.authorRepo.UpdateAuthorLastSeen(ctx, authorID, latestSeenAt)
-		if err != nil {
-			logger.Warn("failed to update author last_seen_at",
-				"error", err,
-				"author_id", authorID)
-		}
+	// Nothing was read, so the previous members are kept as they are
+	if fetchErr != nil && len(members) == 0 {
+		return 0, rateLimitHits, retried, fetchErr
+	}
+
+	if err := s.feedListRepo.SyncListMembers(ctx, userID, listID, members, complete, time.Now()); err != nil {
+		span.RecordError(err)
+		return len(members), rateLimitHits, retried, fmt.Errorf("failed to store members of list %d: %w", listID, err)
 	}
 
 	span.SetAttributes(
-		attribute.Int("tweets_fetched", fetched),
+		attribute.Int("members_count", len(members)),
+		attribute.Bool("complete", complete),
 		attribute.Int("rate_limit_hits", rateLimitHits),
 		attribute.Int("retried", retried),
 	)
 
-	return fetched, rateLimitHits, retried, nil
+	return len(members), rateLimitHits, retried, fetchErr
 }
 
-// processTweetPage processes a page of tweets and returns the count and whether backfill cutoff was reached
-func (s *IngestService) processTweetPage(
-	ctx context.Context,
-	userID uuid.UUID,
-	authorHandle string,
-	tweets []TweetData,
-	backfillCutoff time.Time,
-	isBackfill bool,
-	latestSeenAt *time.Time,
+// ingestTweets ingests tweets from followed users and members of the user's lists
+func (s *IngestService) ingestTweets(ctx context.Context, userID uuid.UUID, runID string, backfillHours int, progress *repositories.IngestRunProgress) (int, int, int, error) {
+	ctx, span := ingestionServiceTracer.Start(ctx, "ingestTweets")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("run_id", runID),
+		attribute.Int("backfill_hours", backfillHours),
+	)
+
+	// Get followed authors within the user's following limit
+	allFollowing, err := s.followingRepo.GetFollowing(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return 0, 0, 0, fmt.Errorf("failed to get following list: %w", err)
+	}
+	sources := make([]authorSource, 0, len(allFollowing))
+	sourceIndex := make(map[int64]int, len(allFollowing))
+	for _, follow := range allFollowing {
+		if follow.ExcludedAt == nil {
+			sourceIndex[follow.XAuthorID] = len(sources)
+			sources = append(sources, authorSource{authorID: follow.XAuthorID})
+		}
+	}
+
+	// Merge in list members within the list author limit; an author both followed and listed is fetched once
+	// and tagged with its lists
+	listAuthors, err := s.feedListRepo.GetListAuthors(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return 0, 0, 0, fmt.Errorf("failed to get list authors: %w", err)
+	}
+	for _, listAuthor := range listAuthors {
+		if i, ok := sourceIndex[listAuthor.XAuthorID]; ok {
+			sources[i].listIDs = listAuthor.ListIDs
+			continue
+		}
+		sources = append(sources, authorSource{authorID: listAuthor.XAuthorID, listIDs: listAuthor.ListIDs})
+	}
+
+	policy, err := s.getIngestPolicy(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return 0, 0, 0, err
+	}
+
+	// Calculate backfill cutoff time
+	backfillCutoff := time.Now().Add(-time.Duration(backfillHours) * time.Hour)
+	isBackfill := backfillHours > 0
+
+	// Totals and per-author outcomes are aggregated across workers and guarded by mu
+	var mu sync.Mutex
+	fetched := 0
+	rateLimitHits := 0
+	retried := 0
+	succeeded := 0
+	var failures []error
+
+	// Authors are processed by a bounded pool of workers; request pacing is left to
+	// the rate limiter shared by all TwitterClient callers
+	authors := make(chan authorSource)
+	var wg sync.WaitGroup
+	for i := 0; i < min(MaxConcurrentAuthors, len(sources)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for source := range authors {
+				authorTweetsFetched, hits, retries, err := s.ingestFollowedAuthor(
+					ctx, userID, runID, source.authorID, *policy, postTags{listIDs: source.listIDs}, backfillCutoff, isBackfill)
+
+				mu.Lock()
+				fetched += authorTweetsFetched
+				rateLimitHits += hits
+				retried += retries
+				if err == nil {
+					succeeded++
+				} else if ctx.Err() == nil {
+					failures = append(failures, err)
+				}
+				// Update progress
+				progress.TweetsCount = fetched
+				progress.RateLimitHits += hits
+				progress.Retried += retries
+				if ctx.Err() == nil {
+					s.saveProgress(ctx, runID, progress)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for _, source := range sources {
+		select {
+		case authors <- source:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(authors)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return fetched, rateLimitHits, retried, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("tweets_fetched", fetched),
+		attribute.Int("rate_limit_hits", rateLimitHits),
+		attribute.Int("retried", retried),
+		attribute.Int("authors_failed", len(failures)),
+	)
+
+	// Failures of some authors are recorded against the run; the run only fails when no author succeeded
+	if succeeded == 0 && len(failures) > 0 {
+		err := authorFailuresError(failures)
+		span.RecordError(err)
+		return fetched, rateLimitHits, retried, err
+	}
+
+	logger.Info("tweets ingestion completed",
+		"user_id", userID,
+		"fetched", fetched,
+		"authors_processed", len(sources),
+		"rate_limit_hits", rateLimitHits)
+
+	return fetched, rateLimitHits, retried, nil
+}
+
+// authorFailuresError summarizes the errors of a tweets phase in which every author failed
+// The result wraps a rate limit error only when every author was rate limited, so the run is marked
+// rate_limited for that case and error otherwise
+func authorFailuresError(failures []error) error {
+	rateLimited := 0
+	var other error
+	for _, err := range failures {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.IsRateLimited() {
+			rateLimited++
+		} else if other == nil {
+			other = err
+		}
+	}
+
+	if other != nil {
+		return fmt.Errorf("tweets of all %d authors failed (%d rate limited): %w", len(failures), rateLimited, other)
+	}
+	return fmt.Errorf("tweets of all %d authors failed, rate limited: %w", len(failures), failures[0])
+}
+
+// getIngestPolicy returns the user's ingest policy; the defaults apply until it is changed
+func (s *IngestService) getIngestPolicy(ctx context.Context, userID uuid.UUID) (*db.IngestPolicy, error) {
+	policy, err := s.ingestPolicyRepo.GetIngestPolicy(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingest policy: %w", err)
+	}
+	if policy == nil {
+		defaults := DefaultIngestPolicy(userID)
+		policy = &defaults
+	}
+	return policy, nil
+}
+
+// ingestSearches runs the search queries the user saved as feed sources
+// Failures are recorded against the run and do not fail it
+func (s *IngestService) ingestSearches(ctx context.Context, userID uuid.UUID, runID string, backfillHours int, progress *repositories.IngestRunProgress) {
+	ctx, span := ingestionServiceTracer.Start(ctx, "ingestSearches")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("run_id", runID))
+
+	sources, err := s.searchSourceRepo.GetSearchSources(ctx, userID)
+	if err == nil && len(sources) == 0 {
+		return
+	}
+	var policy *db.IngestPolicy
+	if err == nil {
+		policy, err = s.getIngestPolicy(ctx, userID)
+	}
+	if err != nil {
+		span.RecordError(err)
+		logger.Warn("failed to prepare saved searches, skipping them",
+			"error", err,
+			"user_id", userID,
+			"run_id", runID)
+		s.recordRunError(ctx, runID, userID, "tweets", "", err)
+		return
+	}
+
+	backfillCutoff := time.Now().Add(-time.Duration(backfillHours) * time.Hour)
+	isBackfill := backfillHours > 0
+
+	for _, source := range sources {
+		if ctx.Err() != nil {
+			return
+		}
+
+		fetched, hits, retries, err := s.ingestSearchSource(ctx, userID, source.SearchSource, *policy, backfillCutoff, isBackfill)
+		progress.TweetsCount += fetched
+		progress.RateLimitHits += hits
+		progress.Retried += retries
+		s.saveProgress(ctx, runID, progress)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			span.RecordError(err)
+			logger.Warn("failed to run saved search, continuing with others",
+				"error", err,
+				"search_source_id", source.ID,
+				"user_id", userID)
+			s.recordRunError(ctx, runID, userID, "tweets", "", err)
+			continue
+		}
+
+		logger.Debug("saved search ingested",
+			"search_source_id", source.ID,
+			"user_id", userID,
+			"fetched", fetched)
+	}
+
+	span.SetAttributes(attribute.Int("searches_count", len(sources)))
+}
+
+// ingestSearchSource stores the results of a saved search, tagged with the search source
+// Regular ingest reads only results newer than the newest one seen (first page only if the query never ran);
+// backfill paginates until the cutoff. Both stop after MaxSearchPages
+func (s *IngestService) ingestSearchSource(
+	ctx context.Context,
+	userID uuid.UUID,
+	source db.SearchSource,
+	policy db.IngestPolicy,
+	backfillCutoff time.Time,
+	isBackfill bool,
+) (int, int, int, error) {
+	ctx, span := ingestionServiceTracer.Start(ctx, "ingestSearchSource")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("search_source_id", source.ID),
+		attribute.Bool("is_backfill", isBackfill),
+	)
+
+	query := source.Query
+	if !isBackfill && source.LastTweetID != nil {
+		query = fmt.Sprintf("%s since_id:%d", query, *source.LastTweetID)
+	}
+	label := "search:" + source.ID
+	tags := postTags{searchSourceID: source.ID}
+
+	fetched := 0
+	rateLimitHits := 0
+	retried := 0
+	cursor := ""
+	latestSeenAt := time.Time{}
+	newest := tweetMark{}
+
+	for page := 1; ; page++ {
+		resp, hits, retries, err := s.searchTweetsWithRetry(ctx, label, query, cursor)
+		rateLimitHits += hits
+		retried += retries
+		if err != nil {
+			span.RecordError(err)
+			return fetched, rateLimitHits, retried, fmt.Errorf("failed to run search %s: %w", source.ID, err)
+		}
+
+		tweets := s.ensureTweetAuthors(ctx, label, resp.Tweets)
+		_, reachedCutoff := s.processTweetPage(
+			ctx, userID, label, policy, tags, tweets,
+			backfillCutoff, isBackfill, &latestSeenAt, &fetched,
+		)
+		newest.track(resp.Tweets)
+
+		if !isBackfill && source.LastTweetID == nil {
+			// Never ran before: only fetch first page
+			break
+		}
+		if isBackfill && reachedCutoff {
+			break
+		}
+		if page >= MaxSearchPages {
+			logger.Debug("saved search page limit reached",
+				"search_source_id", source.ID,
+				"pages", page)
+			break
+		}
+		if !resp.HasNextPage || resp.NextCursor == "" {
+			break
+		}
+
+		cursor = resp.NextCursor
+	}
+
+	if err := s.searchSourceRepo.MarkSearchRun(ctx, userID, source.ID, newest.id, time.Now()); err != nil {
+		logger.Warn("failed to mark saved search run",
+			"error", err,
+			"search_source_id", source.ID,
+			"user_id", userID)
+	}
+
+	span.SetAttributes(
+		attribute.Int("tweets_fetched", fetched),
+		attribute.Int("rate_limit_hits", rateLimitHits),
+		attribute.Int("retried", retried),
+	)
+
+	return fetched, rateLimitHits, retried, nil
+}
+
+// ingestMentions stores the tweets mentioning the user's own account when the ingest policy includes them
+// Regular ingest reads mentions newer than the newest stored one (first page only if there is none);
+// backfill reads back to the cutoff. Both stop after MaxMentionPages. Failures are recorded and do not fail the run
+func (s *IngestService) ingestMentions(ctx context.Context, userID uuid.UUID, xUsername string, runID string, backfillHours int, progress *repositories.IngestRunProgress) {
+	ctx, span := ingestionServiceTracer.Start(ctx, "ingestMentions")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("run_id", runID),
+		attribute.String("x_username", xUsername),
+	)
+
+	policy, err := s.getIngestPolicy(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		s.recordRunError(ctx, runID, userID, "tweets", "", err)
+		return
+	}
+	if !policy.IncludeMentions {
+		return
+	}
+
+	fetched, hits, retries, err := s.ingestMentionPages(ctx, userID, xUsername, *policy, backfillHours)
+	progress.TweetsCount += fetched
+	progress.RateLimitHits += hits
+	progress.Retried += retries
+	s.saveProgress(ctx, runID, progress)
+	if err != nil && ctx.Err() == nil {
+		span.RecordError(err)
+		logger.Warn("failed to ingest mentions",
+			"error", err,
+			"user_id", userID,
+			"run_id", runID)
+		s.recordRunError(ctx, runID, userID, "tweets", xUsername, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("mentions_fetched", fetched))
+}
+
+// ingestMentionPages reads and stores pages of mentions of xUsername
+// Mentions are mostly replies and quotes, so those are kept regardless of the policy; the language allow-list still applies
+func (s *IngestService) ingestMentionPages(ctx context.Context, userID uuid.UUID, xUsername string, policy db.IngestPolicy, backfillHours int) (int, int, int, error) {
+	isBackfill := backfillHours > 0
+	backfillCutoff := time.Now().Add(-time.Duration(backfillHours) * time.Hour)
+
+	var since time.Time
+	if isBackfill {
+		since = backfillCutoff
+	} else {
+		latest, err := s.postRepo.GetLatestMentionTime(ctx, userID)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if latest != nil {
+			since = *latest
+		}
+	}
+
+	mentionPolicy := policy
+	mentionPolicy.IncludeReplies = true
+	mentionPolicy.IncludeQuotes = true
+
+	label := "mentions:" + xUsername
+	tags := postTags{mention: true}
+	fetched := 0
+	rateLimitHits := 0
+	retried := 0
+	cursor := ""
+	latestSeenAt := time.Time{}
+
+	for page := 1; ; page++ {
+		resp, hits, retries, err := s.getMentionsWithRetry(ctx, xUsername, since, cursor)
+		rateLimitHits += hits
+		retried += retries
+		if err != nil {
+			return fetched, rateLimitHits, retried, fmt.Errorf("failed to get mentions: %w", err)
+		}
+
+		tweets := s.ensureTweetAuthors(ctx, label, resp.Tweets)
+		s.processTweetPage(
+			ctx, userID, label, mentionPolicy, tags, tweets,
+			backfillCutoff, isBackfill, &latestSeenAt, &fetched,
+		)
+
+		if !isBackfill && since.IsZero() {
+			// No mention stored yet: only fetch first page
+			break
+		}
+		if page >= MaxMentionPages {
+			logger.Debug("mentions page limit reached",
+				"x_username", xUsername,
+				"pages", page)
+			break
+		}
+		if !resp.HasNextPage || resp.NextCursor == "" {
+			break
+		}
+
+		cursor = resp.NextCursor
+	}
+
+	return fetched, rateLimitHits, retried, nil
+}
+
+// ensureTweetAuthors stores the authors of tweets that come from any author (search results and mentions)
+// Tweets whose author could not be stored are left out
+func (s *IngestService) ensureTweetAuthors(ctx context.Context, label string, tweets []TweetData) []TweetData {
+	stored := make([]TweetData, 0, len(tweets))
+	for _, tweet := range tweets {
+		if _, err := s.ensureAuthorExists(ctx, &tweet.Author); err != nil {
+			logger.Warn("failed to store tweet author, skipping tweet",
+				"error", err,
+				"tweet_id", tweet.ID,
+				"source", label)
+			continue
+		}
+		stored = append(stored, tweet)
+	}
+	return stored
+}
+
+// authorSource is an author whose tweets are ingested for the user
+// listIDs holds the lists the author was ingested through, empty for authors that are only followed
+type authorSource struct {
+	authorID int64
+	listIDs  []int64
+}
+
+// postTags holds the sources a stored post is tagged with besides the followed author
+// listIDs are the lists the post was ingested through, searchSourceID the saved search that found it,
+// and mention is set for mentions of the user's own account
+type postTags struct {
+	listIDs        []int64
+	searchSourceID string
+	mention        bool
+}
+
+// ingestFollowedAuthor ingests tweets of a single followed or listed author
+// Failures are logged and recorded against the run; counts are returned even when the author failed
+func (s *IngestService) ingestFollowedAuthor(
+	ctx context.Context,
+	userID uuid.UUID,
+	runID string,
+	authorID int64,
+	policy db.IngestPolicy,
+	tags postTags,
+	backfillCutoff time.Time,
+	isBackfill bool,
+) (int, int, int, error) {
+	// Get author details
+	author, err := s.authorRepo.GetAuthor(ctx, authorID)
+	if err != nil {
+		logger.Warn("failed to get author details, skipping",
+			"error", err,
+			"author_id", authorID,
+			"user_id", userID)
+		return 0, 0, 0, err
+	}
+
+	if author == nil || author.Handle == "" {
+		logger.Debug("skipping author with no handle",
+			"author_id", authorID,
+			"user_id", userID)
+		return 0, 0, 0, nil
+	}
+
+	// Get tweets for this author
+	fetched, hits, retries, err := s.ingestTweetsForAuthor(
+		ctx, userID, author.Handle, author.XAuthorID, policy, tags, backfillCutoff, isBackfill)
+	if err != nil {
+		if ctx.Err() != nil {
+			return fetched, hits, retries, err
+		}
+		logger.Warn("failed to ingest tweets for author, continuing with others",
+			"error", err,
+			"author_handle", author.Handle,
+			"author_id", authorID,
+			"user_id", userID)
+		s.recordRunError(ctx, runID, userID, "tweets", author.Handle, err)
+		return fetched, hits, retries, err
+	}
+
+	return fetched, hits, retries, nil
+}
+
+// saveProgress persists the run's per-phase progress; failures are logged and do not stop the run
+func (s *IngestService) saveProgress(ctx context.Context, runID string, progress *repositories.IngestRunProgress) {
+	if err := s.ingestRepo.UpdateIngestRunProgress(ctx, runID, *progress); err != nil {
+		logger.Warn("failed to update progress",
+			"error", err,
+			"run_id", runID,
+			"phase", progress.Phase)
+	}
+}
+
+// recordRunError stores a non-fatal error against the run so it is visible in the run details
+func (s *IngestService) recordRunError(ctx context.Context, runID string, userID uuid.UUID, phase string, authorHandle string, runErr error) {
+	var handle *string
+	if authorHandle != "" {
+		handle = &authorHandle
+	}
+
+	if err := s.ingestRepo.InsertIngestRunError(ctx, runID, userID, phase, handle, runErr.Error()); err != nil {
+		logger.Warn("failed to record ingest run error",
+			"error", err,
+			"run_id", runID,
+			"phase", phase)
+	}
+}
+
+// ingestTweetsForAuthor ingests tweets for a specific author with pagination and temporal filtering
+// Regular ingest pages down to the author's watermark (first page only if there is none), at most
+// MaxIncrementalPages per run; backfill paginates until the cutoff. The watermark is advanced only once paging
+// reached it. A run stopped by the page limit stores a resume point, and the next run continues from there
+func (s *IngestService) ingestTweetsForAuthor(
+	ctx context.Context,
+	userID uuid.UUID,
+	authorHandle string,
+	authorID int64,
+	policy db.IngestPolicy,
+	tags postTags,
+	backfillCutoff time.Time,
+	isBackfill bool,
+) (int, int, int, error) {
+	ctx, span := ingestionServiceTracer.Start(ctx, "ingestTweetsForAuthor")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("author_handle", authorHandle),
+		attribute.Int64("author_id", authorID),
+		attribute.Bool("is_backfill", isBackfill),
+	)
+
+	watermark, err := s.watermarkRepo.GetWatermark(ctx, userID, authorID)
+	if err != nil {
+		span.RecordError(err)
+		return 0, 0, 0, fmt.Errorf("failed to get author watermark: %w", err)
+	}
+
+	position := AuthorTimelinePosition{}
+	newest := tweetMark{}
+	if watermark != nil {
+		position.WatermarkID = watermark.LastTweetID
+		if watermark.ResumeCursor != nil {
+			position.ResumeCursor = *watermark.ResumeCursor
+		}
+		if watermark.PendingTweetID != nil && watermark.PendingTweetAt != nil {
+			// Tweets fetched by earlier runs that did not catch up become the watermark once this one does
+			position.PendingID = *watermark.PendingTweetID
+			newest = tweetMark{id: *watermark.PendingTweetID, at: *watermark.PendingTweetAt}
+		}
+		span.SetAttributes(
+			attribute.Int64("watermark_tweet_id", position.WatermarkID),
+			attribute.Bool("resuming", position.ResumeCursor != ""),
+		)
+	}
+
+	fetched := 0
+	rateLimitHits := 0
+	retried := 0
+	latestSeenAt := time.Time{}
+
+	fetchPage := func(ctx context.Context, cursor string) (*TweetResponse, error) {
+		resp, hits, retries, err := s.getTweetsWithRetry(ctx, authorID, authorHandle, cursor)
+		rateLimitHits += hits
+		retried += retries
+		return resp, err
+	}
+	handlePage := func(tweets []TweetData) bool {
+		_, reachedCutoff := s.processTweetPage(
+			ctx, userID, authorHandle, policy, tags, tweets,
+			backfillCutoff, isBackfill, &latestSeenAt, &fetched,
+		)
+		newest.track(tweets)
+		return reachedCutoff
+	}
+
+	var paging AuthorTimelinePaging
+	if isBackfill {
+		paging, err = pageBackfillTimeline(ctx, fetchPage, handlePage)
+	} else {
+		paging, err = PageAuthorTimeline(ctx, position, MaxIncrementalPages, fetchPage, func(tweets []TweetData) {
+			handlePage(tweets)
+		})
+	}
+
+	// Update author's last_seen_at if we found any tweets
+	if !latestSeenAt.IsZero() {
+		err := s.authorRepo.UpdateAuthorLastSeen(ctx, authorID, latestSeenAt)
+		if err != nil {
+			logger.Warn("failed to update author last_seen_at",
+				"error", err,
+				"author_id", authorID)
+		}
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		if paging.Resumed {
+			// The stored cursor may have expired; the next run starts catching up from the top again
+			s.saveResumePoint(ctx, userID, authorID, "", newest)
+		}
+		return fetched, rateLimitHits, retried, fmt.Errorf("failed to get user tweets: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.Int("pages", paging.Pages),
+		attribute.Bool("caught_up", paging.CaughtUp),
+	)
+
+	if paging.CaughtUp {
+		// Advance the watermark to the newest tweet seen
+		if newest.id > position.WatermarkID {
+			err := s.watermarkRepo.AdvanceWatermark(ctx, userID, authorID, newest.id, newest.at)
+			if err != nil {
+				logger.Warn("failed to advance author watermark",
+					"error", err,
+					"author_id", authorID,
+					"user_id", userID)
+			}
+		}
+	} else {
+		logger.Warn("regular ingest: page limit reached before watermark, resuming next run",
+			"author_handle", authorHandle,
+			"watermark_tweet_id", position.WatermarkID,
+			"pages", paging.Pages)
+		s.saveResumePoint(ctx, userID, authorID, paging.ResumeCursor, newest)
+	}
+
+	span.SetAttributes(
+		attribute.Int("tweets_fetched", fetched),
+		attribute.Int("rate_limit_hits", rateLimitHits),
+		attribute.Int("retried", retried),
+	)
+
+	return fetched, rateLimitHits, retried, nil
+}
+
+// saveResumePoint records where catching up to an author's watermark continues; failures are logged only
+func (s *IngestService) saveResumePoint(ctx context.Context, userID uuid.UUID, authorID int64, cursor string, newest tweetMark) {
+	if newest.id == 0 {
+		return
+	}
+
+	err := s.watermarkRepo.SaveResumePoint(ctx, userID, authorID, cursor, newest.id, newest.at)
+	if err != nil {
+		logger.Warn("failed to save author resume point",
+			"error", err,
+			"author_id", authorID,
+			"user_id", userID)
+	}
+}
+
+// AuthorTimelinePosition is where a regular ingest of an author's timeline starts from
+type AuthorTimelinePosition struct {
+	WatermarkID  int64  // Newest tweet up to which the timeline was ingested; 0 if never synced
+	ResumeCursor string // Cursor where an earlier run stopped before reaching the watermark
+	PendingID    int64  // Newest tweet fetched by the earlier runs that did not reach the watermark
+}
+
+// AuthorTimelinePaging is the outcome of paging an author's timeline
+type AuthorTimelinePaging struct {
+	Pages        int
+	CaughtUp     bool   // Paging reached the watermark or the end of the timeline, so the watermark may advance
+	Resumed      bool   // Paging jumped to the resume cursor of an earlier run
+	ResumeCursor string // Where the next run continues when not caught up
+}
+
+// PageAuthorTimeline pages an author's timeline from the newest tweet down to the watermark, at most maxPages pages
+// An author never synced gets the first page only. Once paging reaches the tweets an earlier run fetched
+// (PendingID), it jumps to that run's resume cursor instead of reading them again. When maxPages is reached
+// first, the result holds the cursor the next run continues from. handlePage gets every page fetched
+func PageAuthorTimeline(
+	ctx context.Context,
+	position AuthorTimelinePosition,
+	maxPages int,
+	fetchPage func(ctx context.Context, cursor string) (*TweetResponse, error),
+	handlePage func(tweets []TweetData),
+) (AuthorTimelinePaging, error) {
+	paging := AuthorTimelinePaging{}
+	cursor := ""
+
+	for {
+		resp, err := fetchPage(ctx, cursor)
+		if err != nil {
+			return paging, err
+		}
+		paging.Pages++
+
+		handlePage(resp.Tweets)
+
+		if position.WatermarkID == 0 || reachedWatermark(resp.Tweets, position.WatermarkID) || !resp.HasNextPage {
+			paging.CaughtUp = true
+			return paging, nil
+		}
+
+		next := resp.NextCursor
+		if !paging.Resumed && position.ResumeCursor != "" && reachedWatermark(resp.Tweets, position.PendingID) {
+			// Tweets from here down to the resume cursor were fetched by an earlier run
+			next = position.ResumeCursor
+			paging.Resumed = true
+		}
+
+		if paging.Pages >= maxPages {
+			paging.ResumeCursor = next
+			return paging, nil
+		}
+
+		cursor = next
+	}
+}
+
+// pageBackfillTimeline pages an author's timeline until handlePage reports the backfill cutoff or the timeline ends
+// A backfill always catches up, since tweets before the cutoff are not wanted
+func pageBackfillTimeline(
+	ctx context.Context,
+	fetchPage func(ctx context.Context, cursor string) (*TweetResponse, error),
+	handlePage func(tweets []TweetData) bool,
+) (AuthorTimelinePaging, error) {
+	paging := AuthorTimelinePaging{}
+	cursor := ""
+
+	for {
+		resp, err := fetchPage(ctx, cursor)
+		if err != nil {
+			return paging, err
+		}
+		paging.Pages++
+
+		if handlePage(resp.Tweets) || !resp.HasNextPage {
+			paging.CaughtUp = true
+			return paging, nil
+		}
+
+		cursor = resp.NextCursor
+	}
+}
+
+// tweetMark tracks the newest tweet seen while paginating an author's timeline
+type tweetMark struct {
+	id int64
+	at time.Time
+}
+
+// track updates the mark with the newest tweet of a page
+// All tweets count, including retweets and replies, since the watermark follows the timeline itself
+func (m *tweetMark) track(tweets []TweetData) {
+	for _, tweet := range tweets {
+		id, err := strconv.ParseInt(tweet.ID, 10, 64)
+		if err != nil || id <= m.id {
+			continue
+		}
+		tweetTime, err := time.Parse(time.RubyDate, tweet.CreatedAt)
+		if err != nil {
+			continue
+		}
+		m.id = id
+		m.at = tweetTime
+	}
+}
+
+// reachedWatermark reports whether a page of tweets reaches back to the watermark
+// The last (oldest) tweet is checked so a pinned tweet at the top of the first page does not stop pagination early
+func reachedWatermark(tweets []TweetData, watermarkID int64) bool {
+	if len(tweets) == 0 {
+		return true
+	}
+
+	id, err := strconv.ParseInt(tweets[len(tweets)-1].ID, 10, 64)
+	if err != nil {
+		return true
+	}
+
+	return id <= watermarkID
+}
+
+// processTweetPage processes a page of tweets and returns the count and whether backfill cutoff was reached
+func (s *IngestService) processTweetPage(
+	ctx context.Context,
+	userID uuid.UUID,
+	authorHandle string,
+	policy db.IngestPolicy,
+	tags postTags,
+	tweets []TweetData,
+	backfillCutoff time.Time,
+	isBackfill bool,
+	latestSeenAt *time.Time,
 	totalFetched *int,
 ) (int, bool) {
 	tweetsInPage := 0
 	reachedCutoff := false
 
 	for _, tweet := range tweets {
-		// Only ingest original posts (filters out retweets, quote tweets, but allows self-replies)
-		if !s.twitterClient.IsOriginalPost(tweet) {
+		// Only ingest the tweets allowed by the user's ingest policy (self-replies are always allowed)
+		if !AllowsTweet(policy, tweet) {
 			continue
 		}
 
@@ -509,7 +1589,7 @@ func (s *IngestService) processTweetPage(
 		}
 
 		// Process and store the tweet
-		if s.processSingleTweet(ctx, userID, authorHandle, &tweet) {
+		if s.processSingleTweet(ctx, userID, authorHandle, tags, &tweet) {
 			*totalFetched++
 			tweetsInPage++
 		}
@@ -519,25 +1599,83 @@ func (s *IngestService) processTweetPage(
 }
 
 // processSingleTweet processes and stores a single tweet, returns true if successfully stored
+// A retweet is stored as the retweeted tweet, and a quote tweet also stores the quoted tweet,
+// both under their original authors so they are cited correctly
+// Stored tweets are tagged with the lists or saved search they were ingested through
 func (s *IngestService) processSingleTweet(
 	ctx context.Context,
 	userID uuid.UUID,
 	authorHandle string,
+	tags postTags,
 	tweet *TweetData,
 ) bool {
+	if tweet.RetweetedTweet != nil {
+		repostedByID, err := strconv.ParseInt(tweet.Author.ID, 10, 64)
+		if err != nil {
+			repostedByID = 0
+		}
+		return s.storeEmbeddedTweet(ctx, userID, authorHandle, tags, tweet.RetweetedTweet, db.PostKindRetweet, repostedByID)
+	}
+
 	// Convert tweet to DTO
 	tweetDTO := s.twitterClient.ConvertToDTO(*tweet)
+	if !s.storeTweet(ctx, userID, authorHandle, tags, tweet, tweetDTO) {
+		return false
+	}
 
-	// Process media (images and videos) if OpenRouter client is available
-	if s.openRouterClient != nil {
-		if err := s.processMedia(ctx, tweet, tweetDTO); err != nil {
-			logger.Warn("failed to process media, continuing without media descriptions",
-				"error", err,
-				"tweet_id", tweet.ID,
-				"author_handle", authorHandle)
-		}
+	// Give link-only posts the content of the pages they share
+	s.enrichLinks(ctx, userID, authorHandle, tweet, tweetDTO.ID)
+
+	if tweet.QuotedTweet != nil {
+		s.storeEmbeddedTweet(ctx, userID, authorHandle, tags, tweet.QuotedTweet, db.PostKindQuoted, 0)
+	}
+
+	return true
+}
+
+// storeEmbeddedTweet stores a retweeted or quoted tweet under its original author
+// The author is added to authors if needed, without being followed
+func (s *IngestService) storeEmbeddedTweet(
+	ctx context.Context,
+	userID uuid.UUID,
+	authorHandle string,
+	tags postTags,
+	tweet *TweetData,
+	kind string,
+	repostedByID int64,
+) bool {
+	if _, err := s.ensureAuthorExists(ctx, &tweet.Author); err != nil {
+		logger.Warn("failed to store original author, skipping embedded tweet",
+			"error", err,
+			"tweet_id", tweet.ID,
+			"kind", kind,
+			"author_handle", authorHandle)
+		return false
+	}
+
+	tweetDTO := s.twitterClient.ConvertToDTO(*tweet)
+	tweetDTO.Kind = kind
+	tweetDTO.RepostedByID = repostedByID
+	// An embedded tweet is not part of a followed author's thread
+	tweetDTO.InReplyToID = 0
+	// A quoted tweet is not the mention itself, only the quote tweet is
+	if kind == db.PostKindQuoted {
+		tags.mention = false
 	}
 
+	return s.storeTweet(ctx, userID, authorHandle, tags, tweet, tweetDTO)
+}
+
+// storeTweet stores a converted tweet, returns true if successfully stored
+// A tweet that is already stored is checked for edits instead; both are tagged with the post's sources
+func (s *IngestService) storeTweet(
+	ctx context.Context,
+	userID uuid.UUID,
+	authorHandle string,
+	tags postTags,
+	tweet *TweetData,
+	tweetDTO *dto.TweetDTO,
+) bool {
 	// Check if tweet already exists in database
 	exists, err := s.postRepo.PostExists(ctx, userID, tweetDTO.ID)
 	if err != nil {
@@ -549,7 +1687,32 @@ func (s *IngestService) processSingleTweet(
 	}
 
 	if exists {
-		return false // Skip existing tweets
+		// Compare with the stored text to record edits made on X since the post was ingested
+		edited, err := s.postRepo.ApplyPostText(ctx, tweetDTO.ID, tweetDTO.RawText, time.Now())
+		if err != nil {
+			logger.Warn("failed to check post for edits",
+				"error", err,
+				"post_id", tweetDTO.ID,
+				"author_handle", authorHandle)
+		} else if edited {
+			logger.Info("post edit detected",
+				"post_id", tweetDTO.ID,
+				"author_handle", authorHandle)
+		}
+		if tags.mention {
+			if err := s.postRepo.MarkPostMention(ctx, userID, tweetDTO.ID); err != nil {
+				logger.Warn("failed to mark post as mention",
+					"error", err,
+					"post_id", tweetDTO.ID,
+					"user_id", userID)
+			}
+		}
+		s.tagPost(ctx, userID, tweetDTO.ID, tags)
+		return false
+	}
+
+	if tags.mention {
+		tweetDTO.Source = db.PostSourceMention
 	}
 
 	// Insert the tweet into database
@@ -562,9 +1725,137 @@ func (s *IngestService) processSingleTweet(
 		return false
 	}
 
+	s.tagPost(ctx, userID, tweetDTO.ID, tags)
+	s.storeMedia(ctx, userID, authorHandle, tweet, tweetDTO.ID)
+
+	if HasArticleLink(*tweet) {
+		s.storeArticle(ctx, userID, authorHandle, tweet.ID, tweetDTO.ID)
+	}
 	return true
 }
 
+// storeArticle fetches the X article linked from a newly stored post and stores its body in chunks
+// Failures are logged and do not stop the run; the post keeps its teaser text
+func (s *IngestService) storeArticle(ctx context.Context, userID uuid.UUID, authorHandle string, tweetID string, postID int64) {
+	article, _, _, err := s.getArticleWithRetry(ctx, authorHandle, tweetID)
+	if err != nil {
+		logger.Warn("failed to fetch article, keeping the teaser only",
+			"error", err,
+			"post_id", postID,
+			"author_handle", authorHandle)
+		return
+	}
+
+	chunks := ChunkArticle(ArticleBody(*article), ArticleChunkSize)
+	if len(chunks) == 0 {
+		logger.Warn("article has no content, keeping the teaser only",
+			"post_id", postID,
+			"author_handle", authorHandle)
+		return
+	}
+	if len(chunks) > MaxArticleChunks {
+		logger.Warn("article too long, storing its beginning only",
+			"post_id", postID,
+			"author_handle", authorHandle,
+			"chunk_count", len(chunks))
+		chunks = chunks[:MaxArticleChunks]
+	}
+
+	var title *string
+	if trimmed := strings.TrimSpace(article.Title); trimmed != "" {
+		title = &trimmed
+	}
+
+	if err := s.postRepo.InsertArticle(ctx, userID, postID, title, chunks); err != nil {
+		logger.Warn("failed to store article",
+			"error", err,
+			"post_id", postID,
+			"author_handle", authorHandle)
+	}
+}
+
+// enrichLinks fetches the outbound links of a newly stored post and stores their readable content
+// Pages are summarized when the OpenRouter client is available. Links are skipped when the link fetcher
+// is disabled; failures are logged and do not stop the run
+func (s *IngestService) enrichLinks(ctx context.Context, userID uuid.UUID, authorHandle string, tweet *TweetData, postID int64) {
+	if s.linkFetcher == nil {
+		return
+	}
+
+	links := OutboundLinks(*tweet)
+	if len(links) > s.linkFetcher.MaxLinksPerPost() {
+		links = links[:s.linkFetcher.MaxLinksPerPost()]
+	}
+
+	for _, link := range links {
+		page, err := s.linkFetcher.Fetch(ctx, link)
+		if err != nil {
+			logger.Warn("failed to fetch linked page",
+				"error", err,
+				"post_id", postID,
+				"url", link,
+				"author_handle", authorHandle)
+			continue
+		}
+		if page.Text == "" {
+			logger.Debug("linked page has no readable text, skipping",
+				"post_id", postID,
+				"url", link)
+			continue
+		}
+
+		postLink := db.PostLink{
+			XPostID:  postID,
+			URL:      page.URL,
+			FinalURL: page.FinalURL,
+			Content:  page.Text,
+		}
+		if page.Title != "" {
+			postLink.Title = &page.Title
+		}
+
+		if s.openRouterClient != nil {
+			summary, err := s.openRouterClient.SummarizePage(ctx, page.Title, page.Text)
+			if err != nil {
+				logger.Warn("failed to summarize linked page, storing its text only",
+					"error", err,
+					"post_id", postID,
+					"url", link)
+			} else if summary = strings.TrimSpace(summary); summary != "" {
+				postLink.Summary = &summary
+			}
+		}
+
+		if err := s.postRepo.InsertPostLink(ctx, userID, postLink); err != nil {
+			logger.Warn("failed to store linked page",
+				"error", err,
+				"post_id", postID,
+				"url", link)
+		}
+	}
+}
+
+// tagPost records the lists and saved search a post was ingested through; failures are logged and do not stop the run
+func (s *IngestService) tagPost(ctx context.Context, userID uuid.UUID, postID int64, tags postTags) {
+	if err := s.feedListRepo.TagPost(ctx, userID, postID, tags.listIDs); err != nil {
+		logger.Warn("failed to tag post with lists",
+			"error", err,
+			"post_id", postID,
+			"user_id", userID)
+	}
+
+	if tags.searchSourceID == "" {
+		return
+	}
+	if err := s.searchSourceRepo.TagPost(ctx, userID, postID, tags.searchSourceID); err != nil {
+		logger.Warn("failed to tag post with search source",
+			"error", err,
+			"post_id", postID,
+			"search_source_id", tags.searchSourceID,
+			"user_id", userID)
+	}
+}
+
 // ensureAuthorExists ensures an author exists in the database
 func (s *IngestService) ensureAuthorExists(ctx context.Context, user *UserData) (int64, error) {
 	ctx, span := ingestionServiceTracer.Start(ctx, "ensureAuthorExists")
@@ -596,188 +1887,193 @@ func (s *IngestService) ensureAuthorExists(ctx context.Context, user *UserData)
 
 // getFollowingsWithRetry gets user followings with exponential backoff retry logic
 func (s *IngestService) getFollowingsWithRetry(ctx context.Context, username string, cursor string) (*FollowingResponse, int, int, error) {
-	rateLimitHits := 0
-	retried := 0
-
-	for attempt := 0; attempt <= MaxRetries; attempt++ {
-		resp, err := s.twitterClient.GetUserFollowings(ctx, username, cursor)
-		if err == nil {
-			return resp, rateLimitHits, retried, nil
-		}
+	return withRetry(ctx, "user followings", username, func() (*FollowingResponse, error) {
+		return s.twitterClient.GetUserFollowings(ctx, username, cursor)
+	})
+}
 
-		// Check if it's a rate limit error (429)
-		if strings.Contains(err.Error(), "429") {
-			rateLimitHits++
+// getListMembersWithRetry gets a page of list members with exponential backoff retry logic
+func (s *IngestService) getListMembersWithRetry(ctx context.Context, listID int64, cursor string) (*ListMembersResponse, int, int, error) {
+	listName := fmt.Sprintf("list:%d", listID)
+	return withRetry(ctx, "list members", listName, func() (*ListMembersResponse, error) {
+		return s.twitterClient.GetListMembers(ctx, strconv.FormatInt(listID, 10), cursor)
+	})
+}
 
-			if attempt < MaxRetries {
-				retried++
-				backoffDelay := time.Duration(math.Pow(float64(BaseBackoffDelay), float64(attempt+1))) * time.Second
-				logger.Warn("rate limited, retrying with exponential backoff",
-					"attempt", attempt+1,
-					"max_retries", MaxRetries,
-					"backoff_delay", backoffDelay,
-					"username", username)
+// searchTweetsWithRetry gets a page of search results with exponential backoff retry logic
+func (s *IngestService) searchTweetsWithRetry(ctx context.Context, label string, query string, cursor string) (*SearchTweetsResponse, int, int, error) {
+	return withRetry(ctx, "search tweets", label, func() (*SearchTweetsResponse, error) {
+		return s.twitterClient.SearchTweets(ctx, query, cursor)
+	})
+}
 
-				time.Sleep(backoffDelay)
-				continue
-			}
-		}
+// getMentionsWithRetry gets a page of mentions of a user with exponential backoff retry logic
+func (s *IngestService) getMentionsWithRetry(ctx context.Context, username string, since time.Time, cursor string) (*MentionsResponse, int, int, error) {
+	return withRetry(ctx, "user mentions", username, func() (*MentionsResponse, error) {
+		return s.twitterClient.GetUserMentions(ctx, username, since, cursor)
+	})
+}
 
-		// Non-rate-limit error or max retries exceeded
-		return nil, rateLimitHits, retried, err
-	}
+// getArticleWithRetry gets the X article published with a tweet with exponential backoff retry logic
+func (s *IngestService) getArticleWithRetry(ctx context.Context, username string, tweetID string) (*ArticleData, int, int, error) {
+	return withRetry(ctx, "article", username, func() (*ArticleData, error) {
+		return s.twitterClient.GetArticle(ctx, tweetID)
+	})
+}
 
-	return nil, rateLimitHits, retried, fmt.Errorf("max retries exceeded for user followings")
+// getTweetsWithRetry gets an author's tweets with exponential backoff retry logic
+// Timelines of authors followed by several users are fetched once through the fetch planner
+func (s *IngestService) getTweetsWithRetry(ctx context.Context, authorID int64, username string, cursor string) (*TweetResponse, int, int, error) {
+	return withRetry(ctx, "user tweets", username, func() (*TweetResponse, error) {
+		return s.fetchPlanner.GetAuthorTweets(ctx, authorID, username, cursor)
+	})
 }
 
-// getTweetsWithRetry gets user tweets with exponential backoff retry logic
-func (s *IngestService) getTweetsWithRetry(ctx context.Context, username string, cursor string) (*TweetResponse, int, int, error) {
+// withRetry calls fn, retrying rate limits (429), server errors (5xx) and transient network errors
+// up to MaxRetries times. The delay follows the API's Retry-After hint when present, otherwise
+// exponential backoff. Returns the result, the number of rate limit hits and the number of retries
+func withRetry[T any](ctx context.Context, operation string, username string, fn func() (T, error)) (T, int, int, error) {
 	rateLimitHits := 0
 	retried := 0
 
-	for attempt := 0; attempt <= MaxRetries; attempt++ {
-		resp, err := s.twitterClient.GetUserTweets(ctx, username, cursor)
+	for attempt := 0; ; attempt++ {
+		resp, err := fn()
 		if err == nil {
 			return resp, rateLimitHits, retried, nil
 		}
 
-		// Check if it's a rate limit error (429)
-		if strings.Contains(err.Error(), "429") {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.IsRateLimited() {
 			rateLimitHits++
+		}
 
-			if attempt < MaxRetries {
-				retried++
-				backoffDelay := time.Duration(math.Pow(float64(BaseBackoffDelay), float64(attempt+1))) * time.Second
-				logger.Warn("rate limited, retrying with exponential backoff",
-					"attempt", attempt+1,
-					"max_retries", MaxRetries,
-					"backoff_delay", backoffDelay,
-					"username", username)
+		if attempt >= MaxRetries || !isRetryableError(ctx, err) {
+			return resp, rateLimitHits, retried, err
+		}
 
-				time.Sleep(backoffDelay)
-				continue
-			}
+		retried++
+		backoffDelay := retryDelay(attempt, err)
+		logger.Warn("request failed, retrying with backoff",
+			"error", err,
+			"operation", operation,
+			"attempt", attempt+1,
+			"max_retries", MaxRetries,
+			"backoff_delay", backoffDelay,
+			"username", username)
+
+		if err := sleepCtx(ctx, backoffDelay); err != nil {
+			return resp, rateLimitHits, retried, err
 		}
+	}
+}
+
+// isRetryableError reports whether a failed twitterapi.io call may succeed when retried
+func isRetryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
 
-		// Non-rate-limit error or max retries exceeded
-		return nil, rateLimitHits, retried, err
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsRetryable()
 	}
 
-	return nil, rateLimitHits, retried, fmt.Errorf("max retries exceeded for user tweets")
+	// Timeouts, refused or reset connections and truncated bodies
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
 }
 
-// processMedia processes media (images and videos) in a tweet and appends descriptions to the text
-func (s *IngestService) processMedia(ctx context.Context, tweet *TweetData, tweetDTO *dto.TweetDTO) error {
-	ctx, span := ingestionServiceTracer.Start(ctx, "processMedia")
-	defer span.End()
+// retryDelay returns how long to wait before the next attempt
+// The API's hint is honoured up to MaxRetryDelay; without one the delay grows exponentially
+func retryDelay(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return min(apiErr.RetryAfter, MaxRetryDelay)
+	}
+	return time.Duration(math.Pow(float64(BaseBackoffDelay), float64(attempt+1))) * time.Second
+}
 
-	if tweet.Media == nil {
-		return nil // No media to process
+// runTotals returns the totals already persisted on a run
+func runTotals(run *db.IngestRun) repositories.IngestRunTotals {
+	return repositories.IngestRunTotals{
+		FetchedCount:  run.FetchedCount,
+		Retried:       run.Retried,
+		RateLimitHits: run.RateLimitHits,
 	}
+}
 
-	var mediaDescriptions []string
+// sleepCtx waits for d or until ctx is done, whichever comes first
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
 
-	// Process images (max 4)
-	if len(tweet.Media.Photos) > 0 {
-		span.SetAttributes(attribute.Int("photo_count", len(tweet.Media.Photos)))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
 
-		// Collect image URLs (limit to MaxImagesPerPost)
-		imageURLs := make([]string, 0, len(tweet.Media.Photos))
-		for i, photo := range tweet.Media.Photos {
-			if i >= MaxImagesPerPost {
-				logger.Warn("tweet has more than max images, processing only first",
-					"total_images", len(tweet.Media.Photos),
-					"max_images", MaxImagesPerPost,
-					"tweet_id", tweet.ID)
-				break
-			}
-			imageURLs = append(imageURLs, photo.URL)
-		}
+// storeMedia stores the images and videos of a newly stored post with their descriptions
+// Descriptions are kept apart from the post text. Images are described concurrently and reuse cached
+// descriptions; a video already transcribed for another user is not transcribed again. Images without
+// an image describer and videos without a transcriber are stored pending for the media reprocessor.
+// Failures are logged and do not stop the run
+func (s *IngestService) storeMedia(ctx context.Context, userID uuid.UUID, authorHandle string, tweet *TweetData, postID int64) {
+	ctx, span := ingestionServiceTracer.Start(ctx, "storeMedia")
+	defer span.End()
 
-		// Describe images using OpenRouter
-		if len(imageURLs) > 0 {
-			descriptions, err := s.openRouterClient.DescribeImages(ctx, imageURLs)
-			if err != nil {
-				span.RecordError(err)
-				logger.Warn("failed to describe images",
-					"error", err,
-					"tweet_id", tweet.ID,
-					"image_count", len(imageURLs))
-				// Don't return error, continue without image descriptions
-			} else {
-				for i, desc := range descriptions {
-					mediaDescriptions = append(mediaDescriptions, fmt.Sprintf("[Image %d: %s]", i+1, desc))
-				}
-				span.SetAttributes(attribute.Int("images_described", len(descriptions)))
-			}
-		}
+	media := MediaFromTweet(tweet, postID)
+	if len(media) == 0 {
+		return
 	}
 
-	// Process videos
-	if len(tweet.Media.Videos) > 0 {
-		span.SetAttributes(attribute.Int("video_count", len(tweet.Media.Videos)))
+	span.SetAttributes(attribute.Int("media_count", len(media)))
 
-		for i, video := range tweet.Media.Videos {
-			// Calculate duration in seconds
-			durationSeconds := video.DurationMs / 1000
-
-			// Estimate size (we don't have exact size from API, so we skip size check)
-			// In a real implementation, you might need to fetch the video to check size
-			sizeBytes := int64(0) // Unknown size
+	toDescribe := make([]*db.PostMedia, 0, len(media))
+	for i := range media {
+		model := mediaDescriptionModel(media[i].MediaType, s.imageDescriber, s.transcriber)
+		if model == "" {
+			continue
+		}
 
-			// Try to transcribe video
-			transcription, err := s.openRouterClient.TranscribeVideo(ctx, video.URL, durationSeconds, sizeBytes)
+		if media[i].MediaType == db.MediaTypeVideo {
+			existing, err := s.postRepo.GetMediaDescription(ctx, media[i].URLHash, model)
 			if err != nil {
-				// Check if it's a limit error
-				if strings.Contains(err.Error(), "exceeds limit") {
-					logger.Warn("video exceeds limits, skipping",
-						"error", err,
-						"tweet_id", tweet.ID,
-						"video_index", i,
-						"duration_seconds", durationSeconds)
-					// Continue to next video
-					continue
-				} else if strings.Contains(err.Error(), "not yet implemented") {
-					logger.Debug("video transcription not implemented, skipping",
-						"tweet_id", tweet.ID,
-						"video_index", i)
-					// Continue to next video
-					continue
-				} else {
-					logger.Warn("failed to transcribe video",
-						"error", err,
-						"tweet_id", tweet.ID,
-						"video_index", i)
-					// Continue to next video
-					continue
-				}
+				logger.Warn("failed to look up media description",
+					"error", err,
+					"post_id", postID)
 			}
-
-			if transcription != "" {
-				mediaDescriptions = append(mediaDescriptions, fmt.Sprintf("[Video %d transcription: %s]", i+1, transcription))
-				span.SetAttributes(attribute.Int("videos_transcribed", i+1))
+			if existing != nil {
+				now := time.Now()
+				media[i].Description = existing
+				media[i].Model = &model
+				media[i].Status = db.MediaStatusDescribed
+				media[i].DescribedAt = &now
+				continue
 			}
 		}
-	}
 
-	// Append media descriptions to tweet text
-	if len(mediaDescriptions) > 0 {
-		originalText := tweetDTO.Text
-		mediaText := strings.Join(mediaDescriptions, " ")
-		tweetDTO.Text = originalText + "\n\n" + mediaText
-
-		span.SetAttributes(
-			attribute.Int("media_descriptions_count", len(mediaDescriptions)),
-			attribute.Int("original_text_length", len(originalText)),
-			attribute.Int("final_text_length", len(tweetDTO.Text)),
-		)
+		toDescribe = append(toDescribe, &media[i])
+	}
 
-		logger.Info("media processed and added to tweet text",
-			"tweet_id", tweet.ID,
-			"media_count", len(mediaDescriptions),
-			"original_length", len(originalText),
-			"final_length", len(tweetDTO.Text))
+	describeMediaItems(ctx, s.imageDescriber, s.transcriber, toDescribe)
+	for _, item := range toDescribe {
+		if item.Status == db.MediaStatusFailed {
+			logger.Warn("failed to describe media, leaving it for the media reprocessor",
+				"error", *item.Error,
+				"post_id", postID,
+				"media_type", item.MediaType,
+				"author_handle", authorHandle)
+		}
 	}
 
-	return nil
+	if err := s.postRepo.InsertPostMedia(ctx, userID, media); err != nil {
+		span.RecordError(err)
+		logger.Warn("failed to store post media",
+			"error", err,
+			"post_id", postID,
+			"author_handle", authorHandle)
+	}
 }
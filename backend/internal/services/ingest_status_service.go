@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sopeal/AskYourFeed/internal/db"
@@ -14,6 +16,9 @@ import (
 
 var ingestStatusServiceTracer = otel.Tracer("ingest_status_service")
 
+// MaxIngestRunErrors is the maximum number of errors returned with run details
+const MaxIngestRunErrors = 100
+
 // IngestStatusService handles business logic for ingestion operations
 type IngestStatusService struct {
 	ingestRepo *repositories.IngestRepository
@@ -81,6 +86,122 @@ func (s *IngestStatusService) GetIngestStatus(ctx context.Context, userID uuid.U
 	return response, nil
 }
 
+// GetIngestRun retrieves a single ingestion run with its per-phase progress and recorded errors
+// Returns ErrIngestRunNotFound if the run does not exist or belongs to another user
+func (s *IngestStatusService) GetIngestRun(ctx context.Context, userID uuid.UUID, runID string) (*dto.IngestRunDetailDTO, error) {
+	ctx, span := ingestStatusServiceTracer.Start(ctx, "GetIngestRun")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("run_id", runID),
+	)
+
+	run, err := s.ingestRepo.GetRunByID(ctx, userID, runID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrIngestRunNotFound) {
+			return nil, ErrIngestRunNotFound
+		}
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get ingest run: %w", err)
+	}
+
+	runErrors, err := s.ingestRepo.GetIngestRunErrors(ctx, userID, runID, MaxIngestRunErrors)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get ingest run errors: %w", err)
+	}
+
+	response := &dto.IngestRunDetailDTO{
+		ID:          run.ID,
+		Status:      run.Status,
+		Phase:       run.Phase,
+		StartedAt:   run.StartedAt,
+		CompletedAt: run.CompletedAt,
+		Progress: dto.IngestRunProgressDTO{
+			Following: run.FollowingCount,
+			Tweets:    run.TweetsCount,
+		},
+		FetchedCount:      run.FetchedCount,
+		Retried:           run.Retried,
+		RateLimitHits:     run.RateLimitHits,
+		Errors:            make([]dto.IngestRunErrorDTO, 0, len(runErrors)),
+		CancelRequestedAt: run.CancelRequestedAt,
+		HeartbeatAt:       run.HeartbeatAt,
+	}
+
+	if run.ErrText != nil {
+		response.Error = *run.ErrText
+	}
+
+	for _, runErr := range runErrors {
+		errorDTO := dto.IngestRunErrorDTO{
+			Phase:      runErr.Phase,
+			Message:    runErr.Message,
+			OccurredAt: runErr.CreatedAt,
+		}
+		if runErr.AuthorHandle != nil {
+			errorDTO.AuthorHandle = *runErr.AuthorHandle
+		}
+		response.Errors = append(response.Errors, errorDTO)
+	}
+
+	span.SetAttributes(
+		attribute.String("status", run.Status),
+		attribute.String("phase", run.Phase),
+		attribute.Int("errors_count", len(response.Errors)),
+	)
+
+	return response, nil
+}
+
+// GetRateLimitStats aggregates rate limit hits and retries of the user's runs over the last days (UTC)
+func (s *IngestStatusService) GetRateLimitStats(ctx context.Context, userID uuid.UUID, days int) (*dto.IngestRateLimitStatsDTO, error) {
+	ctx, span := ingestStatusServiceTracer.Start(ctx, "GetRateLimitStats")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.Int("days", days),
+	)
+
+	// The window covers today plus the previous days-1 full days
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+
+	dailyStats, err := s.ingestRepo.GetDailyRateLimitStats(ctx, userID, since)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get rate limit stats: %w", err)
+	}
+
+	response := &dto.IngestRateLimitStatsDTO{
+		Days:  days,
+		Daily: make([]dto.IngestRateLimitDayDTO, 0, len(dailyStats)),
+	}
+
+	for _, day := range dailyStats {
+		response.Runs += day.Runs
+		response.RateLimitedRuns += day.RateLimitedRuns
+		response.RateLimitHits += day.RateLimitHits
+		response.Retried += day.Retried
+
+		response.Daily = append(response.Daily, dto.IngestRateLimitDayDTO{
+			Date:            day.Day.Format(time.DateOnly),
+			Runs:            day.Runs,
+			RateLimitedRuns: day.RateLimitedRuns,
+			RateLimitHits:   day.RateLimitHits,
+			Retried:         day.Retried,
+		})
+	}
+
+	span.SetAttributes(
+		attribute.Int("runs", response.Runs),
+		attribute.Int("rate_limit_hits", response.RateLimitHits),
+	)
+
+	return response, nil
+}
+
 // mapIngestRunToDTO converts a database IngestRun entity to IngestRunDTO
 func mapIngestRunToDTO(run *db.IngestRun) *dto.IngestRunDTO {
 	runDTO := &dto.IngestRunDTO{
@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/net/html"
+)
+
+var linkFetcherTracer = otel.Tracer("link_fetcher")
+
+const (
+	// DefaultLinkFetchTimeout bounds a linked page fetch, redirects and body included
+	DefaultLinkFetchTimeout = 10 * time.Second
+
+	// DefaultLinkMaxBodyBytes is the most of a linked page that is read; the rest is ignored
+	DefaultLinkMaxBodyBytes = 2 * 1024 * 1024
+
+	// DefaultMaxLinksPerPost caps how many outbound links of a post are fetched
+	DefaultMaxLinksPerPost = 3
+
+	// MaxLinkRedirects is the number of redirects followed for a linked page
+	MaxLinkRedirects = 5
+
+	// MaxLinkTextLength caps the readable text kept from a linked page (in characters)
+	MaxLinkTextLength = 8000
+
+	// MaxLinkPromptLength caps the text of a linked page included in a Q&A prompt when it has no summary
+	MaxLinkPromptLength = 1500
+)
+
+// Common link fetcher errors
+var (
+	ErrLinkBlockedAddress     = errors.New("link resolves to a non-public address")
+	ErrLinkUnsupportedScheme  = errors.New("link scheme is not http or https")
+	ErrLinkUnsupportedContent = errors.New("linked content is not a web page")
+)
+
+// LinkFetcherConfig holds configuration for fetching pages linked from posts
+type LinkFetcherConfig struct {
+	Enabled         bool
+	Timeout         time.Duration
+	MaxBodyBytes    int
+	MaxLinksPerPost int
+}
+
+// DefaultLinkFetcherConfig returns the default link fetcher configuration
+func DefaultLinkFetcherConfig() LinkFetcherConfig {
+	return LinkFetcherConfig{
+		Enabled:         true,
+		Timeout:         DefaultLinkFetchTimeout,
+		MaxBodyBytes:    DefaultLinkMaxBodyBytes,
+		MaxLinksPerPost: DefaultMaxLinksPerPost,
+	}
+}
+
+// LinkPage is the readable content of a page linked from a post
+type LinkPage struct {
+	URL      string // Link as found in the post
+	FinalURL string // URL after redirects
+	Title    string
+	Text     string
+}
+
+// LinkFetcher fetches pages linked from posts and extracts their readable text
+type LinkFetcher struct {
+	httpClient *http.Client
+	config     LinkFetcherConfig
+}
+
+// NewLinkFetcher creates a new LinkFetcher instance
+// A nil httpClient uses NewSafeHTTPClient, which refuses to connect to non-public addresses
+func NewLinkFetcher(httpClient *http.Client, config LinkFetcherConfig) *LinkFetcher {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultLinkFetchTimeout
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = DefaultLinkMaxBodyBytes
+	}
+	if config.MaxLinksPerPost <= 0 {
+		config.MaxLinksPerPost = DefaultMaxLinksPerPost
+	}
+	if httpClient == nil {
+		httpClient = NewSafeHTTPClient(config.Timeout)
+	}
+
+	return &LinkFetcher{
+		httpClient: httpClient,
+		config:     config,
+	}
+}
+
+// MaxLinksPerPost returns how many links of a post are fetched
+func (f *LinkFetcher) MaxLinksPerPost() int {
+	return f.config.MaxLinksPerPost
+}
+
+// NewSafeHTTPClient creates an HTTP client for fetching user-supplied URLs
+// Connections are checked after DNS resolution, so a host resolving to a loopback, private,
+// link-local or otherwise non-public address is refused, including on redirects.
+// Proxies from the environment are not used, as they would bypass the check
+func NewSafeHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil || !IsPublicAddress(addr) {
+				return ErrLinkBlockedAddress
+			}
+			return nil
+		},
+	}
+
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= MaxLinkRedirects {
+				return fmt.Errorf("stopped after %d redirects", MaxLinkRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return ErrLinkUnsupportedScheme
+			}
+			return nil
+		},
+	}
+}
+
+// nonPublicPrefixes are special-purpose ranges not covered by the netip.Addr checks
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// IsPublicAddress reports whether an IP address is publicly routable
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// Fetch downloads a linked page and extracts its title and readable text
+// Only HTML and plain text pages are read, up to the configured size
+func (f *LinkFetcher) Fetch(ctx context.Context, rawURL string) (*LinkPage, error) {
+	ctx, span := linkFetcherTracer.Start(ctx, "Fetch")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("url", rawURL))
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid link: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, ErrLinkUnsupportedScheme
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", parsed.String(), nil)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "AskYourFeed/1.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/plain;q=0.9")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, ErrLinkBlockedAddress) {
+			return nil, ErrLinkBlockedAddress
+		}
+		return nil, fmt.Errorf("failed to fetch link: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			span.RecordError(err)
+		}
+	}()
+
+	span.SetAttributes(attribute.Int("status_code", resp.StatusCode))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("link returned status %d", resp.StatusCode)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	body := io.LimitReader(resp.Body, int64(f.config.MaxBodyBytes))
+
+	page := &LinkPage{
+		URL:      rawURL,
+		FinalURL: resp.Request.URL.String(),
+	}
+
+	switch mediaType {
+	case "text/html", "application/xhtml+xml":
+		page.Title, page.Text, err = ExtractReadableText(body)
+	case "text/plain":
+		var data []byte
+		data, err = io.ReadAll(body)
+		page.Text = strings.TrimSpace(string(data))
+	default:
+		return nil, ErrLinkUnsupportedContent
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to read linked page: %w", err)
+	}
+
+	// Pages in legacy encodings are not converted; invalid bytes are dropped so the text can be stored
+	page.Title = strings.ToValidUTF8(page.Title, "")
+	page.Text = truncateRunes(strings.ToValidUTF8(page.Text, ""), MaxLinkTextLength)
+
+	span.SetAttributes(
+		attribute.String("final_url", page.FinalURL),
+		attribute.Int("text_length", len(page.Text)),
+	)
+
+	return page, nil
+}
+
+// skippedElements hold no readable content
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "svg": true, "iframe": true,
+	"nav": true, "header": true, "footer": true, "aside": true, "form": true, "button": true,
+}
+
+// blockElements start a new paragraph of readable text
+var blockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "tr": true, "blockquote": true, "pre": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"article": true, "main": true, "section": true, "figcaption": true,
+}
+
+// ExtractReadableText extracts the title and readable text of an HTML page
+// Scripts, styles and page chrome (navigation, header, footer, forms) are left out.
+// When the page has an <article> or <main> element, only its text is kept.
+// Pages without readable text fall back to their description meta tag
+func ExtractReadableText(r io.Reader) (string, string, error) {
+	tokenizer := html.NewTokenizer(r)
+
+	var title, description string
+	var all, main strings.Builder
+	inTitle := false
+	skipDepth := 0
+	mainDepth := 0
+
+	write := func(text string) {
+		all.WriteString(text)
+		if mainDepth > 0 {
+			main.WriteString(text)
+		}
+	}
+
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", "", err
+			}
+			text := normalizeReadableText(main.String())
+			if text == "" {
+				text = normalizeReadableText(all.String())
+			}
+			if text == "" {
+				text = description
+			}
+			return title, text, nil
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			tag := string(name)
+			switch {
+			case tag == "title" && skipDepth == 0:
+				inTitle = true
+			case tag == "meta" && hasAttr:
+				if content := metaContent(tokenizer); content != "" {
+					description = content
+				}
+			case skippedElements[tag]:
+				if tokenType == html.StartTagToken {
+					skipDepth++
+				}
+			case tag == "article" || tag == "main":
+				mainDepth++
+			}
+			if blockElements[tag] {
+				write("\n")
+			}
+
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			switch {
+			case tag == "title":
+				inTitle = false
+			case skippedElements[tag] && skipDepth > 0:
+				skipDepth--
+			case (tag == "article" || tag == "main") && mainDepth > 0:
+				mainDepth--
+			}
+			if blockElements[tag] {
+				write("\n")
+			}
+
+		case html.TextToken:
+			text := string(tokenizer.Text())
+			if inTitle {
+				title = strings.TrimSpace(strings.Join(strings.Fields(title+" "+text), " "))
+				continue
+			}
+			if skipDepth == 0 {
+				write(text)
+			}
+		}
+	}
+}
+
+// metaContent returns the content of a description meta tag, or "" for other meta tags
+func metaContent(tokenizer *html.Tokenizer) string {
+	var name, content string
+	for {
+		key, value, more := tokenizer.TagAttr()
+		switch string(key) {
+		case "name", "property":
+			name = strings.ToLower(string(value))
+		case "content":
+			content = string(value)
+		}
+		if !more {
+			break
+		}
+	}
+
+	if name == "description" || name == "og:description" {
+		return strings.TrimSpace(content)
+	}
+	return ""
+}
+
+// normalizeReadableText collapses whitespace within lines and drops empty lines
+func normalizeReadableText(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncateRunes shortens text to at most limit characters
+func truncateRunes(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	return string([]rune(text)[:limit])
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	openai "github.com/sashabaranov/go-openai"
@@ -74,32 +75,172 @@ func (s *LLMService) GenerateAnswer(ctx context.Context, question string, posts
 }
 
 // formatPostsForLLM formats posts chronologically with metadata
+// A self-reply thread is presented as a single post holding its parts in order
 func (s *LLMService) formatPostsForLLM(posts []db.PostWithAuthor) string {
 	if len(posts) == 0 {
 		return ""
 	}
 
 	var formatted string
-	for i, post := range posts {
+	for i, thread := range GroupThreads(posts) {
+		post := thread[0]
 		displayName := post.Handle
 		if post.DisplayName != nil && *post.DisplayName != "" {
 			displayName = *post.DisplayName
 		}
 
+		if len(thread) == 1 {
+			formatted += fmt.Sprintf(
+				"[Post %d]\nAuthor: %s (@%s)\nPublished: %s\nURL: %s\nEngagement: %d likes, %d reposts, %d quotes, %d replies\n%sContent: %s\n%s\n",
+				i+1,
+				displayName,
+				post.Handle,
+				post.PublishedAt.Format(time.RFC3339),
+				post.URL,
+				post.LikeCount,
+				post.RetweetCount,
+				post.QuoteCount,
+				post.ReplyCount,
+				describePostKind(post),
+				post.Text,
+				describeMedia(post)+describeArticle(post)+describeLinks(post),
+			)
+			continue
+		}
+
 		formatted += fmt.Sprintf(
-			"[Post %d]\nAuthor: %s (@%s)\nPublished: %s\nURL: %s\nContent: %s\n\n",
+			"[Post %d]\nThread of %d parts\nAuthor: %s (@%s)\nPublished: %s\nURL: %s\n",
 			i+1,
+			len(thread),
 			displayName,
 			post.Handle,
 			post.PublishedAt.Format(time.RFC3339),
 			post.URL,
-			post.Text,
 		)
+		for j, part := range thread {
+			formatted += fmt.Sprintf(
+				"(%d/%d) Published: %s\nEngagement: %d likes, %d reposts, %d quotes, %d replies\n%sContent: %s\n%s",
+				j+1,
+				len(thread),
+				part.PublishedAt.Format(time.RFC3339),
+				part.LikeCount,
+				part.RetweetCount,
+				part.QuoteCount,
+				part.ReplyCount,
+				describePostKind(part),
+				part.Text,
+				describeMedia(part)+describeArticle(part)+describeLinks(part),
+			)
+		}
+		formatted += "\n"
 	}
 
 	return formatted
 }
 
+// describePostKind returns the context lines of mentions of the user, retweets, quote tweets and replies to other authors
+func describePostKind(post db.PostWithAuthor) string {
+	description := ""
+	if post.Source == db.PostSourceMention {
+		description = "Mentions the user\n"
+	}
+
+	switch post.Kind {
+	case db.PostKindRetweet:
+		if post.RepostedByHandle != nil {
+			description += fmt.Sprintf("Reposted by: @%s\n", *post.RepostedByHandle)
+		}
+	case db.PostKindQuote:
+		if post.QuotedHandle != nil && post.QuotedText != nil {
+			description += fmt.Sprintf("Quoting @%s: %s\n", *post.QuotedHandle, *post.QuotedText)
+		}
+	case db.PostKindReply:
+		description += "Reply to another author's post\n"
+	}
+	return description
+}
+
+// describeMedia returns the generated descriptions of a post's images and videos
+// They are marked as such so the LLM does not take them for the author's words
+func describeMedia(post db.PostWithAuthor) string {
+	description := ""
+	images, videos := 0, 0
+	for _, item := range post.Media {
+		if item.MediaType == db.MediaTypeImage {
+			images++
+		} else {
+			videos++
+		}
+		if item.Status != db.MediaStatusDescribed || item.Description == nil {
+			continue
+		}
+
+		if item.MediaType == db.MediaTypeImage {
+			description += fmt.Sprintf("- Image %d: %s\n", images, *item.Description)
+		} else {
+			description += fmt.Sprintf("- Video %d transcription: %s\n", videos, *item.Description)
+		}
+	}
+
+	if description == "" {
+		return ""
+	}
+	return "Media (generated descriptions, not written by the author):\n" + description
+}
+
+// describeArticle returns the chunks of the X article linked from a post selected for the prompt
+// The post content is then only the article's teaser; omitted parts of the article are marked
+func describeArticle(post db.PostWithAuthor) string {
+	article := post.Article
+	if article == nil || len(article.Chunks) == 0 {
+		return ""
+	}
+
+	description := "Article"
+	if article.Title != nil {
+		description += ": " + *article.Title
+	}
+	description += "\n"
+
+	last := -1
+	for i, chunk := range article.Chunks {
+		index := i
+		if article.ChunkIndexes != nil {
+			index = article.ChunkIndexes[i]
+		}
+		if index > last+1 {
+			description += "(...)\n\n"
+		}
+		description += chunk + "\n\n"
+		last = index
+	}
+	description = strings.TrimSuffix(description, "\n")
+
+	if article.ChunkCount > last+1 {
+		description += "(article continues)\n"
+	}
+	return description
+}
+
+// describeLinks returns the summaries of the pages linked from a post
+// Pages that were not summarized are described by the beginning of their text
+func describeLinks(post db.PostWithAuthor) string {
+	description := ""
+	for _, link := range post.Links {
+		title := link.FinalURL
+		if link.Title != nil && *link.Title != "" {
+			title = fmt.Sprintf("%s (%s)", *link.Title, link.FinalURL)
+		}
+
+		content := truncateRunes(link.Content, MaxLinkPromptLength)
+		if link.Summary != nil && *link.Summary != "" {
+			content = *link.Summary
+		}
+		description += fmt.Sprintf("Linked page: %s\n%s\n", title, content)
+	}
+	return description
+}
+
 // buildSystemPrompt constructs the system prompt for the LLM
 func (s *LLMService) buildSystemPrompt() string {
 	return `You are an AI assistant that analyzes social media feed posts. Your role is to answer user questions based ONLY on the feed posts provided below.
@@ -110,6 +251,13 @@ Important constraints:
 - Generate structured answers with bullet points when appropriate
 - Be concise and factual
 - If the posts don't contain relevant information, state this clearly
+- Engagement counts show which posts are getting traction; favour them when several posts cover the same topic
+- A thread is one post written in several parts; read its parts in order and cite it as a single post
+- A reposted post is cited under its original author; a quoted post is context for the author's comment on it
+- Media descriptions are generated from the post's images and videos; treat them as context, never as the author's words
+- A linked page is the content a post shares; use it to explain what the post is about
+- A post followed by an article links to a long-form article by its author; the article text is the substance of the post
+- A post that mentions the user was written to or about the user, usually by an account the user does not follow
 - Always cite which posts you're referencing in your answer
 - Answer in the same language as the user question.`
 }
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/db"
+)
+
+// MaxMediaAttempts is the number of times describing a media item is tried before it stays failed
+const MaxMediaAttempts = 3
+
+// MediaURLHash returns the hash identifying a media item across posts and users
+func MediaURLHash(mediaURL string) string {
+	sum := sha256.Sum256([]byte(mediaURL))
+	return hex.EncodeToString(sum[:])
+}
+
+// MediaFromTweet lists the media of a tweet as pending post_media rows, images first
+// At most MaxImagesPerPost images are kept
+func MediaFromTweet(tweet *TweetData, postID int64) []db.PostMedia {
+	if tweet.Media == nil {
+		return nil
+	}
+
+	media := make([]db.PostMedia, 0, len(tweet.Media.Photos)+len(tweet.Media.Videos))
+	for i, photo := range tweet.Media.Photos {
+		if i >= MaxImagesPerPost {
+			break
+		}
+		if photo.URL == "" {
+			continue
+		}
+		media = append(media, db.PostMedia{
+			XPostID:   postID,
+			MediaType: db.MediaTypeImage,
+			URL:       photo.URL,
+		})
+	}
+	for _, video := range tweet.Media.Videos {
+		if video.URL == "" {
+			continue
+		}
+		durationMs := video.DurationMs
+		media = append(media, db.PostMedia{
+			XPostID:    postID,
+			MediaType:  db.MediaTypeVideo,
+			URL:        video.URL,
+			DurationMs: &durationMs,
+		})
+	}
+
+	for i := range media {
+		media[i].MediaIndex = i
+		media[i].URLHash = MediaURLHash(media[i].URL)
+		media[i].Status = db.MediaStatusPending
+	}
+	return media
+}
+
+// describeMediaItems describes media items and records the outcome on each of them
+// Images are described concurrently through the image describer and videos are transcribed one at a time;
+// the caller passes only items whose type has a describer. Media over the limits is marked skipped;
+// other errors mark the item failed
+func describeMediaItems(ctx context.Context, imageDescriber *ImageDescriber, transcriber Transcriber, items []*db.PostMedia) {
+	var images []*db.PostMedia
+	for _, item := range items {
+		if item.MediaType == db.MediaTypeImage {
+			images = append(images, item)
+			continue
+		}
+
+		durationSeconds := 0
+		if item.DurationMs != nil {
+			durationSeconds = *item.DurationMs / 1000
+		}
+		transcript, err := transcriber.Transcribe(ctx, item.URL, durationSeconds)
+		recordMediaDescription(item, transcript, transcriber.Model(), err)
+	}
+
+	if len(images) == 0 {
+		return
+	}
+
+	imageURLs := make([]string, len(images))
+	for i, item := range images {
+		imageURLs[i] = item.URL
+	}
+	for i, result := range imageDescriber.DescribeImages(ctx, imageURLs) {
+		recordMediaDescription(images[i], result.Description, result.Model, result.Err)
+	}
+}
+
+// recordMediaDescription records the outcome of one description attempt on a media item
+func recordMediaDescription(item *db.PostMedia, description string, model string, err error) {
+	item.Attempts++
+	if err != nil {
+		errText := err.Error()
+		item.Error = &errText
+		item.Status = db.MediaStatusFailed
+		if errors.Is(err, ErrMediaExceedsLimit) {
+			item.Status = db.MediaStatusSkipped
+		}
+		return
+	}
+
+	description = strings.TrimSpace(description)
+	now := time.Now()
+	item.Description = &description
+	item.Model = &model
+	item.Status = db.MediaStatusDescribed
+	item.Error = nil
+	item.DescribedAt = &now
+}
+
+// mediaDescriptionModel returns the model describing media of the given type, or "" if none is configured
+func mediaDescriptionModel(mediaType string, imageDescriber *ImageDescriber, transcriber Transcriber) string {
+	switch mediaType {
+	case db.MediaTypeImage:
+		if imageDescriber != nil {
+			return imageDescriber.Model()
+		}
+	case db.MediaTypeVideo:
+		if transcriber != nil {
+			return transcriber.Model()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var mediaReprocessorTracer = otel.Tracer("media_reprocessor")
+
+const (
+	// DefaultMediaReprocessInterval is how often media still to be described is picked up
+	DefaultMediaReprocessInterval = time.Hour
+
+	// DefaultMediaReprocessBatchSize caps the media items read per cycle
+	DefaultMediaReprocessBatchSize = 200
+)
+
+// MediaReprocessorConfig holds configuration for the periodic media reprocessor
+type MediaReprocessorConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	BatchSize int
+	// Redescribe also picks up media described by a model other than the current one for its type
+	Redescribe bool
+}
+
+// DefaultMediaReprocessorConfig returns the default media reprocessor configuration
+func DefaultMediaReprocessorConfig() MediaReprocessorConfig {
+	return MediaReprocessorConfig{
+		Enabled:    true,
+		Interval:   DefaultMediaReprocessInterval,
+		BatchSize:  DefaultMediaReprocessBatchSize,
+		Redescribe: false,
+	}
+}
+
+// MediaReprocessor periodically describes stored media that has no current description
+// It picks up media stored while OpenRouter or the transcriber was unavailable, retries failed
+// descriptions, and with Redescribe enabled replaces descriptions made by an older model.
+// Each cycle also purges expired cached image descriptions.
+// Every process runs its own reprocessor; disable it on all but one replica
+type MediaReprocessor struct {
+	imageDescriber *ImageDescriber
+	transcriber    Transcriber
+	postRepo       *repositories.PostRepository
+	config         MediaReprocessorConfig
+}
+
+// NewMediaReprocessor creates a new MediaReprocessor instance
+func NewMediaReprocessor(
+	imageDescriber *ImageDescriber,
+	transcriber Transcriber,
+	postRepo *repositories.PostRepository,
+	config MediaReprocessorConfig,
+) *MediaReprocessor {
+	if config.Interval <= 0 {
+		config.Interval = DefaultMediaReprocessInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultMediaReprocessBatchSize
+	}
+
+	return &MediaReprocessor{
+		imageDescriber: imageDescriber,
+		transcriber:    transcriber,
+		postRepo:       postRepo,
+		config:         config,
+	}
+}
+
+// Run starts the reprocessing loop and blocks until ctx is cancelled
+func (r *MediaReprocessor) Run(ctx context.Context) {
+	if !r.config.Enabled {
+		logger.Info("media reprocessor disabled")
+		return
+	}
+	if r.imageDescriber == nil && r.transcriber == nil {
+		logger.Warn("media reprocessor disabled - neither image describer nor transcriber configured")
+		return
+	}
+
+	logger.Info("media reprocessor started",
+		"interval", r.config.Interval.String(),
+		"batch_size", r.config.BatchSize,
+		"redescribe", r.config.Redescribe)
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("media reprocessor stopped")
+			return
+		case <-ticker.C:
+			r.Reprocess(ctx)
+			if r.imageDescriber != nil {
+				r.imageDescriber.PurgeExpiredCache(ctx)
+			}
+		}
+	}
+}
+
+// Reprocess describes one batch of media still to be described
+// Each distinct media item is described once and the outcome is recorded on every copy of it
+// Returns the number of media items described
+func (r *MediaReprocessor) Reprocess(ctx context.Context) int {
+	ctx, span := mediaReprocessorTracer.Start(ctx, "Reprocess")
+	defer span.End()
+
+	imageModel := mediaDescriptionModel(db.MediaTypeImage, r.imageDescriber, r.transcriber)
+	videoModel := mediaDescriptionModel(db.MediaTypeVideo, r.imageDescriber, r.transcriber)
+	media, err := r.postRepo.ListMediaForReprocessing(ctx, imageModel, videoModel, r.config.Redescribe, MaxMediaAttempts, r.config.BatchSize)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("media reprocessor failed to list media", err)
+		return 0
+	}
+
+	seen := make(map[string]bool, len(media))
+	distinct := make([]*db.PostMedia, 0, len(media))
+	for i := range media {
+		if seen[media[i].URLHash] {
+			continue
+		}
+		seen[media[i].URLHash] = true
+		distinct = append(distinct, &media[i])
+	}
+
+	describeMediaItems(ctx, r.imageDescriber, r.transcriber, distinct)
+
+	described, failed := 0, 0
+	for _, item := range distinct {
+		if ctx.Err() != nil {
+			break
+		}
+		if item.Status == db.MediaStatusDescribed {
+			described++
+		} else {
+			failed++
+			logger.Warn("failed to describe media",
+				"error", *item.Error,
+				"post_id", item.XPostID,
+				"media_type", item.MediaType,
+				"status", item.Status)
+		}
+
+		if _, err := r.postRepo.UpdateMediaDescription(ctx, *item); err != nil {
+			span.RecordError(err)
+			logger.Warn("failed to record media description",
+				"error", err,
+				"post_id", item.XPostID)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("media_listed", len(media)),
+		attribute.Int("media_described", described),
+		attribute.Int("media_failed", failed),
+	)
+
+	logger.Info("media reprocessing completed",
+		"media_listed", len(media),
+		"media_described", described,
+		"media_failed", failed)
+
+	return described
+}
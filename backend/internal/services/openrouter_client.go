@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/sashabaranov/go-openai"
-	"github.com/sopeal/AskYourFeed/pkg/logger"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -21,17 +21,14 @@ const (
 	// MaxImageSize is the maximum image size to process (25 MB)
 	MaxImageSize = 25 * 1024 * 1024
 
-	// MaxVideoSize is the maximum video size to process (25 MB)
-	MaxVideoSize = 25 * 1024 * 1024
-
-	// MaxVideoDuration is the maximum video duration in seconds (90 seconds)
-	MaxVideoDuration = 90
-
 	// MaxImagesPerPost is the maximum number of images to process per post
 	MaxImagesPerPost = 4
+
+	// MediaDescriptionModel is the vision model describing images; recorded with each description
+	MediaDescriptionModel = "openai/gpt-4o-mini" // Using GPT-4o-mini for vision (cost-effective)
 )
 
-// OpenRouterClient handles communication with OpenRouter API for vision and transcription
+// OpenRouterClient handles communication with OpenRouter API for vision and summaries
 type OpenRouterClient struct {
 	client *openai.Client
 }
@@ -57,15 +54,20 @@ func NewOpenRouterClient(apiKey string, httpClient *http.Client) *OpenRouterClie
 }
 
 // DescribeImage generates a text description of an image using vision model
+// imageURL is either a URL the model fetches or a base64 data URL of downloaded image bytes
 func (c *OpenRouterClient) DescribeImage(ctx context.Context, imageURL string) (string, error) {
 	ctx, span := openRouterTracer.Start(ctx, "DescribeImage")
 	defer span.End()
 
-	span.SetAttributes(attribute.String("image_url", imageURL))
+	if strings.HasPrefix(imageURL, "data:") {
+		span.SetAttributes(attribute.Int("image_data_length", len(imageURL)))
+	} else {
+		span.SetAttributes(attribute.String("image_url", imageURL))
+	}
 
 	// Prepare the request using SDK
 	req := openai.ChatCompletionRequest{
-		Model: "openai/gpt-4o-mini", // Using GPT-4o-mini for vision (cost-effective)
+		Model: MediaDescriptionModel,
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role: openai.ChatMessageRoleUser,
@@ -95,79 +97,35 @@ func (c *OpenRouterClient) DescribeImage(ctx context.Context, imageURL string) (
 	return description, nil
 }
 
-// DescribeImages generates text descriptions for multiple images
-func (c *OpenRouterClient) DescribeImages(ctx context.Context, imageURLs []string) ([]string, error) {
-	ctx, span := openRouterTracer.Start(ctx, "DescribeImages")
+// SummarizePage generates a short summary of the readable text of a page linked from a post
+func (c *OpenRouterClient) SummarizePage(ctx context.Context, title string, text string) (string, error) {
+	ctx, span := openRouterTracer.Start(ctx, "SummarizePage")
 	defer span.End()
 
-	span.SetAttributes(attribute.Int("image_count", len(imageURLs)))
-
-	// Limit to MaxImagesPerPost
-	if len(imageURLs) > MaxImagesPerPost {
-		logger.Warn("too many images, limiting to max",
-			"total", len(imageURLs),
-			"max", MaxImagesPerPost)
-		imageURLs = imageURLs[:MaxImagesPerPost]
-	}
-
-	descriptions := make([]string, 0, len(imageURLs))
+	span.SetAttributes(attribute.Int("text_length", len(text)))
 
-	for i, imageURL := range imageURLs {
-		description, err := c.DescribeImage(ctx, imageURL)
-		if err != nil {
-			logger.Warn("failed to describe image, skipping",
-				"error", err,
-				"image_index", i,
-				"image_url", imageURL)
-			continue
-		}
-		descriptions = append(descriptions, description)
-	}
-
-	span.SetAttributes(attribute.Int("successful_descriptions", len(descriptions)))
-	return descriptions, nil
-}
-
-// TranscribeVideo transcribes video content to text
-// Note: OpenRouter doesn't directly support video transcription via API
-// This is a placeholder for future implementation or alternative service
-func (c *OpenRouterClient) TranscribeVideo(ctx context.Context, videoURL string, durationSeconds int, sizeBytes int64) (string, error) {
-	_, span := openRouterTracer.Start(ctx, "TranscribeVideo")
-	defer span.End()
-
-	span.SetAttributes(
-		attribute.String("video_url", videoURL),
-		attribute.Int("duration_seconds", durationSeconds),
-		attribute.Int64("size_bytes", sizeBytes),
-	)
-
-	// Check video limits
-	if durationSeconds > MaxVideoDuration {
-		logger.Warn("video exceeds duration limit, skipping",
-			"duration", durationSeconds,
-			"max_duration", MaxVideoDuration,
-			"video_url", videoURL)
-		return "", fmt.Errorf("video duration %ds exceeds limit of %ds", durationSeconds, MaxVideoDuration)
+	req := openai.ChatCompletionRequest{
+		Model: MediaDescriptionModel, // Same cost-effective model as for images
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleSystem,
+				Content: "Summarize the web page below in at most 5 sentences. Keep the key claims, numbers and conclusions. Answer in the language of the page. Ignore navigation, cookie notices and ads.",
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: fmt.Sprintf("Title: %s\n\n%s", title, text),
+			},
+		},
 	}
 
-	if sizeBytes > MaxVideoSize {
-		logger.Warn("video exceeds size limit, skipping",
-			"size_mb", sizeBytes/(1024*1024),
-			"max_size_mb", MaxVideoSize/(1024*1024),
-			"video_url", videoURL)
-		return "", fmt.Errorf("video size %d MB exceeds limit of %d MB", sizeBytes/(1024*1024), MaxVideoSize/(1024*1024))
+	summary, err := c.makeCompletionRequest(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to summarize page: %w", err)
 	}
 
-	// TODO: Implement video transcription
-	// Options:
-	// 1. Use OpenAI Whisper API directly (not through OpenRouter)
-	// 2. Use another transcription service
-	// 3. Extract frames and describe them as images
-
-	logger.Info("video transcription not yet implemented, skipping",
-		"video_url", videoURL)
-
-	return "", fmt.Errorf("video transcription not yet implemented")
+	span.SetAttributes(attribute.Int("summary_length", len(summary)))
+	return summary, nil
 }
 
 // makeCompletionRequest makes a chat completion request to OpenRouter using the SDK
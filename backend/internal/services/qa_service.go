@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -27,10 +28,11 @@ const noContentMessage = "Brak treści w wybranym zakresie dat. Spróbuj rozszer
 
 // QAService orchestrates Q&A creation workflow
 type QAService struct {
-	database   *sqlx.DB
-	postRepo   *repositories.PostRepository
-	qaRepo     *repositories.QARepository
-	llmService *LLMService
+	database     *sqlx.DB
+	postRepo     *repositories.PostRepository
+	qaRepo       *repositories.QARepository
+	feedListRepo *repositories.FeedListRepository
+	llmService   *LLMService
 }
 
 // NewQAService creates a new QAService instance
@@ -38,24 +40,30 @@ func NewQAService(
 	database *sqlx.DB,
 	postRepo *repositories.PostRepository,
 	qaRepo *repositories.QARepository,
+	feedListRepo *repositories.FeedListRepository,
 	llmService *LLMService,
 ) *QAService {
 	return &QAService{
-		database:   database,
-		postRepo:   postRepo,
-		qaRepo:     qaRepo,
-		llmService: llmService,
+		database:     database,
+		postRepo:     postRepo,
+		qaRepo:       qaRepo,
+		feedListRepo: feedListRepo,
+		llmService:   llmService,
 	}
 }
 
 // CreateQA creates a new Q&A interaction
 // Fetches posts, generates answer via LLM, persists Q&A record, and returns response
+// A non-nil listID scopes the question to posts ingested through that list; returns ErrFeedListNotFound
+// if the user did not register it. A non-nil source keeps only the posts of that source (see db.PostSource values)
 func (s *QAService) CreateQA(
 	ctx context.Context,
 	userID uuid.UUID,
 	question string,
 	dateFrom time.Time,
 	dateTo time.Time,
+	listID *int64,
+	source *string,
 ) (*dto.QADetailDTO, error) {
 	ctx, span := qaServiceTracer.Start(ctx, "CreateQA")
 	defer span.End()
@@ -66,13 +74,45 @@ func (s *QAService) CreateQA(
 		attribute.String("date_to", dateTo.Format(time.RFC3339)),
 	)
 
+	if listID != nil {
+		span.SetAttributes(attribute.Int64("list_id", *listID))
+
+		exists, err := s.feedListRepo.FeedListExists(ctx, userID, *listID)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to check feed list: %w", err)
+		}
+		if !exists {
+			return nil, ErrFeedListNotFound
+		}
+	}
+
+	sourceFilter := ""
+	if source != nil {
+		sourceFilter = *source
+		span.SetAttributes(attribute.String("source", sourceFilter))
+	}
+
 	// Step 1: Fetch posts from date range
-	posts, err := s.postRepo.GetPostsByDateRange(ctx, userID, dateFrom, dateTo)
+	posts, err := s.postRepo.GetPostsByDateRange(ctx, userID, dateFrom, dateTo, listID, sourceFilter)
 	if err != nil {
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to fetch posts: %w", err)
 	}
 
+	// Read threads whole, including parts outside the date range
+	posts, err = s.completeThreads(ctx, userID, posts)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// Give the LLM what it reads beside the post text
+	if err := s.attachPostContent(ctx, userID, question, posts); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	span.SetAttributes(attribute.Int("posts_found", len(posts)))
 
 	var answer string
@@ -122,6 +162,8 @@ func (s *QAService) CreateQA(
 		Answer:    answer,
 		DateFrom:  dateFrom,
 		DateTo:    dateTo,
+		ListID:    listID,
+		Source:    source,
 		CreatedAt: createdAt,
 	}
 
@@ -162,6 +204,8 @@ func (s *QAService) CreateQA(
 		Answer:    answer,
 		DateFrom:  dateFrom,
 		DateTo:    dateTo,
+		ListID:    listID,
+		Source:    source,
 		CreatedAt: createdAt,
 		Sources:   sourceDTOs,
 	}
@@ -169,7 +213,107 @@ func (s *QAService) CreateQA(
 	return response, nil
 }
 
+// completeThreads adds the missing parts of threads that have a post among the given posts
+// Returns the posts ordered chronologically
+func (s *QAService) completeThreads(ctx context.Context, userID uuid.UUID, posts []db.PostWithAuthor) ([]db.PostWithAuthor, error) {
+	type threadKey struct {
+		authorID       int64
+		conversationID int64
+	}
+
+	present := make(map[int64]bool, len(posts))
+	threads := make(map[threadKey]bool)
+	conversationIDs := make([]int64, 0)
+	for _, post := range posts {
+		present[post.XPostID] = true
+		if post.ConversationID == nil || *post.ConversationID <= 0 {
+			continue
+		}
+
+		key := threadKey{authorID: post.AuthorID, conversationID: *post.ConversationID}
+		if !threads[key] {
+			threads[key] = true
+			conversationIDs = append(conversationIDs, *post.ConversationID)
+		}
+	}
+
+	if len(conversationIDs) == 0 {
+		return posts, nil
+	}
+
+	threadPosts, err := s.postRepo.GetThreadPosts(ctx, userID, conversationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch thread posts: %w", err)
+	}
+
+	added := 0
+	for _, post := range threadPosts {
+		// Another author's thread in the same conversation was not retrieved for the question
+		if present[post.XPostID] || !threads[threadKey{authorID: post.AuthorID, conversationID: *post.ConversationID}] {
+			continue
+		}
+		present[post.XPostID] = true
+		posts = append(posts, post)
+		added++
+	}
+
+	if added > 0 {
+		slices.SortStableFunc(posts, comparePostsChronologically)
+	}
+
+	return posts, nil
+}
+
+// attachPostContent loads the media descriptions, X articles and linked pages of the given posts
+// Articles longer than ArticlePromptBudget are cut down to the chunks most relevant to the question
+func (s *QAService) attachPostContent(ctx context.Context, userID uuid.UUID, question string, posts []db.PostWithAuthor) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]int64, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.XPostID
+	}
+
+	media, err := s.postRepo.GetPostMedia(ctx, userID, postIDs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch post media: %w", err)
+	}
+
+	articles, err := s.postRepo.GetArticles(ctx, userID, postIDs, MaxArticleChunks)
+	if err != nil {
+		return fmt.Errorf("failed to fetch articles: %w", err)
+	}
+	for _, article := range articles {
+		selected := SelectArticleChunks(article.Chunks, question, ArticlePromptBudget)
+		if len(selected) == len(article.Chunks) {
+			continue
+		}
+		chunks := make([]string, len(selected))
+		for i, index := range selected {
+			chunks[i] = article.Chunks[index]
+		}
+		article.Chunks = chunks
+		article.ChunkIndexes = selected
+	}
+
+	links, err := s.postRepo.GetPostLinks(ctx, userID, postIDs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch post links: %w", err)
+	}
+
+	for i := range posts {
+		posts[i].Media = media[posts[i].XPostID]
+		posts[i].Article = articles[posts[i].XPostID]
+		posts[i].Links = links[posts[i].XPostID]
+	}
+
+	return nil
+}
+
 // buildSourceDTOs creates QASourceDTO objects from posts and selected source IDs
+// Sources from the same thread are merged into one source carrying the whole thread
 func (s *QAService) buildSourceDTOs(posts []db.PostWithAuthor, sourcePostIDs []int64) []dto.QASourceDTO {
 	if len(sourcePostIDs) == 0 {
 		return []dto.QASourceDTO{}
@@ -181,33 +325,57 @@ func (s *QAService) buildSourceDTOs(posts []db.PostWithAuthor, sourcePostIDs []i
 		sourceIDMap[id] = true
 	}
 
-	// Build DTOs for selected source posts
+	// Build DTOs for selected source posts, one per thread
 	sourceDTOs := make([]dto.QASourceDTO, 0, len(sourcePostIDs))
-	for _, post := range posts {
-		if sourceIDMap[post.XPostID] {
-			displayName := post.Handle
-			if post.DisplayName != nil && *post.DisplayName != "" {
-				displayName = *post.DisplayName
-			}
+	for _, thread := range GroupThreads(posts) {
+		i := slices.IndexFunc(thread, func(post db.PostWithAuthor) bool {
+			return sourceIDMap[post.XPostID]
+		})
+		if i < 0 {
+			continue
+		}
+		post := thread[i]
 
-			// Create text preview (first 200 chars)
-			textPreview := post.Text
-			if len(textPreview) > 200 {
-				textPreview = textPreview[:200] + "..."
-			}
+		displayName := post.Handle
+		if post.DisplayName != nil && *post.DisplayName != "" {
+			displayName = *post.DisplayName
+		}
 
-			sourceDTO := dto.QASourceDTO{
-				XPostID:           post.XPostID,
-				AuthorHandle:      post.Handle,
-				AuthorDisplayName: displayName,
-				PublishedAt:       post.PublishedAt,
-				URL:               post.URL,
-				TextPreview:       textPreview,
-				Text:              post.Text,
-			}
+		// Create text preview (first 200 chars)
+		textPreview := post.Text
+		if len(textPreview) > 200 {
+			textPreview = textPreview[:200] + "..."
+		}
+
+		sourceDTO := dto.QASourceDTO{
+			XPostID:           post.XPostID,
+			AuthorHandle:      post.Handle,
+			AuthorDisplayName: displayName,
+			PublishedAt:       post.PublishedAt,
+			URL:               post.URL,
+			TextPreview:       textPreview,
+			Text:              post.Text,
+			Edited:            post.EditedSeen,
+			Kind:              post.Kind,
+		}
+		if post.RepostedByHandle != nil {
+			sourceDTO.RepostedBy = *post.RepostedByHandle
+		}
 
-			sourceDTOs = append(sourceDTOs, sourceDTO)
+		if len(thread) > 1 {
+			sourceDTO.ConversationID = post.ConversationID
+			sourceDTO.Thread = make([]dto.QAThreadPostDTO, len(thread))
+			for j, part := range thread {
+				sourceDTO.Thread[j] = dto.QAThreadPostDTO{
+					XPostID:     part.XPostID,
+					PublishedAt: part.PublishedAt,
+					URL:         part.URL,
+					Text:        part.Text,
+				}
+			}
 		}
+
+		sourceDTOs = append(sourceDTOs, sourceDTO)
 	}
 
 	return sourceDTOs
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var searchSourceServiceTracer = otel.Tracer("search_source_service")
+
+// MaxSearchSources is the maximum number of search queries a user can save as feed sources
+const MaxSearchSources = 10
+
+// Common errors
+var (
+	ErrSearchSourceNotFound = errors.New("search source not found")
+	ErrSearchSourceExists   = errors.New("search source already saved")
+	ErrTooManySearchSources = errors.New("too many search sources")
+	ErrEmptySearchQuery     = errors.New("search query is empty")
+)
+
+// SearchSourceService handles business logic for saved search queries used as feed sources
+type SearchSourceService struct {
+	searchSourceRepo *repositories.SearchSourceRepository
+}
+
+// NewSearchSourceService creates a new SearchSourceService instance
+func NewSearchSourceService(searchSourceRepo *repositories.SearchSourceRepository) *SearchSourceService {
+	return &SearchSourceService{
+		searchSourceRepo: searchSourceRepo,
+	}
+}
+
+// GetSearchSources retrieves the search queries the user saved as feed sources
+func (s *SearchSourceService) GetSearchSources(ctx context.Context, userID uuid.UUID) (*dto.SearchSourcesResponseDTO, error) {
+	ctx, span := searchSourceServiceTracer.Start(ctx, "GetSearchSources")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	items, err := s.searchSourceRepo.GetSearchSources(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get search sources: %w", err)
+	}
+
+	dtoItems := make([]dto.SearchSourceDTO, len(items))
+	for i, item := range items {
+		dtoItems[i] = convertSearchSource(item.SearchSource, item.PostCount)
+	}
+
+	span.SetAttributes(attribute.Int("items_returned", len(dtoItems)))
+
+	return &dto.SearchSourcesResponseDTO{Items: dtoItems}, nil
+}
+
+// AddSearchSource saves a search query as a feed source
+// It first runs at the next ingestion run; returns ErrEmptySearchQuery, ErrSearchSourceExists or ErrTooManySearchSources
+func (s *SearchSourceService) AddSearchSource(ctx context.Context, userID uuid.UUID, cmd dto.AddSearchSourceCommand) (*dto.SearchSourceDTO, error) {
+	ctx, span := searchSourceServiceTracer.Start(ctx, "AddSearchSource")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID.String()))
+
+	query := strings.TrimSpace(cmd.Query)
+	if query == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	sources, err := s.searchSourceRepo.GetSearchSources(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to get search sources: %w", err)
+	}
+	if len(sources) >= MaxSearchSources {
+		return nil, ErrTooManySearchSources
+	}
+
+	var name *string
+	if trimmed := strings.TrimSpace(cmd.Name); trimmed != "" {
+		name = &trimmed
+	}
+
+	source, err := s.searchSourceRepo.InsertSearchSource(ctx, ulid.Make().String(), userID, query, name)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to add search source: %w", err)
+	}
+	if source == nil {
+		return nil, ErrSearchSourceExists
+	}
+
+	span.SetAttributes(attribute.String("search_source_id", source.ID))
+
+	result := convertSearchSource(*source, 0)
+	return &result, nil
+}
+
+// RemoveSearchSource deletes a saved search; posts it found are kept but no longer tagged with it
+// Returns ErrSearchSourceNotFound if the user has no search source with the ID
+func (s *SearchSourceService) RemoveSearchSource(ctx context.Context, userID uuid.UUID, id string) error {
+	ctx, span := searchSourceServiceTracer.Start(ctx, "RemoveSearchSource")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("search_source_id", id),
+	)
+
+	found, err := s.searchSourceRepo.DeleteSearchSource(ctx, userID, id)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to remove search source: %w", err)
+	}
+	if !found {
+		return ErrSearchSourceNotFound
+	}
+
+	return nil
+}
+
+// convertSearchSource converts a stored search source to its DTO
+func convertSearchSource(source db.SearchSource, postCount int) dto.SearchSourceDTO {
+	return dto.SearchSourceDTO{
+		ID:        source.ID,
+		Query:     source.Query,
+		Name:      convertStringPtr(source.Name),
+		PostCount: postCount,
+		CreatedAt: source.CreatedAt,
+		LastRunAt: source.LastRunAt,
+	}
+}
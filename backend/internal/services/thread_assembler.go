@@ -0,0 +1,203 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// MaxThreadsPerRun caps how many incomplete threads an ingest run fills through the thread context endpoint
+	MaxThreadsPerRun = 20
+
+	// MaxThreadContextPages caps the thread context pages read per thread
+	MaxThreadContextPages = 3
+)
+
+// assembleThreads fills the gaps of self-reply threads that received posts during the run
+// A thread has a gap when a self-reply's parent was not ingested, e.g. because it is older than
+// the backfill cutoff or the author's watermark. Missing tweets of the thread's author are stored
+// as regular posts. Failures are logged and recorded against the run; they do not fail it
+func (s *IngestService) assembleThreads(ctx context.Context, userID uuid.UUID, runID string, since time.Time, progress *repositories.IngestRunProgress) int {
+	ctx, span := ingestionServiceTracer.Start(ctx, "assembleThreads")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.String()),
+		attribute.String("run_id", runID),
+	)
+
+	threads, err := s.postRepo.ListIncompleteThreads(ctx, userID, since, MaxThreadsPerRun)
+	if err != nil {
+		span.RecordError(err)
+		logger.Warn("failed to list incomplete threads, skipping thread assembly",
+			"error", err,
+			"user_id", userID)
+		return 0
+	}
+
+	inserted := 0
+	for _, thread := range threads {
+		if ctx.Err() != nil {
+			break
+		}
+
+		threadInserted, hits, retries, err := s.fillThread(ctx, userID, thread)
+		inserted += threadInserted
+		progress.TweetsCount += threadInserted
+		progress.RateLimitHits += hits
+		progress.Retried += retries
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			logger.Warn("failed to fill thread, continuing with others",
+				"error", err,
+				"conversation_id", thread.ConversationID,
+				"user_id", userID)
+			s.recordRunError(ctx, runID, userID, "tweets", "",
+				fmt.Errorf("thread %d: %w", thread.ConversationID, err))
+		}
+	}
+
+	if ctx.Err() == nil {
+		s.saveProgress(ctx, runID, progress)
+	}
+
+	span.SetAttributes(
+		attribute.Int("incomplete_threads", len(threads)),
+		attribute.Int("tweets_inserted", inserted),
+	)
+
+	logger.Info("thread assembly completed",
+		"user_id", userID,
+		"incomplete_threads", len(threads),
+		"tweets_inserted", inserted)
+
+	return inserted
+}
+
+// fillThread reads the thread context of a thread's latest post and stores the author's tweets missing from it
+// Returns the number of tweets stored, rate limit hits and retries
+func (s *IngestService) fillThread(ctx context.Context, userID uuid.UUID, thread db.Thread) (int, int, int, error) {
+	ctx, span := ingestionServiceTracer.Start(ctx, "fillThread")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int64("conversation_id", thread.ConversationID),
+		attribute.Int64("author_id", thread.AuthorID),
+		attribute.Int("post_count", thread.PostCount),
+	)
+
+	if len(thread.PostIDs) == 0 {
+		return 0, 0, 0, nil
+	}
+
+	// The context of the latest post holds all of its ancestors
+	tweetID := strconv.FormatInt(thread.PostIDs[len(thread.PostIDs)-1], 10)
+
+	inserted := 0
+	rateLimitHits := 0
+	retried := 0
+	cursor := ""
+	for page := 1; page <= MaxThreadContextPages; page++ {
+		resp, hits, retries, err := withRetry(ctx, "thread context", "", func() (*ThreadContextResponse, error) {
+			return s.twitterClient.GetTweetThreadContext(ctx, tweetID, cursor)
+		})
+		rateLimitHits += hits
+		retried += retries
+		if err != nil {
+			span.RecordError(err)
+			return inserted, rateLimitHits, retried, fmt.Errorf("failed to get thread context: %w", err)
+		}
+
+		for i := range resp.Tweets {
+			tweet := &resp.Tweets[i]
+			if !belongsToThread(*tweet, thread) {
+				continue
+			}
+			// Parts are not tagged with lists; list-scoped questions complete threads from the tagged parts
+			if s.processSingleTweet(ctx, userID, tweet.Author.UserName, postTags{}, tweet) {
+				inserted++
+			}
+		}
+
+		if !resp.HasNextPage || resp.NextCursor == "" {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+
+	span.SetAttributes(attribute.Int("tweets_inserted", inserted))
+
+	return inserted, rateLimitHits, retried, nil
+}
+
+// belongsToThread checks if a tweet from a thread context is part of the author's own thread
+// The head of a thread may reply to another author or quote a tweet; it is kept so the thread is whole
+func belongsToThread(tweet TweetData, thread db.Thread) bool {
+	if tweet.RetweetedTweet != nil {
+		return false
+	}
+
+	authorID, err := strconv.ParseInt(tweet.Author.ID, 10, 64)
+	if err != nil || authorID != thread.AuthorID {
+		return false
+	}
+
+	conversationID, err := strconv.ParseInt(tweet.ConversationId, 10, 64)
+	return err == nil && conversationID == thread.ConversationID
+}
+
+// GroupThreads groups posts into threads: posts of the same author in the same conversation
+// Each thread is ordered chronologically, and threads are ordered by their first post.
+// A post that is not part of a thread forms a thread of one
+func GroupThreads(posts []db.PostWithAuthor) [][]db.PostWithAuthor {
+	type threadKey struct {
+		authorID       int64
+		conversationID int64
+	}
+
+	threads := make([][]db.PostWithAuthor, 0, len(posts))
+	index := make(map[threadKey]int)
+	for _, post := range posts {
+		if post.ConversationID == nil || *post.ConversationID <= 0 {
+			threads = append(threads, []db.PostWithAuthor{post})
+			continue
+		}
+
+		key := threadKey{authorID: post.AuthorID, conversationID: *post.ConversationID}
+		if i, ok := index[key]; ok {
+			threads[i] = append(threads[i], post)
+			continue
+		}
+		index[key] = len(threads)
+		threads = append(threads, []db.PostWithAuthor{post})
+	}
+
+	for _, thread := range threads {
+		slices.SortStableFunc(thread, comparePostsChronologically)
+	}
+	slices.SortStableFunc(threads, func(a, b []db.PostWithAuthor) int {
+		return comparePostsChronologically(a[0], b[0])
+	})
+
+	return threads
+}
+
+// comparePostsChronologically orders posts by publication time, then by ID
+func comparePostsChronologically(a, b db.PostWithAuthor) int {
+	if c := a.PublishedAt.Compare(b.PublishedAt); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.XPostID, b.XPostID)
+}
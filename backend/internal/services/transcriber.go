@@ -0,0 +1,231 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var transcriberTracer = otel.Tracer("transcriber")
+
+const (
+	// MaxVideoSize is the maximum video size to process (25 MB, the upload limit of Whisper APIs)
+	MaxVideoSize = 25 * 1024 * 1024
+
+	// MaxVideoDuration is the maximum video duration in seconds (90 seconds)
+	MaxVideoDuration = 90
+
+	// DefaultTranscriptionModel is the model requested from a Whisper-compatible API
+	DefaultTranscriptionModel = "whisper-1"
+
+	// VideoDownloadTimeout bounds downloading a video before transcription
+	VideoDownloadTimeout = 60 * time.Second
+)
+
+// ErrMediaExceedsLimit is returned for media over the duration or size limits; such media is skipped
+var ErrMediaExceedsLimit = errors.New("media exceeds limit")
+
+// Transcriber turns the audio of a video into text
+type Transcriber interface {
+	// Transcribe returns the transcript of the video at videoURL
+	// durationSeconds is the duration reported by X; 0 if unknown
+	Transcribe(ctx context.Context, videoURL string, durationSeconds int) (string, error)
+
+	// Model names the model making the transcripts; recorded with each transcript
+	Model() string
+}
+
+// WhisperTranscriber transcribes videos through a Whisper-compatible /audio/transcriptions endpoint
+type WhisperTranscriber struct {
+	BaseURL        string
+	apiKey         string
+	model          string
+	apiClient      *http.Client
+	downloadClient *http.Client
+}
+
+// NewWhisperTranscriber creates a transcriber for the API at baseURL (e.g. https://api.openai.com/v1)
+// A nil httpClient uses a client with a long timeout for the API and NewSafeHTTPClient for downloads;
+// a given httpClient is used for both
+func NewWhisperTranscriber(baseURL string, apiKey string, model string, httpClient *http.Client) *WhisperTranscriber {
+	if model == "" {
+		model = DefaultTranscriptionModel
+	}
+
+	apiClient, downloadClient := httpClient, httpClient
+	if httpClient == nil {
+		apiClient = &http.Client{
+			Timeout: 120 * time.Second, // Transcribing a long video takes a while
+		}
+		downloadClient = NewSafeHTTPClient(VideoDownloadTimeout)
+	}
+
+	return &WhisperTranscriber{
+		BaseURL:        strings.TrimRight(baseURL, "/"),
+		apiKey:         apiKey,
+		model:          model,
+		apiClient:      apiClient,
+		downloadClient: downloadClient,
+	}
+}
+
+// Model returns the transcription model requested from the API
+func (t *WhisperTranscriber) Model() string {
+	return t.model
+}
+
+// whisperResponse represents the JSON response of /audio/transcriptions
+type whisperResponse struct {
+	Text  string `json:"text"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Transcribe downloads a video and sends it to the transcription API
+// Videos longer than MaxVideoDuration or larger than MaxVideoSize return ErrMediaExceedsLimit
+func (t *WhisperTranscriber) Transcribe(ctx context.Context, videoURL string, durationSeconds int) (string, error) {
+	ctx, span := transcriberTracer.Start(ctx, "Transcribe")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("video_url", videoURL),
+		attribute.Int("duration_seconds", durationSeconds),
+	)
+
+	if durationSeconds > MaxVideoDuration {
+		return "", fmt.Errorf("video duration %ds exceeds limit of %ds: %w", durationSeconds, MaxVideoDuration, ErrMediaExceedsLimit)
+	}
+
+	video, err := t.download(ctx, videoURL)
+	if err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+
+	span.SetAttributes(attribute.Int("size_bytes", len(video)))
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("model", t.model); err != nil {
+		return "", fmt.Errorf("failed to build request: %w", err)
+	}
+	if err := writer.WriteField("response_format", "json"); err != nil {
+		return "", fmt.Errorf("failed to build request: %w", err)
+	}
+	part, err := writer.CreateFormFile("file", videoFileName(videoURL))
+	if err != nil {
+		return "", fmt.Errorf("failed to build request: %w", err)
+	}
+	if _, err := part.Write(video); err != nil {
+		return "", fmt.Errorf("failed to build request: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", t.BaseURL+"/audio/transcriptions", &body)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if t.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	}
+
+	resp, err := t.apiClient.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			span.RecordError(err)
+		}
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var result whisperResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		span.RecordError(err)
+		return "", fmt.Errorf("failed to unmarshal response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		message := ""
+		if result.Error != nil {
+			message = result.Error.Message
+		}
+		err := fmt.Errorf("transcription API returned status %d: %s", resp.StatusCode, message)
+		span.RecordError(err)
+		return "", err
+	}
+
+	transcript := strings.TrimSpace(result.Text)
+	span.SetAttributes(attribute.Int("transcript_length", len(transcript)))
+
+	return transcript, nil
+}
+
+// download reads a video, stopping as soon as it is larger than MaxVideoSize
+func (t *WhisperTranscriber) download(ctx context.Context, videoURL string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, VideoDownloadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", videoURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+	req.Header.Set("User-Agent", "AskYourFeed/1.0")
+
+	resp, err := t.downloadClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download video: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("video download returned status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > MaxVideoSize {
+		return nil, fmt.Errorf("video size %d MB exceeds limit of %d MB: %w", resp.ContentLength/(1024*1024), MaxVideoSize/(1024*1024), ErrMediaExceedsLimit)
+	}
+
+	// The declared length may be missing or wrong, so the bytes read are counted too
+	video, err := io.ReadAll(io.LimitReader(resp.Body, MaxVideoSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download video: %w", err)
+	}
+	if len(video) > MaxVideoSize {
+		return nil, fmt.Errorf("video size exceeds limit of %d MB: %w", MaxVideoSize/(1024*1024), ErrMediaExceedsLimit)
+	}
+
+	return video, nil
+}
+
+// videoFileName names the uploaded file after the video URL; the API infers the format from the extension
+func videoFileName(videoURL string) string {
+	name := path.Base(strings.SplitN(videoURL, "?", 2)[0])
+	if path.Ext(name) == "" {
+		return "video.mp4"
+	}
+	return name
+}
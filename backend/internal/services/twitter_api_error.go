@@ -0,0 +1,93 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxAPIErrorMessageLength limits how much of a non-JSON error body is kept in APIError.Message
+const maxAPIErrorMessageLength = 512
+
+// APIError is returned by TwitterClient when twitterapi.io responds with a non-200 status
+type APIError struct {
+	StatusCode int
+	Message    string        // Error message reported by twitterapi.io, or the raw response body
+	RetryAfter time.Duration // Wait hinted by Retry-After or x-rate-limit-reset headers; 0 if none
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("API error: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("API error: %d, message: %s", e.StatusCode, e.Message)
+}
+
+// IsRateLimited reports whether the request was rejected with 429 Too Many Requests
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsRetryable reports whether the request may succeed when retried (429 and 5xx)
+func (e *APIError) IsRetryable() bool {
+	return e.IsRateLimited() || e.StatusCode >= http.StatusInternalServerError
+}
+
+// newAPIError builds an APIError from a non-200 response
+func newAPIError(resp *http.Response, body []byte, now time.Time) *APIError {
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    parseAPIErrorMessage(body),
+		RetryAfter: parseRetryAfter(resp.Header, now),
+	}
+}
+
+// parseAPIErrorMessage extracts the error message from a twitterapi.io error body
+// Falls back to the (truncated) raw body when it is not JSON or carries no message
+func parseAPIErrorMessage(body []byte) string {
+	var payload struct {
+		Msg     string `json:"msg"`
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		for _, msg := range []string{payload.Msg, payload.Message, payload.Error} {
+			if msg != "" {
+				return msg
+			}
+		}
+	}
+
+	msg := strings.TrimSpace(string(body))
+	if len(msg) > maxAPIErrorMessageLength {
+		msg = msg[:maxAPIErrorMessageLength] + "..."
+	}
+	return msg
+}
+
+// parseRetryAfter reads the wait hinted by the response headers
+// Retry-After may be delay-seconds or an HTTP date; x-rate-limit-reset is a unix timestamp
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(value); err == nil && at.After(now) {
+			return at.Sub(now)
+		}
+	}
+
+	if value := header.Get("x-rate-limit-reset"); value != "" {
+		if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+			if at := time.Unix(unix, 0); at.After(now) {
+				return at.Sub(now)
+			}
+		}
+	}
+
+	return 0
+}
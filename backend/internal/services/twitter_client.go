@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sopeal/AskYourFeed/internal/db"
 	"github.com/sopeal/AskYourFeed/internal/dto"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -18,11 +19,28 @@ import (
 
 var twitterClientTracer = otel.Tracer("twitter_client")
 
+const (
+	// TwitterAPIRateLimit is the request rate allowed by twitterapi.io (requests per second)
+	TwitterAPIRateLimit = 200
+
+	// TwitterAPIBurst is the number of requests that may be sent at once before the rate limit applies
+	TwitterAPIBurst = 20
+
+	// TwitterAPIRateLimitPause is how long all requests are held back after a 429 response
+	TwitterAPIRateLimitPause = time.Second
+
+	// TwitterAPIMaxRateLimitPause caps the pause taken from Retry-After hints
+	TwitterAPIMaxRateLimitPause = time.Minute
+)
+
 // TwitterClient handles communication with twitterapi.io
+// All requests go through a shared token-bucket limiter, so concurrent ingest workers
+// in one process stay within the API rate limit together
 type TwitterClient struct {
 	apiKey     string
 	BaseURL    string // Exported for testing
 	httpClient *http.Client
+	limiter    *RateLimiter
 }
 
 // NewTwitterClient creates a new Twitter API client
@@ -36,6 +54,7 @@ func NewTwitterClient(apiKey string, httpClient *http.Client) *TwitterClient {
 		apiKey:     apiKey,
 		BaseURL:    "https://api.twitterapi.io",
 		httpClient: httpClient,
+		limiter:    NewRateLimiter(TwitterAPIRateLimit, TwitterAPIBurst),
 	}
 }
 
@@ -74,6 +93,15 @@ type FollowingResponse struct {
 	Status      string     `json:"status"`
 }
 
+// ListMembersResponse represents the response from list members endpoint
+type ListMembersResponse struct {
+	Members     []UserData `json:"members"`
+	HasNextPage bool       `json:"has_next_page"`
+	NextCursor  string     `json:"next_cursor"`
+	Status      string     `json:"status"`
+	Message     string     `json:"msg"`
+}
+
 // TweetResponse represents the response from tweet endpoints
 type TweetResponse struct {
 	Data        TweetDataWrapper `json:"data"`
@@ -83,6 +111,60 @@ type TweetResponse struct {
 	Tweets      []TweetData      `json:"-"` // Populated from Data.Tweets after unmarshaling
 }
 
+// SearchTweetsResponse represents the response from the advanced search endpoint
+type SearchTweetsResponse struct {
+	Tweets      []TweetData `json:"tweets"`
+	HasNextPage bool        `json:"has_next_page"`
+	NextCursor  string      `json:"next_cursor"`
+	Status      string      `json:"status"`
+	Message     string      `json:"msg"`
+}
+
+// MentionsResponse represents the response from the user mentions endpoint
+type MentionsResponse struct {
+	Tweets      []TweetData `json:"tweets"`
+	HasNextPage bool        `json:"has_next_page"`
+	NextCursor  string      `json:"next_cursor"`
+	Status      string      `json:"status"`
+	Message     string      `json:"message"`
+}
+
+// ArticleResponse represents the response from the article endpoint
+type ArticleResponse struct {
+	Article ArticleData `json:"article"`
+	Status  string      `json:"status"`
+	Message string      `json:"message"`
+}
+
+// ArticleData represents a long-form X article
+type ArticleData struct {
+	Title       string           `json:"title"`
+	PreviewText string           `json:"preview_text"`
+	Contents    []ArticleContent `json:"contents"`
+}
+
+// ArticleContent represents a block of an article body, usually a paragraph
+type ArticleContent struct {
+	Text string `json:"text"`
+}
+
+// TweetsByIDsResponse represents the response from the batch tweet lookup endpoint
+type TweetsByIDsResponse struct {
+	Tweets  []TweetData `json:"tweets"`
+	Status  string      `json:"status"`
+	Message string      `json:"message"`
+}
+
+// ThreadContextResponse represents the response from the tweet thread context endpoint
+// Tweets holds the conversation around the requested tweet, including its ancestors
+type ThreadContextResponse struct {
+	Tweets      []TweetData `json:"tweets"`
+	HasNextPage bool        `json:"has_next_page"`
+	NextCursor  string      `json:"next_cursor"`
+	Status      string      `json:"status"`
+	Message     string      `json:"message"`
+}
+
 // TweetDataWrapper wraps the tweets array in the data field
 type TweetDataWrapper struct {
 	Tweets []TweetData `json:"tweets"`
@@ -90,26 +172,40 @@ type TweetDataWrapper struct {
 
 // TweetData represents tweet information
 type TweetData struct {
-	Type           string      `json:"type"`
-	ID             string      `json:"id"`
-	URL            string      `json:"url"`
-	Text           string      `json:"text"`
-	Source         string      `json:"source"`
-	RetweetCount   int         `json:"retweetCount"`
-	ReplyCount     int         `json:"replyCount"`
-	LikeCount      int         `json:"likeCount"`
-	QuoteCount     int         `json:"quoteCount"`
-	ViewCount      int         `json:"viewCount"`
-	BookmarkCount  int         `json:"bookmarkCount"`
-	CreatedAt      string      `json:"createdAt"`
-	Lang           string      `json:"lang"`
-	IsReply        bool        `json:"isReply"`
-	InReplyToId    string      `json:"inReplyToId"`
-	ConversationId string      `json:"conversationId"`
-	Author         UserData    `json:"author"`
-	QuotedTweet    *TweetData  `json:"quoted_tweet,omitempty"`
-	RetweetedTweet *TweetData  `json:"retweeted_tweet,omitempty"`
-	Media          *MediaData  `json:"media,omitempty"`
+	Type            string      `json:"type"`
+	ID              string      `json:"id"`
+	URL             string      `json:"url"`
+	Text            string      `json:"text"`
+	Source          string      `json:"source"`
+	RetweetCount    int         `json:"retweetCount"`
+	ReplyCount      int         `json:"replyCount"`
+	LikeCount       int         `json:"likeCount"`
+	QuoteCount      int         `json:"quoteCount"`
+	ViewCount       int         `json:"viewCount"`
+	BookmarkCount   int         `json:"bookmarkCount"`
+	CreatedAt       string      `json:"createdAt"`
+	Lang            string      `json:"lang"`
+	IsReply         bool        `json:"isReply"`
+	InReplyToId     string      `json:"inReplyToId"`
+	InReplyToUserId string      `json:"inReplyToUserId"`
+	ConversationId  string      `json:"conversationId"`
+	Author          UserData    `json:"author"`
+	QuotedTweet     *TweetData  `json:"quoted_tweet,omitempty"`
+	RetweetedTweet  *TweetData  `json:"retweeted_tweet,omitempty"`
+	Media           *MediaData  `json:"media,omitempty"`
+	Entities        *EntityData `json:"entities,omitempty"`
+}
+
+// EntityData represents entities parsed from a tweet's text
+type EntityData struct {
+	URLs []URLEntity `json:"urls,omitempty"`
+}
+
+// URLEntity represents a link in a tweet; url is the t.co short link
+type URLEntity struct {
+	URL         string `json:"url"`
+	ExpandedURL string `json:"expanded_url"`
+	DisplayURL  string `json:"display_url"`
 }
 
 // MediaData represents media attached to a tweet
@@ -136,6 +232,7 @@ type VideoData struct {
 }
 
 // makeRequest performs HTTP request with authentication and error handling
+// Non-200 responses are returned as *APIError
 func (c *TwitterClient) makeRequest(ctx context.Context, method, endpoint string, params url.Values) ([]byte, error) {
 	ctx, span := twitterClientTracer.Start(ctx, "makeRequest")
 	defer span.End()
@@ -150,6 +247,12 @@ func (c *TwitterClient) makeRequest(ctx context.Context, method, endpoint string
 		reqURL += "?" + params.Encode()
 	}
 
+	// Wait for the shared rate limiter before sending
+	if err := c.limiter.Wait(ctx); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("rate limiter wait aborted: %w", err)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
 	if err != nil {
 		span.RecordError(err)
@@ -177,8 +280,14 @@ func (c *TwitterClient) makeRequest(ctx context.Context, method, endpoint string
 	}
 
 	if resp.StatusCode != http.StatusOK {
+		apiErr := newAPIError(resp, body, time.Now())
 		span.SetAttributes(attribute.Int("status_code", resp.StatusCode))
-		return nil, fmt.Errorf("API error: %d, body: %s", resp.StatusCode, string(body))
+		span.RecordError(apiErr)
+		if apiErr.IsRateLimited() {
+			// Hold back every caller, not only the one that was rate limited
+			c.limiter.Pause(min(max(apiErr.RetryAfter, TwitterAPIRateLimitPause), TwitterAPIMaxRateLimitPause))
+		}
+		return nil, apiErr
 	}
 
 	span.SetAttributes(attribute.Int("response_size", len(body)))
@@ -250,6 +359,162 @@ func (c *TwitterClient) GetUserFollowings(ctx context.Context, username string,
 	return &resp, nil
 }
 
+// GetListMembers retrieves a page of the members of an X list
+func (c *TwitterClient) GetListMembers(ctx context.Context, listID string, cursor string) (*ListMembersResponse, error) {
+	ctx, span := twitterClientTracer.Start(ctx, "GetListMembers")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("list_id", listID),
+		attribute.String("cursor", cursor),
+	)
+
+	params := url.Values{}
+	params.Set("listId", listID)
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+
+	body, err := c.makeRequest(ctx, "GET", "/twitter/list/members", params)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	var resp ListMembersResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if resp.Status != "" && resp.Status != "success" {
+		return nil, fmt.Errorf("API returned error status: %s, msg: %s", resp.Status, resp.Message)
+	}
+
+	span.SetAttributes(
+		attribute.Int("members_count", len(resp.Members)),
+		attribute.Bool("has_next_page", resp.HasNextPage),
+	)
+
+	return &resp, nil
+}
+
+// SearchTweets retrieves a page of tweets matching an advanced search query, newest first
+func (c *TwitterClient) SearchTweets(ctx context.Context, query string, cursor string) (*SearchTweetsResponse, error) {
+	ctx, span := twitterClientTracer.Start(ctx, "SearchTweets")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("query", query),
+		attribute.String("cursor", cursor),
+	)
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("queryType", "Latest")
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+
+	body, err := c.makeRequest(ctx, "GET", "/twitter/tweet/advanced_search", params)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	var resp SearchTweetsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if resp.Status != "" && resp.Status != "success" {
+		return nil, fmt.Errorf("API returned error status: %s, msg: %s", resp.Status, resp.Message)
+	}
+
+	span.SetAttributes(
+		attribute.Int("tweets_count", len(resp.Tweets)),
+		attribute.Bool("has_next_page", resp.HasNextPage),
+	)
+
+	return &resp, nil
+}
+
+// GetUserMentions retrieves a page of tweets mentioning a user, newest first
+// A non-zero since limits the results to tweets published after it
+func (c *TwitterClient) GetUserMentions(ctx context.Context, username string, since time.Time, cursor string) (*MentionsResponse, error) {
+	ctx, span := twitterClientTracer.Start(ctx, "GetUserMentions")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("username", username),
+		attribute.String("cursor", cursor),
+	)
+
+	params := url.Values{}
+	params.Set("userName", username)
+	if !since.IsZero() {
+		params.Set("sinceTime", strconv.FormatInt(since.Unix(), 10))
+	}
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+
+	body, err := c.makeRequest(ctx, "GET", "/twitter/user/mentions", params)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	var resp MentionsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if resp.Status != "" && resp.Status != "success" {
+		return nil, fmt.Errorf("API returned error status: %s, msg: %s", resp.Status, resp.Message)
+	}
+
+	span.SetAttributes(
+		attribute.Int("tweets_count", len(resp.Tweets)),
+		attribute.Bool("has_next_page", resp.HasNextPage),
+	)
+
+	return &resp, nil
+}
+
+// GetArticle retrieves the long-form X article published with a tweet
+func (c *TwitterClient) GetArticle(ctx context.Context, tweetID string) (*ArticleData, error) {
+	ctx, span := twitterClientTracer.Start(ctx, "GetArticle")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tweet_id", tweetID))
+
+	params := url.Values{}
+	params.Set("tweet_id", tweetID)
+
+	body, err := c.makeRequest(ctx, "GET", "/twitter/article", params)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	var resp ArticleResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if resp.Status != "" && resp.Status != "success" {
+		return nil, fmt.Errorf("API returned error status: %s, msg: %s", resp.Status, resp.Message)
+	}
+
+	span.SetAttributes(attribute.Int("contents_count", len(resp.Article.Contents)))
+
+	return &resp.Article, nil
+}
+
 // GetUserTweets retrieves recent tweets from a user
 func (c *TwitterClient) GetUserTweets(ctx context.Context, username string, cursor string) (*TweetResponse, error) {
 	ctx, span := twitterClientTracer.Start(ctx, "GetUserTweets")
@@ -289,6 +554,78 @@ func (c *TwitterClient) GetUserTweets(ctx context.Context, username string, curs
 	return &resp, nil
 }
 
+// GetTweetsByIDs retrieves tweets by their IDs in a single request
+// Tweets that no longer exist are missing from the response
+func (c *TwitterClient) GetTweetsByIDs(ctx context.Context, tweetIDs []string) (*TweetsByIDsResponse, error) {
+	ctx, span := twitterClientTracer.Start(ctx, "GetTweetsByIDs")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("requested_count", len(tweetIDs)))
+
+	params := url.Values{}
+	params.Set("tweet_ids", strings.Join(tweetIDs, ","))
+
+	body, err := c.makeRequest(ctx, "GET", "/twitter/tweets", params)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	var resp TweetsByIDsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if resp.Status != "" && resp.Status != "success" {
+		return nil, fmt.Errorf("API returned error status: %s, msg: %s", resp.Status, resp.Message)
+	}
+
+	span.SetAttributes(attribute.Int("tweets_count", len(resp.Tweets)))
+
+	return &resp, nil
+}
+
+// GetTweetThreadContext retrieves the thread a tweet belongs to, one page at a time
+func (c *TwitterClient) GetTweetThreadContext(ctx context.Context, tweetID string, cursor string) (*ThreadContextResponse, error) {
+	ctx, span := twitterClientTracer.Start(ctx, "GetTweetThreadContext")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tweet_id", tweetID),
+		attribute.String("cursor", cursor),
+	)
+
+	params := url.Values{}
+	params.Set("tweetId", tweetID)
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+
+	body, err := c.makeRequest(ctx, "GET", "/twitter/tweet/thread_context", params)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	var resp ThreadContextResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if resp.Status != "" && resp.Status != "success" {
+		return nil, fmt.Errorf("API returned error status: %s, msg: %s", resp.Status, resp.Message)
+	}
+
+	span.SetAttributes(
+		attribute.Int("tweets_count", len(resp.Tweets)),
+		attribute.Bool("has_next_page", resp.HasNextPage),
+	)
+
+	return &resp, nil
+}
+
 // ConvertToDTO converts TweetData to TweetDTO
 func (c *TwitterClient) ConvertToDTO(tweet TweetData) *dto.TweetDTO {
 	// Parse created_at timestamp
@@ -317,6 +654,31 @@ func (c *TwitterClient) ConvertToDTO(tweet TweetData) *dto.TweetDTO {
 		conversationID = 0
 	}
 
+	// Only a self-reply records its parent; replies to other authors start a thread of their own
+	var inReplyToID int64
+	if tweet.IsReply && isSelfReply(tweet) {
+		inReplyToID, err = strconv.ParseInt(tweet.InReplyToId, 10, 64)
+		if err != nil {
+			inReplyToID = 0
+		}
+	}
+
+	// Classify the tweet; a retweet is stored as the retweeted tweet under its original author
+	kind := db.PostKindOriginal
+	var quotedPostID int64
+	switch {
+	case tweet.RetweetedTweet != nil:
+		kind = db.PostKindRetweet
+	case tweet.QuotedTweet != nil:
+		kind = db.PostKindQuote
+		quotedPostID, err = strconv.ParseInt(tweet.QuotedTweet.ID, 10, 64)
+		if err != nil {
+			quotedPostID = 0
+		}
+	case tweet.IsReply && !isSelfReply(tweet):
+		kind = db.PostKindReply
+	}
+
 	// Normalize URL to ensure it matches the database constraint
 	// The constraint requires: ^https?://(x|twitter)\.com/.+/status/\d+
 	// Remove query parameters and fragments that might be present in the API response
@@ -333,9 +695,19 @@ func (c *TwitterClient) ConvertToDTO(tweet TweetData) *dto.TweetDTO {
 		ID:             tweetID,
 		AuthorID:       authorID,
 		Text:           tweet.Text,
+		RawText:        tweet.Text,
 		URL:            tweetURL,
 		PublishedAt:    createdAt,
 		ConversationID: conversationID,
+		InReplyToID:    inReplyToID,
+		Kind:           kind,
+		QuotedPostID:   quotedPostID,
+		LikeCount:      int64(max(tweet.LikeCount, 0)),
+		RetweetCount:   int64(max(tweet.RetweetCount, 0)),
+		ReplyCount:     int64(max(tweet.ReplyCount, 0)),
+		QuoteCount:     int64(max(tweet.QuoteCount, 0)),
+		ViewCount:      int64(max(tweet.ViewCount, 0)),
+		BookmarkCount:  int64(max(tweet.BookmarkCount, 0)),
 	}
 }
 
@@ -378,14 +750,9 @@ func (c *TwitterClient) normalizeTwitterURL(rawURL, authorHandle, tweetID string
 }
 
 // ConvertUserToDTO converts UserData to UserDTO
+// LastSeenAt is left unset: the account's creation date says nothing about its activity,
+// which is only known once a tweet of the author is ingested
 func (c *TwitterClient) ConvertUserToDTO(user UserData) *dto.UserDTO {
-	// Parse created_at timestamp
-	// Twitter uses RubyDate format: "Mon Jan 02 15:04:05 -0700 2006"
-	createdAt, err := time.Parse(time.RubyDate, user.CreatedAt)
-	if err != nil {
-		createdAt = time.Now()
-	}
-
 	// Convert user ID to int64
 	userID, err := strconv.ParseInt(user.ID, 10, 64)
 	if err != nil {
@@ -396,7 +763,6 @@ func (c *TwitterClient) ConvertUserToDTO(user UserData) *dto.UserDTO {
 		ID:          userID,
 		Handle:      user.UserName,
 		DisplayName: user.Name,
-		LastSeenAt:  createdAt,
 	}
 }
 
@@ -419,18 +785,88 @@ func (c *TwitterClient) IsOriginalPost(tweet TweetData) bool {
 	}
 	
 	// Allow self-replies (isReply == true AND inReplyToUserId == author.id)
-	// Parse author ID from string
+	return isSelfReply(tweet)
+}
+
+// isSelfReply checks if a tweet replies to a tweet of its own author
+func isSelfReply(tweet TweetData) bool {
 	authorID, err := strconv.ParseInt(tweet.Author.ID, 10, 64)
 	if err != nil {
 		return false
 	}
-	
-	// Parse inReplyToId from string
-	inReplyToUserID, err := strconv.ParseInt(tweet.InReplyToId, 10, 64)
+
+	inReplyToUserID, err := strconv.ParseInt(tweet.InReplyToUserId, 10, 64)
 	if err != nil {
 		return false
 	}
-	
-	// Check if it's a self-reply
+
 	return inReplyToUserID == authorID
 }
+
+// articleLinkMarkers identify links to long-form X articles
+var articleLinkMarkers = []string{"x.com/i/article/", "twitter.com/i/article/"}
+
+// HasArticleLink checks if a tweet links to an X article
+// The article link is usually only present as an expanded URL entity, the text holds the t.co link
+func HasArticleLink(tweet TweetData) bool {
+	links := []string{tweet.Text}
+	if tweet.Entities != nil {
+		for _, entity := range tweet.Entities.URLs {
+			links = append(links, entity.ExpandedURL)
+		}
+	}
+
+	for _, link := range links {
+		for _, marker := range articleLinkMarkers {
+			if strings.Contains(link, marker) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// internalLinkHosts are hosts of links that point back to X rather than to an outside page
+var internalLinkHosts = []string{"x.com", "twitter.com", "t.co"}
+
+// OutboundLinks returns the distinct links of a tweet to pages outside X, in order of appearance
+// Expanded URL entities are used; links written out in the text are added when they are not t.co links
+func OutboundLinks(tweet TweetData) []string {
+	candidates := []string{}
+	if tweet.Entities != nil {
+		for _, entity := range tweet.Entities.URLs {
+			candidates = append(candidates, entity.ExpandedURL)
+		}
+	}
+	for _, field := range strings.Fields(tweet.Text) {
+		if strings.HasPrefix(field, "http://") || strings.HasPrefix(field, "https://") {
+			candidates = append(candidates, field)
+		}
+	}
+
+	seen := make(map[string]bool)
+	links := []string{}
+	for _, candidate := range candidates {
+		parsed, err := url.Parse(candidate)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+			continue
+		}
+		if isInternalLinkHost(parsed.Hostname()) || seen[parsed.String()] {
+			continue
+		}
+		seen[parsed.String()] = true
+		links = append(links, parsed.String())
+	}
+	return links
+}
+
+// isInternalLinkHost checks if a host belongs to X, including subdomains such as mobile.twitter.com
+func isInternalLinkHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, internal := range internalLinkHosts {
+		if host == internal || strings.HasSuffix(host, "."+internal) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// TestGetArticle tests fetching the X article published with a tweet
+func TestGetArticle(t *testing.T) {
+	var requestedTweetID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/twitter/article" {
+			t.Errorf("Expected path /twitter/article, got %s", r.URL.Path)
+		}
+		requestedTweetID = r.URL.Query().Get("tweet_id")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"article": {
+				"title": "Why rates stay high",
+				"preview_text": "Inflation is sticky",
+				"contents": [
+					{"text": "Inflation is sticky."},
+					{"text": "  "},
+					{"text": "Wages keep rising."}
+				]
+			},
+			"status": "success",
+			"message": ""
+		}`))
+	}))
+	defer server.Close()
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+
+	article, err := client.GetArticle(context.Background(), "9201")
+	if err != nil {
+		t.Fatalf("GetArticle returned error: %v", err)
+	}
+
+	if requestedTweetID != "9201" {
+		t.Errorf("Expected tweet_id 9201, got %q", requestedTweetID)
+	}
+	if article.Title != "Why rates stay high" || len(article.Contents) != 3 {
+		t.Fatalf("Expected the article with 3 content blocks, got %+v", article)
+	}
+	if body := services.ArticleBody(*article); body != "Inflation is sticky.\n\nWages keep rising." {
+		t.Errorf("Expected empty blocks to be skipped, got %q", body)
+	}
+}
+
+// TestGetArticleErrorStatus tests that an error status in the body is returned as an error
+func TestGetArticleErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "error", "message": "article not found"}`))
+	}))
+	defer server.Close()
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+
+	if _, err := client.GetArticle(context.Background(), "9202"); err == nil {
+		t.Error("Expected an error for an error status")
+	}
+}
+
+// TestHasArticleLink tests detecting links to X articles in the text and URL entities
+func TestHasArticleLink(t *testing.T) {
+	tests := []struct {
+		name  string
+		tweet services.TweetData
+		want  bool
+	}{
+		{
+			name:  "expanded URL entity",
+			tweet: services.TweetData{Text: "New piece https://t.co/abc", Entities: &services.EntityData{URLs: []services.URLEntity{{URL: "https://t.co/abc", ExpandedURL: "https://x.com/i/article/1866"}}}},
+			want:  true,
+		},
+		{
+			name:  "link in text",
+			tweet: services.TweetData{Text: "Read https://twitter.com/i/article/1866"},
+			want:  true,
+		},
+		{
+			name:  "other link",
+			tweet: services.TweetData{Text: "https://t.co/abc", Entities: &services.EntityData{URLs: []services.URLEntity{{ExpandedURL: "https://example.com/post"}}}},
+			want:  false,
+		},
+		{
+			name:  "no links",
+			tweet: services.TweetData{Text: "Plain tweet"},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := services.HasArticleLink(tt.tweet); got != tt.want {
+				t.Errorf("HasArticleLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestChunkArticle tests splitting an article body at paragraph and word boundaries
+func TestChunkArticle(t *testing.T) {
+	t.Run("paragraphs", func(t *testing.T) {
+		chunks := services.ChunkArticle("First paragraph.\n\nSecond one.\n\nThird paragraph here.", 30)
+		want := []string{"First paragraph.\n\nSecond one.", "Third paragraph here."}
+		if strings.Join(chunks, "|") != strings.Join(want, "|") {
+			t.Errorf("Expected %q, got %q", want, chunks)
+		}
+	})
+
+	t.Run("long paragraph", func(t *testing.T) {
+		chunks := services.ChunkArticle("żółw "+strings.Repeat("word ", 10)+strings.Repeat("x", 25), 12)
+		for _, chunk := range chunks {
+			if utf8.RuneCountInString(chunk) > 12 {
+				t.Errorf("Expected chunks of at most 12 characters, got %q", chunk)
+			}
+		}
+		if chunks[0] != "żółw word" {
+			t.Errorf("Expected the first chunk to end between words, got %q", chunks[0])
+		}
+		if got := strings.Join(chunks, ""); strings.Count(got, "x") != 25 {
+			t.Errorf("Expected the long word to be kept whole across chunks, got %q", chunks)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if chunks := services.ChunkArticle(" \n\n ", 100); len(chunks) != 0 {
+			t.Errorf("Expected no chunks for an empty body, got %q", chunks)
+		}
+	})
+}
+
+// TestSelectArticleChunks tests picking the article chunks most relevant to a question within the prompt budget
+func TestSelectArticleChunks(t *testing.T) {
+	filler := strings.Repeat("Markets moved sideways all week. ", 50)
+	chunks := []string{
+		"Introduction to the outlook for next year.",
+		filler,
+		filler,
+		"The central bank will keep interest rates high because inflation is sticky.",
+		filler,
+		"Housing prices are expected to fall in the second half.",
+	}
+
+	t.Run("relevant chunks beyond the beginning", func(t *testing.T) {
+		selected := services.SelectArticleChunks(chunks, "Why does the central bank keep interest rates high?", 200)
+		if len(selected) < 2 || selected[0] != 0 || selected[1] != 3 {
+			t.Errorf("Expected the introduction and the chunk about rates first, got %v", selected)
+		}
+		for _, index := range selected {
+			if chunks[index] == filler {
+				t.Errorf("Expected no filler chunk over the budget, got chunk %d", index)
+			}
+		}
+	})
+
+	t.Run("all chunks within the budget", func(t *testing.T) {
+		selected := services.SelectArticleChunks(chunks, "housing", services.ArticlePromptBudget)
+		if len(selected) != len(chunks) {
+			t.Errorf("Expected all %d chunks of a short article, got %v", len(chunks), selected)
+		}
+	})
+
+	t.Run("leading chunks without matching words", func(t *testing.T) {
+		budget := utf8.RuneCountInString(chunks[0]) + 2*utf8.RuneCountInString(filler)
+		selected := services.SelectArticleChunks(chunks, "What is it about?", budget)
+		if len(selected) < 3 || selected[0] != 0 || selected[1] != 1 || selected[2] != 2 {
+			t.Errorf("Expected the leading chunks first, got %v", selected)
+		}
+		total := 0
+		for _, index := range selected {
+			total += utf8.RuneCountInString(chunks[index])
+		}
+		if total > budget {
+			t.Errorf("Expected at most %d characters, got %d", budget, total)
+		}
+	})
+
+	t.Run("no chunks", func(t *testing.T) {
+		if selected := services.SelectArticleChunks(nil, "rates", services.ArticlePromptBudget); len(selected) != 0 {
+			t.Errorf("Expected no chunks, got %v", selected)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// fakeTimeline serves an author's timeline of tweets newestID down to 1, pageSize tweets per page
+// Cursors are the offset of the page; every cursor requested is recorded
+type fakeTimeline struct {
+	newestID int64
+	pageSize int
+	failAt   string
+	cursors  []string
+	fetched  []int64
+}
+
+func (f *fakeTimeline) fetchPage(ctx context.Context, cursor string) (*services.TweetResponse, error) {
+	f.cursors = append(f.cursors, cursor)
+	if cursor != "" && cursor == f.failAt {
+		return nil, errors.New("cursor expired")
+	}
+
+	offset := 0
+	if cursor != "" {
+		offset, _ = strconv.Atoi(cursor)
+	}
+
+	resp := &services.TweetResponse{}
+	for i := offset; i < offset+f.pageSize && f.newestID-int64(i) > 0; i++ {
+		resp.Tweets = append(resp.Tweets, services.TweetData{ID: strconv.FormatInt(f.newestID-int64(i), 10)})
+	}
+	if next := offset + f.pageSize; f.newestID-int64(next) > 0 {
+		resp.HasNextPage = true
+		resp.NextCursor = strconv.Itoa(next)
+	}
+	return resp, nil
+}
+
+func (f *fakeTimeline) handlePage(tweets []services.TweetData) {
+	for _, tweet := range tweets {
+		id, _ := strconv.ParseInt(tweet.ID, 10, 64)
+		f.fetched = append(f.fetched, id)
+	}
+}
+
+func (f *fakeTimeline) page(t *testing.T, position services.AuthorTimelinePosition, maxPages int) services.AuthorTimelinePaging {
+	t.Helper()
+
+	paging, err := services.PageAuthorTimeline(context.Background(), position, maxPages, f.fetchPage, f.handlePage)
+	if err != nil {
+		t.Fatalf("PageAuthorTimeline failed: %v", err)
+	}
+	return paging
+}
+
+// TestPageAuthorTimelineReachesWatermark tests that paging stops at the watermark and reports catching up
+func TestPageAuthorTimelineReachesWatermark(t *testing.T) {
+	timeline := &fakeTimeline{newestID: 100, pageSize: 10}
+
+	paging := timeline.page(t, services.AuthorTimelinePosition{WatermarkID: 75}, 10)
+	if !paging.CaughtUp || paging.Pages != 3 || paging.ResumeCursor != "" {
+		t.Errorf("Expected to catch up after 3 pages, got %+v", paging)
+	}
+	if len(timeline.fetched) != 30 || timeline.fetched[29] != 71 {
+		t.Errorf("Expected tweets 100 down to 71, got %v", timeline.fetched)
+	}
+
+	// An author never synced gets the first page only
+	first := &fakeTimeline{newestID: 100, pageSize: 10}
+	paging = first.page(t, services.AuthorTimelinePosition{}, 10)
+	if !paging.CaughtUp || paging.Pages != 1 {
+		t.Errorf("Expected a never-synced author to catch up with one page, got %+v", paging)
+	}
+
+	// A timeline ending above the watermark (deleted tweets) is caught up too
+	short := &fakeTimeline{newestID: 15, pageSize: 10}
+	paging = short.page(t, services.AuthorTimelinePosition{WatermarkID: 1000}, 10)
+	if !paging.CaughtUp {
+		t.Errorf("Expected the end of the timeline to catch up, got %+v", paging)
+	}
+}
+
+// TestPageAuthorTimelinePageLimit tests that a run stopped by the page limit is not caught up and that
+// the next run reads the new tweets, then continues from the stored cursor instead of skipping the gap
+func TestPageAuthorTimelinePageLimit(t *testing.T) {
+	timeline := &fakeTimeline{newestID: 100, pageSize: 10}
+
+	paging := timeline.page(t, services.AuthorTimelinePosition{WatermarkID: 40}, 3)
+	if paging.CaughtUp || paging.Pages != 3 || paging.ResumeCursor != "30" {
+		t.Fatalf("Expected to stop after 3 pages with cursor 30, got %+v", paging)
+	}
+
+	// 15 new tweets arrive; the earlier run fetched up to tweet 100
+	timeline = &fakeTimeline{newestID: 115, pageSize: 10}
+	paging = timeline.page(t, services.AuthorTimelinePosition{WatermarkID: 40, ResumeCursor: "30", PendingID: 100}, 3)
+	if !paging.Resumed || paging.CaughtUp || paging.ResumeCursor != "40" {
+		t.Fatalf("Expected to resume at cursor 30 and stop with cursor 40, got %+v", paging)
+	}
+	if got := timeline.cursors; len(got) != 3 || got[0] != "" || got[1] != "10" || got[2] != "30" {
+		t.Errorf("Expected cursors \"\", 10, 30, got %q", got)
+	}
+	if last := timeline.fetched[len(timeline.fetched)-1]; last != 76 {
+		t.Errorf("Expected the resumed page to end at tweet 76, got %d", last)
+	}
+
+	// The third run reaches the watermark through the stored cursor
+	timeline = &fakeTimeline{newestID: 115, pageSize: 10}
+	paging = timeline.page(t, services.AuthorTimelinePosition{WatermarkID: 40, ResumeCursor: "40", PendingID: 115}, 10)
+	if !paging.CaughtUp || !paging.Resumed || paging.Pages != 5 {
+		t.Errorf("Expected to catch up through the stored cursor in 5 pages, got %+v", paging)
+	}
+	if len(timeline.fetched) != 50 || timeline.fetched[10] != 75 || timeline.fetched[49] != 36 {
+		t.Errorf("Expected tweets 115 to 106, then 75 down to 36, got %v", timeline.fetched)
+	}
+}
+
+// TestPageAuthorTimelineResumeError tests that a failed fetch at the stored cursor is reported as resumed
+func TestPageAuthorTimelineResumeError(t *testing.T) {
+	timeline := &fakeTimeline{newestID: 100, pageSize: 10, failAt: "30"}
+
+	paging, err := services.PageAuthorTimeline(context.Background(),
+		services.AuthorTimelinePosition{WatermarkID: 40, ResumeCursor: "30", PendingID: 100},
+		10, timeline.fetchPage, timeline.handlePage)
+	if err == nil {
+		t.Fatal("Expected the fetch error")
+	}
+	if !paging.Resumed || paging.CaughtUp {
+		t.Errorf("Expected a failed resume that did not catch up, got %+v", paging)
+	}
+}
@@ -0,0 +1,198 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/internal/services"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+)
+
+var _ services.EngagementPostStore = (*repositories.PostRepository)(nil)
+
+// fakeEngagementStore keeps the posts to refresh in memory and records every update
+type fakeEngagementStore struct {
+	mu        sync.Mutex
+	postIDs   []int64
+	texts     map[int64]string
+	edited    []int64
+	snapshots []db.PostEngagement
+	deleted   []int64
+}
+
+func (s *fakeEngagementStore) ListPostIDsForEngagementRefresh(ctx context.Context, since time.Time, limit int) ([]int64, error) {
+	return s.postIDs[:min(limit, len(s.postIDs))], nil
+}
+
+func (s *fakeEngagementStore) ApplyPostText(ctx context.Context, postID int64, rawText string, detectedAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.texts[postID] == rawText {
+		return false, nil
+	}
+	s.texts[postID] = rawText
+	s.edited = append(s.edited, postID)
+	return true, nil
+}
+
+func (s *fakeEngagementStore) RecordPostEngagement(ctx context.Context, snapshots []db.PostEngagement) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshots = append(s.snapshots, snapshots...)
+	return nil
+}
+
+func (s *fakeEngagementStore) MarkPostsDeleted(ctx context.Context, postIDs []int64, deletedAt time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleted = append(s.deleted, postIDs...)
+	return len(postIDs), nil
+}
+
+// newEngagementStore returns a store with posts 1001 to 1005, each with the text "post <id>"
+func newEngagementStore() *fakeEngagementStore {
+	store := &fakeEngagementStore{texts: map[int64]string{}}
+	for id := int64(1001); id <= 1005; id++ {
+		store.postIDs = append(store.postIDs, id)
+		store.texts[id] = fmt.Sprintf("post %d", id)
+	}
+	return store
+}
+
+// newTweetsByIDsServer serves the batch tweet lookup; tweets in deleted are left out of the response,
+// texts overrides the text of a tweet and a batch containing failID is rejected with 400
+func newTweetsByIDsServer(t *testing.T, deleted map[string]bool, texts map[string]string, failID string) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var batches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query().Get("tweet_ids")
+		mu.Lock()
+		batches = append(batches, ids)
+		mu.Unlock()
+
+		if failID != "" && slices.Contains(strings.Split(ids, ","), failID) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","msg":"invalid tweet_ids"}`))
+			return
+		}
+
+		tweets := []string{}
+		for _, id := range strings.Split(ids, ",") {
+			if deleted[id] {
+				continue
+			}
+			text := "post " + id
+			if edited, ok := texts[id]; ok {
+				text = edited
+			}
+			tweets = append(tweets, fmt.Sprintf(
+				`{"id":%q,"text":%q,"createdAt":"Mon Jan 02 15:04:05 +0000 2006","likeCount":10,"retweetCount":2,"replyCount":1,"quoteCount":0,"viewCount":-1,"bookmarkCount":3,"author":{"id":"42","userName":"author"}}`,
+				id, text))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"tweets":[%s],"status":"success","message":""}`, strings.Join(tweets, ","))
+	}))
+	t.Cleanup(server.Close)
+	return server, &batches
+}
+
+// newTestEngagementRefresher creates a refresher looking up batches of 2 tweets on the fixture server
+func newTestEngagementRefresher(server *httptest.Server, store *fakeEngagementStore) *services.EngagementRefresher {
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+
+	config := services.DefaultEngagementRefresherConfig()
+	config.BatchSize = 2
+	return services.NewEngagementRefresher(client, store, config)
+}
+
+// TestEngagementRefresherRefresh tests that metrics are recorded in batches, edits are counted
+// and posts missing from the response are marked deleted
+func TestEngagementRefresherRefresh(t *testing.T) {
+	logger.Init(slog.LevelInfo)
+
+	store := newEngagementStore()
+	server, batches := newTweetsByIDsServer(t,
+		map[string]bool{"1003": true},
+		map[string]string{"1002": "post 1002 (edited)"},
+		"")
+	refresher := newTestEngagementRefresher(server, store)
+
+	if refreshed := refresher.Refresh(context.Background()); refreshed != 4 {
+		t.Errorf("Expected 4 posts refreshed, got %d", refreshed)
+	}
+
+	if !slices.Equal(*batches, []string{"1001,1002", "1003,1004", "1005"}) {
+		t.Errorf("Expected 3 batches of at most 2 tweets, got %q", *batches)
+	}
+	if !slices.Equal(store.edited, []int64{1002}) || store.texts[1002] != "post 1002 (edited)" {
+		t.Errorf("Expected only post 1002 to be edited, got %v", store.edited)
+	}
+	if !slices.Equal(store.deleted, []int64{1003}) {
+		t.Errorf("Expected only post 1003 to be marked deleted, got %v", store.deleted)
+	}
+
+	if len(store.snapshots) != 4 {
+		t.Fatalf("Expected 4 engagement snapshots, got %d", len(store.snapshots))
+	}
+	for _, snapshot := range store.snapshots {
+		if snapshot.XPostID == 1003 {
+			t.Error("Expected no snapshot of the deleted post")
+		}
+		if snapshot.LikeCount != 10 || snapshot.RetweetCount != 2 || snapshot.BookmarkCount != 3 || snapshot.ViewCount != 0 {
+			t.Errorf("Unexpected engagement counts for post %d: %+v", snapshot.XPostID, snapshot.PostEngagementCounts)
+		}
+	}
+}
+
+// TestEngagementRefresherEmptyBatch tests that an empty response does not mark the whole batch deleted
+func TestEngagementRefresherEmptyBatch(t *testing.T) {
+	logger.Init(slog.LevelInfo)
+
+	store := newEngagementStore()
+	store.postIDs = store.postIDs[:2]
+	server, _ := newTweetsByIDsServer(t, map[string]bool{"1001": true, "1002": true}, nil, "")
+	refresher := newTestEngagementRefresher(server, store)
+
+	if refreshed := refresher.Refresh(context.Background()); refreshed != 0 {
+		t.Errorf("Expected no posts refreshed, got %d", refreshed)
+	}
+	if len(store.deleted) != 0 {
+		t.Errorf("Expected no posts marked deleted, got %v", store.deleted)
+	}
+}
+
+// TestEngagementRefresherFailedBatch tests that a failed batch is skipped without stopping the others
+func TestEngagementRefresherFailedBatch(t *testing.T) {
+	logger.Init(slog.LevelInfo)
+
+	store := newEngagementStore()
+	server, batches := newTweetsByIDsServer(t, nil, nil, "1003")
+	refresher := newTestEngagementRefresher(server, store)
+
+	if refreshed := refresher.Refresh(context.Background()); refreshed != 3 {
+		t.Errorf("Expected the 3 posts outside the failed batch to be refreshed, got %d", refreshed)
+	}
+	if len(*batches) != 3 {
+		t.Errorf("Expected the rejected batch to be requested once, got batches %q", *batches)
+	}
+	if len(store.deleted) != 0 || len(store.edited) != 0 {
+		t.Errorf("Expected no deletions or edits, got deleted %v, edited %v", store.deleted, store.edited)
+	}
+}
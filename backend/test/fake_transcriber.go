@@ -0,0 +1,62 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+var _ services.Transcriber = (*FakeTranscriber)(nil)
+
+// FakeTranscriberModel is the model recorded with transcripts of a FakeTranscriber
+const FakeTranscriberModel = "fake-transcriber"
+
+// FakeTranscriber is a services.Transcriber for tests that returns canned transcripts without network access
+// Videos without a transcript in Transcripts fail; with Err set, every video fails with it
+type FakeTranscriber struct {
+	Transcripts map[string]string
+	Err         error
+
+	mu    sync.Mutex
+	calls []string
+}
+
+// NewFakeTranscriber creates a fake transcriber returning the given transcripts by video URL
+func NewFakeTranscriber(transcripts map[string]string) *FakeTranscriber {
+	return &FakeTranscriber{Transcripts: transcripts}
+}
+
+// Model returns FakeTranscriberModel
+func (f *FakeTranscriber) Model() string {
+	return FakeTranscriberModel
+}
+
+// Transcribe returns the canned transcript of videoURL
+// The duration limit applies as in WhisperTranscriber
+func (f *FakeTranscriber) Transcribe(ctx context.Context, videoURL string, durationSeconds int) (string, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, videoURL)
+	f.mu.Unlock()
+
+	if durationSeconds > services.MaxVideoDuration {
+		return "", fmt.Errorf("video duration %ds exceeds limit of %ds: %w", durationSeconds, services.MaxVideoDuration, services.ErrMediaExceedsLimit)
+	}
+	if f.Err != nil {
+		return "", f.Err
+	}
+	transcript, ok := f.Transcripts[videoURL]
+	if !ok {
+		return "", fmt.Errorf("no transcript for %s", videoURL)
+	}
+	return transcript, nil
+}
+
+// Calls returns the video URLs transcribed so far, in order
+func (f *FakeTranscriber) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.calls...)
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// TestGetListMembers tests reading a page of list members
+func TestGetListMembers(t *testing.T) {
+	var requestedID, requestedCursor string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/twitter/list/members" {
+			t.Errorf("Expected path /twitter/list/members, got %s", r.URL.Path)
+		}
+		requestedID = r.URL.Query().Get("listId")
+		requestedCursor = r.URL.Query().Get("cursor")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"members": [
+				{"id": "42", "userName": "analyst", "name": "Analyst"},
+				{"id": "43", "userName": "economist", "name": "Economist"}
+			],
+			"has_next_page": true,
+			"next_cursor": "page-2",
+			"status": "success",
+			"msg": ""
+		}`))
+	}))
+	defer server.Close()
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+
+	resp, err := client.GetListMembers(context.Background(), "1500", "page-1")
+	if err != nil {
+		t.Fatalf("GetListMembers returned error: %v", err)
+	}
+
+	if requestedID != "1500" || requestedCursor != "page-1" {
+		t.Errorf("Expected listId 1500 and cursor page-1, got %q and %q", requestedID, requestedCursor)
+	}
+	if len(resp.Members) != 2 || resp.Members[0].UserName != "analyst" {
+		t.Fatalf("Expected 2 members starting with analyst, got %+v", resp.Members)
+	}
+	if !resp.HasNextPage || resp.NextCursor != "page-2" {
+		t.Errorf("Expected next page page-2, got %v %q", resp.HasNextPage, resp.NextCursor)
+	}
+}
+
+// TestGetListMembersErrorStatus tests that an error status in the body is returned as an error
+func TestGetListMembersErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"members": [], "status": "error", "msg": "list not found"}`))
+	}))
+	defer server.Close()
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+
+	if _, err := client.GetListMembers(context.Background(), "1500", ""); err == nil {
+		t.Fatal("Expected error for error status, got nil")
+	}
+}
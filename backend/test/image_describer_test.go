@@ -0,0 +1,203 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+)
+
+// fixtureTransport sends every request, including those to OpenRouter, to the fixture server
+type fixtureTransport struct {
+	target *url.URL
+}
+
+func (t fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// visionFixture serves images and a chat completions endpoint describing them
+// It records the image sources sent to the model and the most requests handled at once
+type visionFixture struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	sources     []string
+}
+
+func (f *visionFixture) handleCompletion(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+
+	var req struct {
+		Messages []struct {
+			Content []struct {
+				ImageURL *struct {
+					URL string `json:"url"`
+				} `json:"image_url"`
+			} `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Messages) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	source := ""
+	for _, part := range req.Messages[0].Content {
+		if part.ImageURL != nil {
+			source = part.ImageURL.URL
+		}
+	}
+
+	f.mu.Lock()
+	f.sources = append(f.sources, source)
+	f.mu.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"id":     "chatcmpl-fixture",
+		"object": "chat.completion",
+		"choices": []map[string]any{{
+			"index":         0,
+			"finish_reason": "stop",
+			"message":       map[string]string{"role": "assistant", "content": "  An image from " + strings.SplitN(source, ",", 2)[0] + "  "},
+		}},
+	})
+}
+
+// newVisionFixtureServer starts the fixture and returns an HTTP client routed to it
+func newVisionFixtureServer(t *testing.T) (*visionFixture, *http.Client) {
+	t.Helper()
+
+	fixture := &visionFixture{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/chat/completions", fixture.handleCompletion)
+	mux.HandleFunc("/media/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("png bytes of " + r.URL.Path))
+	})
+	mux.HandleFunc("/page.html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html></html>"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse fixture URL: %v", err)
+	}
+	return fixture, &http.Client{Transport: fixtureTransport{target: target}}
+}
+
+// TestImageDescriberConcurrency tests that images are described in order with a concurrency cap
+func TestImageDescriberConcurrency(t *testing.T) {
+	fixture, httpClient := newVisionFixtureServer(t)
+	client := services.NewOpenRouterClient("test-key", httpClient)
+	config := services.DefaultImageDescriberConfig()
+	config.Concurrency = 2
+	config.DownloadImages = false
+	describer := services.NewImageDescriber(client, nil, httpClient, config)
+
+	imageURLs := []string{
+		"https://pbs.twimg.com/media/1.jpg",
+		"https://pbs.twimg.com/media/2.jpg",
+		"https://pbs.twimg.com/media/3.jpg",
+		"https://pbs.twimg.com/media/4.jpg",
+		"https://pbs.twimg.com/media/5.jpg",
+	}
+	results := describer.DescribeImages(context.Background(), imageURLs)
+
+	if len(results) != len(imageURLs) {
+		t.Fatalf("Expected %d results, got %d", len(imageURLs), len(results))
+	}
+	for i, result := range results {
+		if result.Err != nil {
+			t.Fatalf("Expected image %d to be described, got %v", i, result.Err)
+		}
+		if result.Description != "An image from "+imageURLs[i] || result.Model != services.MediaDescriptionModel || result.Cached {
+			t.Errorf("Expected the trimmed description of image %d by the vision model, got %+v", i, result)
+		}
+	}
+	if fixture.maxInFlight > 2 {
+		t.Errorf("Expected at most 2 images described at once, got %d", fixture.maxInFlight)
+	}
+}
+
+// TestImageDescriberDownload tests that downloaded images are sent as data URLs and others by URL
+func TestImageDescriberDownload(t *testing.T) {
+	// Initialize logger for tests
+	logger.Init(slog.LevelInfo)
+
+	fixture, httpClient := newVisionFixtureServer(t)
+	client := services.NewOpenRouterClient("test-key", httpClient)
+	describer := services.NewImageDescriber(client, nil, httpClient, services.DefaultImageDescriberConfig())
+
+	results := describer.DescribeImages(context.Background(), []string{
+		"https://pbs.twimg.com/media/chart.png",
+		"https://example.com/page.html",
+	})
+
+	if results[0].Err != nil || results[0].Description != "An image from data:image/png;base64" {
+		t.Errorf("Expected the downloaded image to be sent as a data URL, got %+v", results[0])
+	}
+	if results[1].Err != nil || results[1].Description != "An image from https://example.com/page.html" {
+		t.Errorf("Expected a non-image response to fall back to the URL, got %+v", results[1])
+	}
+	if len(fixture.sources) != 2 {
+		t.Errorf("Expected 2 vision requests, got %d", len(fixture.sources))
+	}
+}
+
+// TestNormalizeImageURL tests that variants of one image URL share a cache key
+func TestNormalizeImageURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		imageURL string
+		expected string
+	}{
+		{name: "x image with size", imageURL: "https://pbs.twimg.com/media/ABC.jpg?name=large", expected: "https://pbs.twimg.com/media/ABC.jpg"},
+		{name: "x image with format", imageURL: "http://PBS.twimg.com/media/ABC?format=JPG&name=small", expected: "https://pbs.twimg.com/media/ABC.jpg"},
+		{name: "other host keeps query", imageURL: "HTTPS://Example.com/img.png?v=2&a=1#top", expected: "https://example.com/img.png?a=1&v=2"},
+		{name: "not a URL", imageURL: "  not a url  ", expected: "not a url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := services.NormalizeImageURL(tt.imageURL); got != tt.expected {
+				t.Errorf("NormalizeImageURL(%q) = %q, want %q", tt.imageURL, got, tt.expected)
+			}
+		})
+	}
+
+	if services.ImageURLCacheKey("https://pbs.twimg.com/media/ABC?format=jpg&name=small") != services.ImageURLCacheKey("https://pbs.twimg.com/media/ABC.jpg") {
+		t.Error("Expected size variants of an X image to share a cache key")
+	}
+	if !strings.HasPrefix(services.ImageContentCacheKey([]byte("png")), "sha256:") {
+		t.Error("Expected content cache keys to be prefixed with sha256:")
+	}
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// TestAllowsTweet tests evaluating tweets of a followed author against an ingest policy
+func TestAllowsTweet(t *testing.T) {
+	author := services.UserData{ID: "42", UserName: "author"}
+	original := services.TweetData{ID: "1", Lang: "en", Author: author}
+	selfReply := services.TweetData{ID: "2", Lang: "en", IsReply: true, InReplyToId: "1", InReplyToUserId: "42", Author: author}
+	reply := services.TweetData{ID: "3", Lang: "en", IsReply: true, InReplyToId: "99", InReplyToUserId: "7", Author: author}
+	quote := services.TweetData{ID: "4", Lang: "en", Author: author, QuotedTweet: &services.TweetData{ID: "98", Lang: "pl"}}
+	retweet := services.TweetData{ID: "5", Lang: "en", Author: author, RetweetedTweet: &services.TweetData{ID: "97", Lang: "pl"}}
+	mediaOnly := services.TweetData{ID: "6", Lang: "zxx", Author: author}
+	polish := services.TweetData{ID: "7", Lang: "pl", Author: author}
+
+	defaults := services.DefaultIngestPolicy(uuid.New())
+	everything := db.IngestPolicy{IncludeRetweets: true, IncludeQuotes: true, IncludeReplies: true}
+	english := db.IngestPolicy{IncludeRetweets: true, IncludeQuotes: true, IncludeReplies: true, Languages: pq.StringArray{"en"}}
+
+	tests := []struct {
+		name     string
+		policy   db.IngestPolicy
+		tweet    services.TweetData
+		expected bool
+	}{
+		{"default policy allows original posts", defaults, original, true},
+		{"default policy allows self-replies", defaults, selfReply, true},
+		{"default policy drops replies to others", defaults, reply, false},
+		{"default policy drops quote tweets", defaults, quote, false},
+		{"default policy drops retweets", defaults, retweet, false},
+		{"toggles allow replies to others", everything, reply, true},
+		{"toggles allow quote tweets", everything, quote, true},
+		{"toggles allow retweets", everything, retweet, true},
+		{"language list allows listed language", english, original, true},
+		{"language list drops other languages", english, polish, false},
+		{"language list keeps undetermined language", english, mediaOnly, true},
+		{"language list judges quote tweets by the comment", english, quote, true},
+		{"language list judges retweets by the retweeted tweet", english, retweet, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := services.AllowsTweet(tt.policy, tt.tweet); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestConvertToDTOKind tests classifying tweets into post kinds
+func TestConvertToDTOKind(t *testing.T) {
+	client := services.NewTwitterClient("test-api-key", nil)
+	author := services.UserData{ID: "42", UserName: "author"}
+
+	tests := []struct {
+		name           string
+		tweet          services.TweetData
+		expectedKind   string
+		expectedQuoted int64
+	}{
+		{"original post", services.TweetData{ID: "1", Author: author}, db.PostKindOriginal, 0},
+		{"self-reply", services.TweetData{ID: "2", IsReply: true, InReplyToId: "1", InReplyToUserId: "42", Author: author}, db.PostKindOriginal, 0},
+		{"reply to another author", services.TweetData{ID: "3", IsReply: true, InReplyToId: "99", InReplyToUserId: "7", Author: author}, db.PostKindReply, 0},
+		{"quote tweet", services.TweetData{ID: "4", Author: author, QuotedTweet: &services.TweetData{ID: "98"}}, db.PostKindQuote, 98},
+		{"retweet", services.TweetData{ID: "5", Author: author, RetweetedTweet: &services.TweetData{ID: "97"}}, db.PostKindRetweet, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tweetDTO := client.ConvertToDTO(tt.tweet)
+			if tweetDTO.Kind != tt.expectedKind {
+				t.Errorf("Expected kind %q, got %q", tt.expectedKind, tweetDTO.Kind)
+			}
+			if tweetDTO.QuotedPostID != tt.expectedQuoted {
+				t.Errorf("Expected quoted post %d, got %d", tt.expectedQuoted, tweetDTO.QuotedPostID)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+)
+
+// TestArticlesIntegration tests storing X articles in chunks and loading them for Q&A
+func TestArticlesIntegration(t *testing.T) {
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("ArticleChunks", func(t *testing.T) {
+		testArticleChunks(t, dbHelper)
+	})
+}
+
+// testArticleChunks tests that chunks are kept in order, limited on read and not overwritten
+func testArticleChunks(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	postRepo := repositories.NewPostRepository(database)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now().UTC()
+
+	authorID := int64(555)
+	dataHelper.InsertAuthor(t, authorID, "writer", StringPtr("Writer"), nil)
+
+	for _, postID := range []int64{8501, 8502} {
+		err := postRepo.InsertPost(ctx, userID, &dto.TweetDTO{
+			ID:          postID,
+			AuthorID:    authorID,
+			Text:        "New article https://t.co/abc",
+			RawText:     "New article https://t.co/abc",
+			PublishedAt: now.Add(-time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("InsertPost failed: %v", err)
+		}
+	}
+
+	title := "Why rates stay high"
+	if err := postRepo.InsertArticle(ctx, userID, 8501, &title, []string{"first", "second", "third"}); err != nil {
+		t.Fatalf("InsertArticle failed: %v", err)
+	}
+	// A second fetch of the same article keeps the stored one
+	if err := postRepo.InsertArticle(ctx, userID, 8501, nil, []string{"other"}); err != nil {
+		t.Fatalf("InsertArticle failed: %v", err)
+	}
+
+	articles, err := postRepo.GetArticles(ctx, userID, []int64{8501, 8502}, 2)
+	if err != nil {
+		t.Fatalf("GetArticles failed: %v", err)
+	}
+	if len(articles) != 1 {
+		t.Fatalf("Expected only post 8501 to have an article, got %d articles", len(articles))
+	}
+
+	article := articles[8501]
+	if article == nil || article.Title == nil || *article.Title != title {
+		t.Fatalf("Expected the article titled %q, got %+v", title, article)
+	}
+	if article.ChunkCount != 3 || len(article.Chunks) != 2 || article.Chunks[0] != "first" || article.Chunks[1] != "second" {
+		t.Errorf("Expected the first 2 of 3 chunks in order, got %d %q", article.ChunkCount, article.Chunks)
+	}
+}
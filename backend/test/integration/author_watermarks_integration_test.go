@@ -0,0 +1,97 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+)
+
+// TestAuthorWatermarksIntegration tests storing author watermarks and resume points
+func TestAuthorWatermarksIntegration(t *testing.T) {
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("ResumePoint", func(t *testing.T) {
+		testWatermarkResumePoint(t, dbHelper)
+	})
+}
+
+// testWatermarkResumePoint tests that a resume point leaves the watermark in place until it is advanced
+func testWatermarkResumePoint(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	watermarkRepo := repositories.NewWatermarkRepository(database)
+	ctx := context.Background()
+	userID := uuid.New()
+	authorID := int64(1001)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	dataHelper.InsertAuthor(t, authorID, "busy_author", nil, nil)
+
+	// No watermark yet: nothing to resume
+	if err := watermarkRepo.SaveResumePoint(ctx, userID, authorID, "cursor-1", 300, now); err != nil {
+		t.Fatalf("SaveResumePoint failed: %v", err)
+	}
+	watermark, err := watermarkRepo.GetWatermark(ctx, userID, authorID)
+	if err != nil {
+		t.Fatalf("GetWatermark failed: %v", err)
+	}
+	if watermark != nil {
+		t.Fatalf("Expected no watermark for an author never synced, got %+v", watermark)
+	}
+
+	if err := watermarkRepo.AdvanceWatermark(ctx, userID, authorID, 100, now.Add(-time.Hour)); err != nil {
+		t.Fatalf("AdvanceWatermark failed: %v", err)
+	}
+
+	// Two runs stop at the page limit; the pending tweet only moves forward
+	if err := watermarkRepo.SaveResumePoint(ctx, userID, authorID, "cursor-1", 300, now); err != nil {
+		t.Fatalf("SaveResumePoint failed: %v", err)
+	}
+	if err := watermarkRepo.SaveResumePoint(ctx, userID, authorID, "cursor-2", 250, now.Add(-time.Minute)); err != nil {
+		t.Fatalf("SaveResumePoint failed: %v", err)
+	}
+
+	watermark, err = watermarkRepo.GetWatermark(ctx, userID, authorID)
+	if err != nil {
+		t.Fatalf("GetWatermark failed: %v", err)
+	}
+	if watermark.LastTweetID != 100 {
+		t.Errorf("Expected the watermark to stay at 100, got %d", watermark.LastTweetID)
+	}
+	if watermark.ResumeCursor == nil || *watermark.ResumeCursor != "cursor-2" {
+		t.Errorf("Expected the latest resume cursor, got %v", watermark.ResumeCursor)
+	}
+	if watermark.PendingTweetID == nil || *watermark.PendingTweetID != 300 || !watermark.PendingTweetAt.Equal(now) {
+		t.Errorf("Expected pending tweet 300, got %v at %v", watermark.PendingTweetID, watermark.PendingTweetAt)
+	}
+
+	// Catching up advances the watermark and clears the resume point
+	if err := watermarkRepo.AdvanceWatermark(ctx, userID, authorID, 300, now); err != nil {
+		t.Fatalf("AdvanceWatermark failed: %v", err)
+	}
+	watermark, err = watermarkRepo.GetWatermark(ctx, userID, authorID)
+	if err != nil {
+		t.Fatalf("GetWatermark failed: %v", err)
+	}
+	if watermark.LastTweetID != 300 || watermark.ResumeCursor != nil || watermark.PendingTweetID != nil {
+		t.Errorf("Expected watermark 300 without a resume point, got %+v", watermark)
+	}
+
+	// An older tweet never moves the watermark back
+	if err := watermarkRepo.AdvanceWatermark(ctx, userID, authorID, 200, now.Add(-2*time.Hour)); err != nil {
+		t.Fatalf("AdvanceWatermark failed: %v", err)
+	}
+	watermark, err = watermarkRepo.GetWatermark(ctx, userID, authorID)
+	if err != nil {
+		t.Fatalf("GetWatermark failed: %v", err)
+	}
+	if watermark.LastTweetID != 300 || !watermark.LastTweetAt.Equal(now) {
+		t.Errorf("Expected the watermark to stay at 300, got %+v", watermark)
+	}
+}
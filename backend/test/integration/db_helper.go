@@ -71,6 +71,10 @@ CREATE TABLE IF NOT EXISTS user_following (
     user_id uuid NOT NULL,
     x_author_id bigint NOT NULL,
     last_checked_at timestamptz,
+    unfollowed_at timestamptz,
+    pinned boolean NOT NULL DEFAULT false,
+    excluded_at timestamptz,
+    sampled_at timestamptz,
     PRIMARY KEY (user_id, x_author_id),
     FOREIGN KEY (x_author_id) REFERENCES authors(x_author_id) ON DELETE CASCADE
 );
@@ -81,16 +85,93 @@ CREATE TABLE IF NOT EXISTS ingest_runs (
     user_id uuid NOT NULL,
     started_at timestamptz NOT NULL,
     completed_at timestamptz,
-    status text NOT NULL CHECK (status IN ('ok','rate_limited','error')),
+    status text NOT NULL CHECK (status IN ('ok','rate_limited','error','cancelled')),
     since_id bigint NOT NULL CHECK (since_id > 0),
     fetched_count int NOT NULL,
     retried int NOT NULL,
     rate_limit_hits int NOT NULL,
-    err_text text
+    err_text text,
+    phase text NOT NULL DEFAULT 'queued' CHECK (phase IN ('queued','following','tweets','done')),
+    following_count int NOT NULL DEFAULT 0,
+    tweets_count int NOT NULL DEFAULT 0,
+    cancel_requested_at timestamptz,
+    heartbeat_at timestamptz
 );
 
 -- Create index for ingest_runs on (user_id, started_at desc)
 CREATE INDEX IF NOT EXISTS idx_ingest_runs_user_started ON ingest_runs (user_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_ingest_runs_open ON ingest_runs (heartbeat_at) WHERE completed_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ingest_runs_user_open ON ingest_runs (user_id) WHERE completed_at IS NULL;
+
+-- Create user-scoped table: ingest_run_errors
+CREATE TABLE IF NOT EXISTS ingest_run_errors (
+    id bigserial PRIMARY KEY,
+    run_id char(26) NOT NULL REFERENCES ingest_runs (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL,
+    phase text NOT NULL CHECK (phase IN ('following','tweets')),
+    author_handle text,
+    message text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingest_run_errors_run_created ON ingest_run_errors (run_id, created_at);
+
+-- Create user-scoped table: author_watermarks
+CREATE TABLE IF NOT EXISTS author_watermarks (
+    user_id uuid NOT NULL,
+    x_author_id bigint NOT NULL,
+    last_tweet_id bigint NOT NULL CHECK (last_tweet_id > 0),
+    last_tweet_at timestamptz NOT NULL,
+    resume_cursor text,
+    pending_tweet_id bigint,
+    pending_tweet_at timestamptz,
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT pk_author_watermarks PRIMARY KEY (user_id, x_author_id),
+    CONSTRAINT fk_author_watermarks_author FOREIGN KEY (x_author_id) REFERENCES authors (x_author_id) ON DELETE CASCADE
+);
+
+-- Create user-scoped table: following_events
+CREATE TABLE IF NOT EXISTS following_events (
+    id bigserial PRIMARY KEY,
+    user_id uuid NOT NULL,
+    x_author_id bigint NOT NULL REFERENCES authors (x_author_id) ON DELETE CASCADE,
+    event text NOT NULL CHECK (event IN ('follow','unfollow')),
+    run_id char(26) REFERENCES ingest_runs (id) ON DELETE SET NULL,
+    occurred_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_following_events_user_occurred ON following_events (user_id, occurred_at DESC);
+
+-- Create system table: ingest_jobs (no row level security)
+CREATE TABLE IF NOT EXISTS ingest_jobs (
+    id char(26) PRIMARY KEY,
+    user_id uuid NOT NULL,
+    run_id char(26) REFERENCES ingest_runs (id) ON DELETE SET NULL,
+    backfill_hours int NOT NULL CHECK (backfill_hours BETWEEN 0 AND 720),
+    status text NOT NULL CHECK (status IN ('queued','running','done','failed')),
+    attempts int NOT NULL DEFAULT 0,
+    max_attempts int NOT NULL CHECK (max_attempts > 0),
+    run_after timestamptz NOT NULL DEFAULT now(),
+    locked_by text,
+    locked_until timestamptz,
+    last_error text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingest_jobs_claimable ON ingest_jobs (run_after) WHERE status IN ('queued','running');
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ingest_jobs_user_pending ON ingest_jobs (user_id) WHERE status IN ('queued','running');
+
+-- Create user-scoped table: ingest_policies
+CREATE TABLE IF NOT EXISTS ingest_policies (
+    user_id uuid PRIMARY KEY,
+    include_retweets boolean NOT NULL DEFAULT false,
+    include_quotes boolean NOT NULL DEFAULT false,
+    include_replies boolean NOT NULL DEFAULT false,
+    languages text[] NOT NULL DEFAULT '{}',
+    include_mentions boolean NOT NULL DEFAULT false,
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
 
 -- Create user-scoped table: posts
 CREATE TABLE IF NOT EXISTS posts (
@@ -104,6 +185,20 @@ CREATE TABLE IF NOT EXISTS posts (
     ingested_at timestamptz NOT NULL,
     first_visible_at timestamptz NOT NULL,
     edited_seen boolean NOT NULL DEFAULT false,
+    like_count bigint NOT NULL DEFAULT 0 CHECK (like_count >= 0),
+    retweet_count bigint NOT NULL DEFAULT 0 CHECK (retweet_count >= 0),
+    reply_count bigint NOT NULL DEFAULT 0 CHECK (reply_count >= 0),
+    quote_count bigint NOT NULL DEFAULT 0 CHECK (quote_count >= 0),
+    view_count bigint NOT NULL DEFAULT 0 CHECK (view_count >= 0),
+    bookmark_count bigint NOT NULL DEFAULT 0 CHECK (bookmark_count >= 0),
+    metrics_updated_at timestamptz,
+    raw_text text,
+    deleted_at timestamptz,
+    in_reply_to_id bigint CHECK (in_reply_to_id > 0),
+    kind text NOT NULL DEFAULT 'original' CHECK (kind IN ('original','reply','quote','retweet','quoted')),
+    reposted_by_id bigint REFERENCES authors(x_author_id) ON DELETE SET NULL,
+    quoted_post_id bigint CHECK (quoted_post_id > 0),
+    source text NOT NULL DEFAULT 'feed' CHECK (source IN ('feed','mention')),
     ts tsvector GENERATED ALWAYS AS (to_tsvector('english', text)) STORED,
     PRIMARY KEY (user_id, x_post_id),
     FOREIGN KEY (author_id) REFERENCES authors(x_author_id) ON DELETE CASCADE
@@ -112,6 +207,62 @@ CREATE TABLE IF NOT EXISTS posts (
 -- Create index for posts on (user_id, published_at desc)
 CREATE INDEX IF NOT EXISTS idx_posts_user_published ON posts (user_id, published_at DESC);
 
+-- Create partial index for mentions on (user_id, published_at desc)
+CREATE INDEX IF NOT EXISTS idx_posts_user_mentions ON posts (user_id, published_at DESC) WHERE source = 'mention';
+
+-- Create index for posts on (published_at desc)
+CREATE INDEX IF NOT EXISTS idx_posts_published ON posts (published_at DESC);
+
+-- Create index for posts on (user_id, conversation_id)
+CREATE INDEX IF NOT EXISTS idx_posts_user_conversation ON posts (user_id, conversation_id);
+
+-- Create view: threads (posts grouped by author and conversation, rls of posts applies)
+CREATE OR REPLACE VIEW threads
+WITH (security_invoker = true) AS
+SELECT
+    p.user_id,
+    p.conversation_id,
+    p.author_id,
+    count(*) AS post_count,
+    array_agg(p.x_post_id ORDER BY p.published_at, p.x_post_id) AS post_ids,
+    min(p.published_at) AS started_at,
+    max(p.published_at) AS last_published_at,
+    max(p.ingested_at) AS last_ingested_at,
+    bool_and(
+        p.in_reply_to_id IS NULL
+        OR EXISTS (
+            SELECT 1 FROM posts parent
+            WHERE parent.user_id = p.user_id AND parent.x_post_id = p.in_reply_to_id
+        )
+    ) AS complete
+FROM posts p
+WHERE p.conversation_id > 0
+  AND p.deleted_at IS NULL
+GROUP BY p.user_id, p.conversation_id, p.author_id;
+
+-- Create global table: post_engagement (no row level security)
+CREATE TABLE IF NOT EXISTS post_engagement (
+    x_post_id bigint NOT NULL CHECK (x_post_id > 0),
+    captured_at timestamptz NOT NULL DEFAULT now(),
+    like_count bigint NOT NULL CHECK (like_count >= 0),
+    retweet_count bigint NOT NULL CHECK (retweet_count >= 0),
+    reply_count bigint NOT NULL CHECK (reply_count >= 0),
+    quote_count bigint NOT NULL CHECK (quote_count >= 0),
+    view_count bigint NOT NULL CHECK (view_count >= 0),
+    bookmark_count bigint NOT NULL CHECK (bookmark_count >= 0),
+    PRIMARY KEY (x_post_id, captured_at)
+);
+
+-- Create global table: post_revisions (no row level security)
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id bigserial PRIMARY KEY,
+    x_post_id bigint NOT NULL CHECK (x_post_id > 0),
+    previous_text text NOT NULL,
+    text text NOT NULL,
+    detected_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_post_revisions_post_detected ON post_revisions (x_post_id, detected_at DESC);
+
 -- Create user-scoped table: qa_messages
 CREATE TABLE IF NOT EXISTS qa_messages (
     id char(26) PRIMARY KEY,
@@ -120,6 +271,8 @@ CREATE TABLE IF NOT EXISTS qa_messages (
     answer text NOT NULL,
     date_from timestamptz NOT NULL,
     date_to timestamptz NOT NULL,
+    list_id bigint CHECK (list_id > 0),
+    source text CHECK (source IN ('feed','mention')),
     created_at timestamptz NOT NULL
 );
 
@@ -136,19 +289,197 @@ CREATE TABLE IF NOT EXISTS qa_sources (
     FOREIGN KEY (user_id, x_post_id) REFERENCES posts(user_id, x_post_id) ON DELETE CASCADE
 );
 
+-- Create user-scoped table: feed_lists
+CREATE TABLE IF NOT EXISTS feed_lists (
+    user_id uuid NOT NULL,
+    x_list_id bigint NOT NULL CHECK (x_list_id > 0),
+    name text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    last_synced_at timestamptz,
+    PRIMARY KEY (user_id, x_list_id)
+);
+
+-- Create user-scoped table: feed_list_members
+CREATE TABLE IF NOT EXISTS feed_list_members (
+    user_id uuid NOT NULL,
+    x_list_id bigint NOT NULL,
+    x_author_id bigint NOT NULL REFERENCES authors(x_author_id) ON DELETE CASCADE,
+    added_at timestamptz NOT NULL DEFAULT now(),
+    excluded_at timestamptz,
+    sampled_at timestamptz,
+    PRIMARY KEY (user_id, x_list_id, x_author_id),
+    FOREIGN KEY (user_id, x_list_id) REFERENCES feed_lists(user_id, x_list_id) ON DELETE CASCADE
+);
+
+-- Create index for feed_list_members on (user_id, x_author_id)
+CREATE INDEX IF NOT EXISTS idx_feed_list_members_user_author ON feed_list_members (user_id, x_author_id);
+
+-- Create user-scoped junction table: post_lists
+CREATE TABLE IF NOT EXISTS post_lists (
+    user_id uuid NOT NULL,
+    x_post_id bigint NOT NULL,
+    x_list_id bigint NOT NULL,
+    PRIMARY KEY (user_id, x_list_id, x_post_id),
+    FOREIGN KEY (user_id, x_post_id) REFERENCES posts(user_id, x_post_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id, x_list_id) REFERENCES feed_lists(user_id, x_list_id) ON DELETE CASCADE
+);
+
+-- Create index for post_lists on (user_id, x_post_id)
+CREATE INDEX IF NOT EXISTS idx_post_lists_user_post ON post_lists (user_id, x_post_id);
+
+-- Create user-scoped table: search_sources
+CREATE TABLE IF NOT EXISTS search_sources (
+    id text PRIMARY KEY,
+    user_id uuid NOT NULL,
+    query text NOT NULL CHECK (length(query) BETWEEN 1 AND 512),
+    name text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    last_run_at timestamptz,
+    last_tweet_id bigint,
+    UNIQUE (user_id, query)
+);
+
+-- Create user-scoped junction table: post_search_sources
+CREATE TABLE IF NOT EXISTS post_search_sources (
+    user_id uuid NOT NULL,
+    x_post_id bigint NOT NULL,
+    search_source_id text NOT NULL REFERENCES search_sources(id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, search_source_id, x_post_id),
+    FOREIGN KEY (user_id, x_post_id) REFERENCES posts(user_id, x_post_id) ON DELETE CASCADE
+);
+
+-- Create index for post_search_sources on (user_id, x_post_id)
+CREATE INDEX IF NOT EXISTS idx_post_search_sources_user_post ON post_search_sources (user_id, x_post_id);
+
+-- Create user-scoped table: post_articles
+CREATE TABLE IF NOT EXISTS post_articles (
+    user_id uuid NOT NULL,
+    x_post_id bigint NOT NULL,
+    title text,
+    chunk_count integer NOT NULL CHECK (chunk_count >= 0),
+    fetched_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, x_post_id),
+    FOREIGN KEY (user_id, x_post_id) REFERENCES posts(user_id, x_post_id) ON DELETE CASCADE
+);
+
+-- Create user-scoped table: post_article_chunks
+CREATE TABLE IF NOT EXISTS post_article_chunks (
+    user_id uuid NOT NULL,
+    x_post_id bigint NOT NULL,
+    chunk_index integer NOT NULL CHECK (chunk_index >= 0),
+    text text NOT NULL,
+    PRIMARY KEY (user_id, x_post_id, chunk_index),
+    FOREIGN KEY (user_id, x_post_id) REFERENCES post_articles(user_id, x_post_id) ON DELETE CASCADE
+);
+
+-- Create user-scoped table: post_media
+CREATE TABLE IF NOT EXISTS post_media (
+    user_id uuid NOT NULL,
+    x_post_id bigint NOT NULL,
+    media_index integer NOT NULL CHECK (media_index >= 0),
+    media_type text NOT NULL CHECK (media_type IN ('image', 'video')),
+    url text NOT NULL,
+    url_hash text NOT NULL,
+    duration_ms integer,
+    description text,
+    model text,
+    status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'described', 'failed', 'skipped')),
+    error text,
+    attempts integer NOT NULL DEFAULT 0,
+    described_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, x_post_id, media_index),
+    FOREIGN KEY (user_id, x_post_id) REFERENCES posts(user_id, x_post_id) ON DELETE CASCADE
+);
+
+-- Create index for post_media on url_hash
+CREATE INDEX IF NOT EXISTS idx_post_media_url_hash ON post_media (url_hash);
+
+-- Create partial index for post_media awaiting a description
+CREATE INDEX IF NOT EXISTS idx_post_media_reprocess ON post_media (created_at) WHERE status IN ('pending', 'failed');
+
+-- Create user-scoped table: post_links
+CREATE TABLE IF NOT EXISTS post_links (
+    user_id uuid NOT NULL,
+    x_post_id bigint NOT NULL,
+    url text NOT NULL,
+    final_url text NOT NULL,
+    title text,
+    summary text,
+    content text NOT NULL,
+    fetched_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, x_post_id, url),
+    FOREIGN KEY (user_id, x_post_id) REFERENCES posts(user_id, x_post_id) ON DELETE CASCADE
+);
+
+-- Create system table: media_description_cache (no row level security)
+CREATE TABLE IF NOT EXISTS media_description_cache (
+    cache_key text NOT NULL,
+    model text NOT NULL,
+    description text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (cache_key, model)
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_description_cache_expires ON media_description_cache (expires_at);
+
+-- Create system table: timeline_fetches (no row level security)
+CREATE TABLE IF NOT EXISTS timeline_fetches (
+    x_author_id bigint NOT NULL,
+    page_cursor text NOT NULL DEFAULT '',
+    cycle_start timestamptz NOT NULL,
+    status text NOT NULL DEFAULT 'fetching' CHECK (status IN ('fetching','done','failed')),
+    claim_id char(26) NOT NULL,
+    claimed_until timestamptz NOT NULL,
+    response jsonb,
+    error text,
+    error_status integer,
+    fetched_at timestamptz,
+    PRIMARY KEY (x_author_id, page_cursor, cycle_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_timeline_fetches_cycle ON timeline_fetches (cycle_start);
+
 -- Enable row-level security and create policies for user-scoped tables
 ALTER TABLE user_following ENABLE ROW LEVEL SECURITY;
 ALTER TABLE ingest_runs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ingest_run_errors ENABLE ROW LEVEL SECURITY;
+ALTER TABLE author_watermarks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE following_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ingest_policies ENABLE ROW LEVEL SECURITY;
 ALTER TABLE posts ENABLE ROW LEVEL SECURITY;
 ALTER TABLE qa_messages ENABLE ROW LEVEL SECURITY;
 ALTER TABLE qa_sources ENABLE ROW LEVEL SECURITY;
+ALTER TABLE feed_lists ENABLE ROW LEVEL SECURITY;
+ALTER TABLE feed_list_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_lists ENABLE ROW LEVEL SECURITY;
+ALTER TABLE search_sources ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_search_sources ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_articles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_article_chunks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_links ENABLE ROW LEVEL SECURITY;
+ALTER TABLE post_media ENABLE ROW LEVEL SECURITY;
 
 -- Drop existing policies if they exist
 DROP POLICY IF EXISTS user_isolation_user_following ON user_following;
 DROP POLICY IF EXISTS user_isolation_ingest_runs ON ingest_runs;
+DROP POLICY IF EXISTS user_isolation_ingest_run_errors ON ingest_run_errors;
+DROP POLICY IF EXISTS user_isolation_author_watermarks ON author_watermarks;
+DROP POLICY IF EXISTS user_isolation_following_events ON following_events;
+DROP POLICY IF EXISTS user_isolation_ingest_policies ON ingest_policies;
 DROP POLICY IF EXISTS user_isolation_posts ON posts;
 DROP POLICY IF EXISTS user_isolation_qa_messages ON qa_messages;
 DROP POLICY IF EXISTS user_isolation_qa_sources ON qa_sources;
+DROP POLICY IF EXISTS user_isolation_feed_lists ON feed_lists;
+DROP POLICY IF EXISTS user_isolation_feed_list_members ON feed_list_members;
+DROP POLICY IF EXISTS user_isolation_post_lists ON post_lists;
+DROP POLICY IF EXISTS user_isolation_search_sources ON search_sources;
+DROP POLICY IF EXISTS user_isolation_post_search_sources ON post_search_sources;
+DROP POLICY IF EXISTS user_isolation_post_articles ON post_articles;
+DROP POLICY IF EXISTS user_isolation_post_article_chunks ON post_article_chunks;
+DROP POLICY IF EXISTS user_isolation_post_links ON post_links;
+DROP POLICY IF EXISTS user_isolation_post_media ON post_media;
 
 -- Create policies for user-scoped tables
 CREATE POLICY user_isolation_user_following ON user_following
@@ -157,6 +488,18 @@ CREATE POLICY user_isolation_user_following ON user_following
 CREATE POLICY user_isolation_ingest_runs ON ingest_runs
     USING (user_id = current_setting('app.user_id', true)::uuid);
 
+CREATE POLICY user_isolation_ingest_run_errors ON ingest_run_errors
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_author_watermarks ON author_watermarks
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_following_events ON following_events
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_ingest_policies ON ingest_policies
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
 CREATE POLICY user_isolation_posts ON posts
     USING (user_id = current_setting('app.user_id', true)::uuid);
 
@@ -165,6 +508,33 @@ CREATE POLICY user_isolation_qa_messages ON qa_messages
 
 CREATE POLICY user_isolation_qa_sources ON qa_sources
     USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_feed_lists ON feed_lists
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_feed_list_members ON feed_list_members
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_post_lists ON post_lists
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_search_sources ON search_sources
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_post_search_sources ON post_search_sources
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_post_articles ON post_articles
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_post_article_chunks ON post_article_chunks
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_post_links ON post_links
+    USING (user_id = current_setting('app.user_id', true)::uuid);
+
+CREATE POLICY user_isolation_post_media ON post_media
+    USING (user_id = current_setting('app.user_id', true)::uuid);
 `
 
 	_, err := dh.db.Exec(migrationSQL)
@@ -177,7 +547,7 @@ CREATE POLICY user_isolation_qa_sources ON qa_sources
 func (dh *DatabaseHelper) CleanupTestData(t *testing.T) {
 	t.Helper()
 
-	_, err := dh.db.Exec("TRUNCATE TABLE qa_sources, qa_messages, posts, ingest_runs, authors CASCADE")
+	_, err := dh.db.Exec("TRUNCATE TABLE timeline_fetches, media_description_cache, qa_sources, qa_messages, post_search_sources, search_sources, post_lists, post_media, post_links, post_article_chunks, post_articles, feed_list_members, feed_lists, post_revisions, post_engagement, posts, following_events, author_watermarks, ingest_run_errors, ingest_jobs, ingest_runs, ingest_policies, authors CASCADE")
 	if err != nil {
 		t.Fatalf("Failed to cleanup test data: %v", err)
 	}
@@ -0,0 +1,280 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+)
+
+// TestFeedListsIntegration tests the list endpoints, list member sync and list-scoped posts
+func TestFeedListsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("ListEndpoints", func(t *testing.T) {
+		testListEndpoints(t, dbHelper)
+	})
+
+	t.Run("ListMembersAndPosts", func(t *testing.T) {
+		testListMembersAndPosts(t, dbHelper)
+	})
+
+	t.Run("ListAuthorLimit", func(t *testing.T) {
+		testListAuthorLimit(t, dbHelper)
+	})
+}
+
+// testListEndpoints tests registering, listing and removing lists
+func testListEndpoints(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	router := NewTestRouter(dbHelper.GetDB()).GetEngine()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	doRequest := func(method, path string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("X-Test-User-ID", userID.String())
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		w := doRequest("POST", "/api/v1/lists", []byte(`{"x_list_id": 1500, "name": "Macro"}`))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		w = doRequest("POST", "/api/v1/lists", []byte(`{"x_list_id": 1500}`))
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 for a list added twice, got %d", w.Code)
+		}
+
+		w = doRequest("POST", "/api/v1/lists", []byte(`{"x_list_id": 0}`))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for an invalid list ID, got %d", w.Code)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		w := doRequest("GET", "/api/v1/lists", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var response dto.FeedListsResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if len(response.Items) != 1 || response.Items[0].XListID != 1500 || response.Items[0].Name != "Macro" {
+			t.Errorf("Expected list 1500 named Macro, got %+v", response.Items)
+		}
+		if response.Items[0].LastSyncedAt != nil {
+			t.Error("Expected list not to be synced yet")
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		w := doRequest("DELETE", "/api/v1/lists/1500", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		w = doRequest("DELETE", "/api/v1/lists/1500", nil)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for a removed list, got %d", w.Code)
+		}
+	})
+}
+
+// testListMembersAndPosts tests that list members are synced and posts are scoped to the list they came from
+func testListMembersAndPosts(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	feedListRepo := repositories.NewFeedListRepository(database)
+	postRepo := repositories.NewPostRepository(database)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now().UTC()
+
+	listID := int64(1500)
+	listedID := int64(111)
+	followedID := int64(222)
+	dataHelper.InsertAuthor(t, listedID, "listed", StringPtr("Listed"), nil)
+	dataHelper.InsertAuthor(t, followedID, "followed", StringPtr("Followed"), nil)
+
+	if _, err := feedListRepo.InsertFeedList(ctx, userID, listID, nil); err != nil {
+		t.Fatalf("InsertFeedList failed: %v", err)
+	}
+
+	// A partial sync adds members, a complete one also removes the missing ones
+	if err := feedListRepo.SyncListMembers(ctx, userID, listID, []int64{listedID, followedID}, false, now); err != nil {
+		t.Fatalf("SyncListMembers failed: %v", err)
+	}
+	if err := feedListRepo.SyncListMembers(ctx, userID, listID, []int64{listedID}, true, now); err != nil {
+		t.Fatalf("SyncListMembers failed: %v", err)
+	}
+
+	authors, err := feedListRepo.GetListAuthors(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetListAuthors failed: %v", err)
+	}
+	if len(authors) != 1 || authors[0].XAuthorID != listedID || len(authors[0].ListIDs) != 1 || authors[0].ListIDs[0] != listID {
+		t.Fatalf("Expected @listed in list %d, got %+v", listID, authors)
+	}
+
+	for i, authorID := range []int64{listedID, followedID} {
+		postID := int64(6001 + i)
+		err := postRepo.InsertPost(ctx, userID, &dto.TweetDTO{
+			ID:          postID,
+			AuthorID:    authorID,
+			Text:        fmt.Sprintf("post %d", postID),
+			RawText:     fmt.Sprintf("post %d", postID),
+			URL:         fmt.Sprintf("https://x.com/author/status/%d", postID),
+			PublishedAt: now.Add(-time.Duration(i+1) * time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("InsertPost failed: %v", err)
+		}
+	}
+	if err := feedListRepo.TagPost(ctx, userID, 6001, []int64{listID}); err != nil {
+		t.Fatalf("TagPost failed: %v", err)
+	}
+
+	all, err := postRepo.GetPostsByDateRange(ctx, userID, now.Add(-24*time.Hour), now, nil, "")
+	if err != nil {
+		t.Fatalf("GetPostsByDateRange failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("Expected 2 posts without a list scope, got %d", len(all))
+	}
+
+	scoped, err := postRepo.GetPostsByDateRange(ctx, userID, now.Add(-24*time.Hour), now, &listID, "")
+	if err != nil {
+		t.Fatalf("GetPostsByDateRange failed: %v", err)
+	}
+	if len(scoped) != 1 || scoped[0].XPostID != 6001 {
+		t.Errorf("Expected only post 6001 in the list scope, got %d posts", len(scoped))
+	}
+}
+
+// excludedListAuthorIDs returns the listed authors excluded by the user's list author limit
+func excludedListAuthorIDs(t *testing.T, db *sqlx.DB, userID uuid.UUID) []int64 {
+	t.Helper()
+
+	var ids []int64
+	err := db.Select(&ids, "SELECT DISTINCT x_author_id FROM feed_list_members WHERE user_id = $1 AND excluded_at IS NOT NULL ORDER BY x_author_id", userID)
+	if err != nil {
+		t.Fatalf("Failed to get excluded list authors: %v", err)
+	}
+	return ids
+}
+
+// testListAuthorLimit tests that only the most active listed authors are ingested, that followed authors
+// do not count against the limit and that the sampled place rotates among the excluded authors
+func testListAuthorLimit(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	feedListRepo := repositories.NewFeedListRepository(database)
+	userRepo := repositories.NewUserRepository(database)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now().UTC()
+
+	// Authors 301 to 306 were last seen 1 to 6 hours ago; author 307 is also followed
+	for i := int64(1); i <= 7; i++ {
+		lastSeenAt := now.Add(-time.Duration(i) * time.Hour)
+		dataHelper.InsertAuthor(t, 300+i, fmt.Sprintf("listed_%d", i), nil, &lastSeenAt)
+	}
+	dataHelper.InsertUserFollowing(t, userID, 307, &now)
+
+	// Author 303 is in both lists
+	members := map[int64][]int64{
+		1501: {301, 302, 303, 307},
+		1502: {303, 304, 305, 306},
+	}
+	for _, listID := range []int64{1501, 1502} {
+		if _, err := feedListRepo.InsertFeedList(ctx, userID, listID, nil); err != nil {
+			t.Fatalf("InsertFeedList failed: %v", err)
+		}
+		if err := feedListRepo.SyncListMembers(ctx, userID, listID, members[listID], true, now); err != nil {
+			t.Fatalf("SyncListMembers failed: %v", err)
+		}
+	}
+
+	excluded, err := feedListRepo.ApplyListAuthorLimit(ctx, userID, 3, 0, now)
+	if err != nil {
+		t.Fatalf("ApplyListAuthorLimit failed: %v", err)
+	}
+	if excluded != 3 {
+		t.Errorf("Expected 3 listed authors excluded, got %d", excluded)
+	}
+	if ids := excludedListAuthorIDs(t, database, userID); !slices.Equal(ids, []int64{304, 305, 306}) {
+		t.Errorf("Expected the least active authors 304 to 306 excluded, got %v", ids)
+	}
+
+	authors, err := feedListRepo.GetListAuthors(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetListAuthors failed: %v", err)
+	}
+	var authorIDs []int64
+	for _, author := range authors {
+		authorIDs = append(authorIDs, author.XAuthorID)
+	}
+	if !slices.Equal(authorIDs, []int64{307, 303, 302, 301}) {
+		t.Errorf("Expected the followed author and the 3 most active listed authors, got %v", authorIDs)
+	}
+
+	lists, err := feedListRepo.GetFeedLists(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetFeedLists failed: %v", err)
+	}
+	if len(lists) != 2 || lists[0].ExcludedMemberCount != 0 || lists[1].MemberCount != 4 || lists[1].ExcludedMemberCount != 3 {
+		t.Errorf("Expected 3 of the second list's 4 members excluded, got %+v", lists)
+	}
+
+	count, err := userRepo.GetExcludedListAuthorCount(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetExcludedListAuthorCount failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 excluded list authors, got %d", count)
+	}
+
+	// With one sampled place, the third place goes to each excluded author in turn
+	expected := [][]int64{
+		{304, 305, 306},
+		{303, 305, 306},
+		{303, 304, 306},
+		{303, 304, 305},
+	}
+	for i, want := range expected {
+		if _, err := feedListRepo.ApplyListAuthorLimit(ctx, userID, 3, 1, now.Add(time.Duration(i+1)*time.Minute)); err != nil {
+			t.Fatalf("ApplyListAuthorLimit failed: %v", err)
+		}
+		if ids := excludedListAuthorIDs(t, database, userID); !slices.Equal(ids, want) {
+			t.Errorf("Sync %d: expected excluded authors %v, got %v", i+1, want, ids)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/internal/services"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+)
+
+const (
+	sharedAuthorID   = int64(501)
+	unsharedAuthorID = int64(502)
+)
+
+// timelineFixture serves author timelines and counts the requests per author
+// Requests for failing_author block until release is closed and are then rejected with 400
+type timelineFixture struct {
+	mu       sync.Mutex
+	requests map[string]int
+	started  chan struct{}
+	release  chan struct{}
+}
+
+func (f *timelineFixture) count(username string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[username]
+}
+
+// newTimelineFixtureServer starts the fixture and returns a Twitter client using it
+func newTimelineFixtureServer(t *testing.T) (*timelineFixture, *services.TwitterClient) {
+	t.Helper()
+
+	fixture := &timelineFixture{
+		requests: map[string]int{},
+		started:  make(chan struct{}, 10),
+		release:  make(chan struct{}),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username := r.URL.Query().Get("userName")
+		fixture.mu.Lock()
+		fixture.requests[username]++
+		fixture.mu.Unlock()
+
+		if username == "failing_author" {
+			fixture.started <- struct{}{}
+			<-fixture.release
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","msg":"user suspended"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"data":{"tweets":[{"id":"9001","text":"hello from %s","createdAt":"Mon Jan 02 15:04:05 +0000 2006","author":{"id":"1","userName":%q}}]},"has_next_page":true,"next_cursor":"page-2","status":"success"}`,
+			username, username)
+	}))
+	t.Cleanup(server.Close)
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+	return fixture, client
+}
+
+// newTestFetchPlanner creates a planner with a day-long cycle that polls quickly
+func newTestFetchPlanner(database *sqlx.DB, client *services.TwitterClient) *services.FetchPlanner {
+	config := services.DefaultFetchPlannerConfig()
+	config.Cycle = 24 * time.Hour
+	config.PollInterval = 10 * time.Millisecond
+	return services.NewFetchPlanner(client, repositories.NewFollowingRepository(database),
+		repositories.NewTimelineFetchRepository(database), config)
+}
+
+// insertFetchPlannerAuthors inserts an author followed by two users and one followed by a single user
+func insertFetchPlannerAuthors(t *testing.T, database *sqlx.DB) {
+	t.Helper()
+
+	dataHelper := NewTestDataHelper(database)
+	first := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	second := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	now := time.Now().UTC()
+
+	dataHelper.InsertAuthor(t, sharedAuthorID, "shared_author", nil, nil)
+	dataHelper.InsertAuthor(t, unsharedAuthorID, "unshared_author", nil, nil)
+	dataHelper.InsertUserFollowing(t, first, sharedAuthorID, &now)
+	dataHelper.InsertUserFollowing(t, second, sharedAuthorID, &now)
+	dataHelper.InsertUserFollowing(t, first, unsharedAuthorID, &now)
+}
+
+// countTimelineFetches returns the number of stored fetches of an author
+func countTimelineFetches(t *testing.T, database *sqlx.DB, authorID int64) int {
+	t.Helper()
+
+	var count int
+	if err := database.Get(&count, "SELECT COUNT(*) FROM timeline_fetches WHERE x_author_id = $1", authorID); err != nil {
+		t.Fatalf("Failed to count timeline fetches: %v", err)
+	}
+	return count
+}
+
+// TestFetchPlannerIntegration tests sharing author timeline fetches between users and replicas
+func TestFetchPlannerIntegration(t *testing.T) {
+	logger.Init(slog.LevelInfo)
+
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("SharedAndUnsharedAuthors", func(t *testing.T) {
+		testFetchPlannerSharedAuthors(t, dbHelper)
+	})
+
+	t.Run("CycleExpiry", func(t *testing.T) {
+		testFetchPlannerCycleExpiry(t, dbHelper)
+	})
+
+	t.Run("FetchErrorReachesWaiters", func(t *testing.T) {
+		testFetchPlannerFetchError(t, dbHelper)
+	})
+}
+
+// testFetchPlannerSharedAuthors tests that a shared author's page is fetched once for all replicas
+// and that an author with one follower is fetched directly every time
+func testFetchPlannerSharedAuthors(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	insertFetchPlannerAuthors(t, database)
+	fixture, client := newTimelineFixtureServer(t)
+	ctx := context.Background()
+
+	// Two planners stand for two replicas
+	replicas := []*services.FetchPlanner{newTestFetchPlanner(database, client), newTestFetchPlanner(database, client)}
+	for i, planner := range append(replicas, replicas[0]) {
+		resp, err := planner.GetAuthorTweets(ctx, sharedAuthorID, "shared_author", "")
+		if err != nil {
+			t.Fatalf("GetAuthorTweets %d failed: %v", i, err)
+		}
+		if len(resp.Tweets) != 1 || resp.Tweets[0].Text != "hello from shared_author" || !resp.HasNextPage || resp.NextCursor != "page-2" {
+			t.Errorf("GetAuthorTweets %d: unexpected page %+v", i, resp)
+		}
+	}
+	if requests := fixture.count("shared_author"); requests != 1 {
+		t.Errorf("Expected the shared author to be fetched once, got %d requests", requests)
+	}
+
+	// Another page of the same author is a separate fetch
+	if _, err := replicas[1].GetAuthorTweets(ctx, sharedAuthorID, "shared_author", "page-2"); err != nil {
+		t.Fatalf("GetAuthorTweets failed: %v", err)
+	}
+	if requests := fixture.count("shared_author"); requests != 2 {
+		t.Errorf("Expected the second page to be fetched, got %d requests", requests)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := replicas[i].GetAuthorTweets(ctx, unsharedAuthorID, "unshared_author", ""); err != nil {
+			t.Fatalf("GetAuthorTweets failed: %v", err)
+		}
+	}
+	if requests := fixture.count("unshared_author"); requests != 2 {
+		t.Errorf("Expected the unshared author to be fetched every time, got %d requests", requests)
+	}
+	if count := countTimelineFetches(t, database, unsharedAuthorID); count != 0 {
+		t.Errorf("Expected no stored fetches of the unshared author, got %d", count)
+	}
+}
+
+// testFetchPlannerCycleExpiry tests that a page fetched in a past cycle is fetched again and then deleted
+func testFetchPlannerCycleExpiry(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	insertFetchPlannerAuthors(t, database)
+	fixture, client := newTimelineFixtureServer(t)
+	ctx := context.Background()
+
+	planner := newTestFetchPlanner(database, client)
+	if _, err := planner.GetAuthorTweets(ctx, sharedAuthorID, "shared_author", ""); err != nil {
+		t.Fatalf("GetAuthorTweets failed: %v", err)
+	}
+
+	// Move the stored fetch into the previous cycle
+	if _, err := database.Exec("UPDATE timeline_fetches SET cycle_start = cycle_start - INTERVAL '24 hours'"); err != nil {
+		t.Fatalf("Failed to age timeline fetches: %v", err)
+	}
+
+	if _, err := planner.GetAuthorTweets(ctx, sharedAuthorID, "shared_author", ""); err != nil {
+		t.Fatalf("GetAuthorTweets failed: %v", err)
+	}
+	if requests := fixture.count("shared_author"); requests != 2 {
+		t.Errorf("Expected the page of the past cycle to be fetched again, got %d requests", requests)
+	}
+
+	// A planner starting the cycle deletes the fetches of past cycles
+	if _, err := newTestFetchPlanner(database, client).GetAuthorTweets(ctx, sharedAuthorID, "shared_author", ""); err != nil {
+		t.Fatalf("GetAuthorTweets failed: %v", err)
+	}
+	if count := countTimelineFetches(t, database, sharedAuthorID); count != 1 {
+		t.Errorf("Expected only the fetch of the current cycle to be kept, got %d", count)
+	}
+	if requests := fixture.count("shared_author"); requests != 2 {
+		t.Errorf("Expected the current cycle's page to be reused, got %d requests", requests)
+	}
+}
+
+// testFetchPlannerFetchError tests that a failed fetch reaches every run waiting for it, in this process
+// and another replica, and that a later run fetches the page again
+func testFetchPlannerFetchError(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	insertFetchPlannerAuthors(t, database)
+	if _, err := database.Exec("UPDATE authors SET handle = 'failing_author' WHERE x_author_id = $1", sharedAuthorID); err != nil {
+		t.Fatalf("Failed to rename author: %v", err)
+	}
+	fixture, client := newTimelineFixtureServer(t)
+	ctx := context.Background()
+
+	replicas := []*services.FetchPlanner{newTestFetchPlanner(database, client), newTestFetchPlanner(database, client)}
+	errs := make([]error, 3)
+	var wg sync.WaitGroup
+	fetch := func(i int, planner *services.FetchPlanner) {
+		defer wg.Done()
+		_, errs[i] = planner.GetAuthorTweets(ctx, sharedAuthorID, "failing_author", "")
+	}
+
+	wg.Add(1)
+	go fetch(0, replicas[0])
+	select {
+	case <-fixture.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the first run to fetch the page")
+	}
+
+	// The other followers' runs wait for the fetch in progress
+	wg.Add(2)
+	go fetch(1, replicas[0])
+	go fetch(2, replicas[1])
+	time.Sleep(200 * time.Millisecond)
+	close(fixture.release)
+	wg.Wait()
+
+	for i, err := range errs {
+		var apiErr *services.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("Run %d: expected the API error with status 400, got %v", i, err)
+		}
+	}
+	if requests := fixture.count("failing_author"); requests != 1 {
+		t.Errorf("Expected one request for all waiting runs, got %d", requests)
+	}
+
+	// A run starting after the failure fetches again
+	if _, err := replicas[1].GetAuthorTweets(ctx, sharedAuthorID, "failing_author", ""); err == nil {
+		t.Error("Expected the fetch to fail again")
+	}
+	if requests := fixture.count("failing_author"); requests != 2 {
+		t.Errorf("Expected a later run to fetch again, got %d requests", requests)
+	}
+}
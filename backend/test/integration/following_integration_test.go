@@ -1,9 +1,12 @@
 package integration
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
 	"testing"
 	"time"
 
@@ -11,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
 )
 
 // TestFollowingIntegration contains all integration tests for the following endpoint
@@ -40,6 +44,18 @@ func TestFollowingIntegration(t *testing.T) {
 	t.Run("MultipleUsers", func(t *testing.T) {
 		testFollowingMultipleUsers(t, dbHelper)
 	})
+
+	t.Run("UnfollowReconciliation", func(t *testing.T) {
+		testFollowingUnfollowReconciliation(t, dbHelper)
+	})
+
+	t.Run("FollowingLimit", func(t *testing.T) {
+		testFollowingLimit(t, dbHelper)
+	})
+
+	t.Run("FollowingLimitSampling", func(t *testing.T) {
+		testFollowingLimitSampling(t, dbHelper)
+	})
 }
 
 // testFollowingHappyPath tests the happy path scenario with following data
@@ -295,3 +311,289 @@ func testFollowingMultipleUsers(t *testing.T, dbHelper *DatabaseHelper) {
 		}
 	})
 }
+
+// testFollowingUnfollowReconciliation tests that unfollowed authors are soft-deactivated and events are listed
+func testFollowingUnfollowReconciliation(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	db := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(db)
+	followingRepo := repositories.NewFollowingRepository(db)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now().UTC()
+
+	keptID := int64(111111111)
+	droppedID := int64(222222222)
+	dataHelper.InsertAuthor(t, keptID, "@kept", StringPtr("Kept"), nil)
+	dataHelper.InsertAuthor(t, droppedID, "@dropped", StringPtr("Dropped"), nil)
+
+	// First sync sees both authors as new follows
+	for _, authorID := range []int64{keptID, droppedID} {
+		isNew, err := followingRepo.UpsertFollowing(ctx, userID, authorID, now)
+		if err != nil {
+			t.Fatalf("UpsertFollowing failed: %v", err)
+		}
+		if !isNew {
+			t.Errorf("Expected author %d to be a new follow", authorID)
+		}
+	}
+
+	// Second sync only sees the kept author
+	isNew, err := followingRepo.UpsertFollowing(ctx, userID, keptID, now)
+	if err != nil {
+		t.Fatalf("UpsertFollowing failed: %v", err)
+	}
+	if isNew {
+		t.Error("Expected an already followed author not to be a new follow")
+	}
+
+	unfollowed, err := followingRepo.DeactivateUnfollowed(ctx, userID, []int64{keptID}, now)
+	if err != nil {
+		t.Fatalf("DeactivateUnfollowed failed: %v", err)
+	}
+	if len(unfollowed) != 1 || unfollowed[0] != droppedID {
+		t.Fatalf("Expected only author %d to be unfollowed, got %v", droppedID, unfollowed)
+	}
+	runID := dataHelper.InsertIngestRun(t, userID, now, &now, "ok", 0, 0, 0, nil)
+	if err := followingRepo.InsertFollowingEvents(ctx, userID, runID, "unfollow", unfollowed); err != nil {
+		t.Fatalf("InsertFollowingEvents failed: %v", err)
+	}
+
+	router := NewTestRouter(db).GetEngine()
+
+	t.Run("UnfollowedAuthorHidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/following", nil)
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("X-Test-User-ID", userID.String())
+
+		router.ServeHTTP(w, req)
+
+		var response dto.FollowingListResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if len(response.Items) != 1 || response.Items[0].XAuthorID != keptID {
+			t.Errorf("Expected only author %d to be listed, got %+v", keptID, response.Items)
+		}
+	})
+
+	t.Run("EventsListed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/following/events", nil)
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("X-Test-User-ID", userID.String())
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var response dto.FollowingEventsResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if len(response.Items) != 1 {
+			t.Fatalf("Expected 1 event, got %d", len(response.Items))
+		}
+		event := response.Items[0]
+		if event.Event != "unfollow" || event.XAuthorID != droppedID || event.IngestRunID != runID {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+	})
+
+	// Following the author again reactivates it
+	isNew, err = followingRepo.UpsertFollowing(ctx, userID, droppedID, now)
+	if err != nil {
+		t.Fatalf("UpsertFollowing failed: %v", err)
+	}
+	if !isNew {
+		t.Error("Expected a previously unfollowed author to be a new follow")
+	}
+}
+
+// testFollowingLimit tests that pinned and most active authors are kept within the following limit
+func testFollowingLimit(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	db := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(db)
+	followingRepo := repositories.NewFollowingRepository(db)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now().UTC()
+
+	activeID := int64(111111111)
+	quietID := int64(222222222)
+	pinnedID := int64(333333333)
+	lastSeenActive := now.Add(-1 * time.Hour)
+	lastSeenQuiet := now.Add(-2 * time.Hour)
+	lastSeenPinned := now.Add(-3 * time.Hour)
+
+	dataHelper.InsertAuthor(t, activeID, "@active", StringPtr("Active"), &lastSeenActive)
+	dataHelper.InsertAuthor(t, quietID, "@quiet", StringPtr("Quiet"), &lastSeenQuiet)
+	dataHelper.InsertAuthor(t, pinnedID, "@pinned", StringPtr("Pinned"), &lastSeenPinned)
+	dataHelper.InsertUserFollowing(t, userID, activeID, &now)
+	dataHelper.InsertUserFollowing(t, userID, quietID, &now)
+	dataHelper.InsertUserFollowing(t, userID, pinnedID, &now)
+
+	router := NewTestRouter(db).GetEngine()
+
+	t.Run("PinAuthor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/following/333333333/pin", nil)
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("X-Test-User-ID", userID.String())
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("PinUnknownAuthor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v1/following/444444444/pin", nil)
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("X-Test-User-ID", userID.String())
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d. Body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	excluded, err := followingRepo.ApplyFollowingLimit(ctx, userID, 2, 0, now)
+	if err != nil {
+		t.Fatalf("ApplyFollowingLimit failed: %v", err)
+	}
+	if excluded != 1 {
+		t.Fatalf("Expected 1 excluded author, got %d", excluded)
+	}
+
+	t.Run("LeastActiveExcluded", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/following", nil)
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("X-Test-User-ID", userID.String())
+
+		router.ServeHTTP(w, req)
+
+		var response dto.FollowingListResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if len(response.Items) != 3 {
+			t.Fatalf("Expected 3 items, got %d", len(response.Items))
+		}
+		for _, item := range response.Items {
+			if item.Excluded != (item.XAuthorID == quietID) {
+				t.Errorf("Unexpected excluded flag for author %d: %v", item.XAuthorID, item.Excluded)
+			}
+			if item.Pinned != (item.XAuthorID == pinnedID) {
+				t.Errorf("Unexpected pinned flag for author %d: %v", item.XAuthorID, item.Pinned)
+			}
+		}
+	})
+
+	// Raising the limit includes every author again
+	excluded, err = followingRepo.ApplyFollowingLimit(ctx, userID, 3, 0, now)
+	if err != nil {
+		t.Fatalf("ApplyFollowingLimit failed: %v", err)
+	}
+	if excluded != 0 {
+		t.Errorf("Expected no excluded authors, got %d", excluded)
+	}
+}
+
+// excludedAuthorIDs returns the IDs of the user's excluded authors in ascending order
+func excludedAuthorIDs(t *testing.T, db *sqlx.DB, userID uuid.UUID) []int64 {
+	t.Helper()
+
+	var ids []int64
+	err := db.Select(&ids, "SELECT x_author_id FROM user_following WHERE user_id = $1 AND excluded_at IS NOT NULL ORDER BY x_author_id", userID)
+	if err != nil {
+		t.Fatalf("Failed to get excluded authors: %v", err)
+	}
+	return ids
+}
+
+// testFollowingLimitSampling tests that the sampled places under the limit rotate among the excluded authors,
+// that an author without ingested tweets ranks last and that new activity ranks an excluded author back in
+func testFollowingLimitSampling(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	db := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(db)
+	followingRepo := repositories.NewFollowingRepository(db)
+	authorRepo := repositories.NewAuthorRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now().UTC()
+
+	// Authors 1 to 4 were last seen 1 to 4 hours ago; author 5 has no ingested tweets
+	for id := int64(1); id <= 5; id++ {
+		var lastSeen *time.Time
+		if id < 5 {
+			seen := now.Add(-time.Duration(id) * time.Hour)
+			lastSeen = &seen
+		}
+		dataHelper.InsertAuthor(t, id, "@author"+strconv.FormatInt(id, 10), nil, lastSeen)
+		dataHelper.InsertUserFollowing(t, userID, id, &now)
+	}
+
+	// Storing a known author again must not reset its activity
+	if _, err := authorRepo.InsertAuthor(ctx, &dto.UserDTO{ID: 1, Handle: "@author1"}); err != nil {
+		t.Fatalf("InsertAuthor failed: %v", err)
+	}
+
+	// Limit 3 with 1 sampled place: authors 1 and 2 are kept, the last place rotates among 3, 4 and 5
+	expected := [][]int64{{4, 5}, {3, 5}, {3, 4}, {4, 5}}
+	for sync, want := range expected {
+		syncedAt := now.Add(time.Duration(sync) * time.Minute)
+		excluded, err := followingRepo.ApplyFollowingLimit(ctx, userID, 3, 1, syncedAt)
+		if err != nil {
+			t.Fatalf("ApplyFollowingLimit failed: %v", err)
+		}
+		if excluded != 2 {
+			t.Errorf("Sync %d: expected 2 excluded authors, got %d", sync, excluded)
+		}
+		if got := excludedAuthorIDs(t, db, userID); !slices.Equal(got, want) {
+			t.Errorf("Sync %d: expected excluded authors %v, got %v", sync, want, got)
+		}
+	}
+
+	// A tweet of author 5 ranks it first and pushes author 2 into the sampled place
+	if err := authorRepo.UpdateAuthorLastSeen(ctx, 5, now); err != nil {
+		t.Fatalf("UpdateAuthorLastSeen failed: %v", err)
+	}
+	if _, err := followingRepo.ApplyFollowingLimit(ctx, userID, 3, 1, now.Add(time.Hour)); err != nil {
+		t.Fatalf("ApplyFollowingLimit failed: %v", err)
+	}
+	if got := excludedAuthorIDs(t, db, userID); !slices.Equal(got, []int64{3, 4}) {
+		t.Errorf("Expected authors 3 and 4 to be excluded, got %v", got)
+	}
+
+	t.Run("SessionListIsCapped", func(t *testing.T) {
+		count, err := userRepo.GetExcludedFollowingCount(ctx, userID)
+		if err != nil {
+			t.Fatalf("GetExcludedFollowingCount failed: %v", err)
+		}
+		if count != 2 {
+			t.Errorf("Expected 2 excluded authors, got %d", count)
+		}
+
+		items, err := userRepo.GetExcludedFollowing(ctx, userID, 1)
+		if err != nil {
+			t.Fatalf("GetExcludedFollowing failed: %v", err)
+		}
+		if len(items) != 1 || items[0].XAuthorID != 3 {
+			t.Errorf("Expected only the most active excluded author 3, got %+v", items)
+		}
+	})
+}
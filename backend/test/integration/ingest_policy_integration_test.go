@@ -0,0 +1,159 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+)
+
+// TestIngestPolicyIntegration tests the ingest policy endpoints and storage of retweeted and quoted posts
+func TestIngestPolicyIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("PolicyEndpoints", func(t *testing.T) {
+		testPolicyEndpoints(t, dbHelper)
+	})
+
+	t.Run("RepostedAndQuotedPosts", func(t *testing.T) {
+		testRepostedAndQuotedPosts(t, dbHelper)
+	})
+}
+
+// testPolicyEndpoints tests reading the defaults, replacing the policy and rejecting invalid languages
+func testPolicyEndpoints(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	router := NewTestRouter(dbHelper.GetDB()).GetEngine()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	doRequest := func(method string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, "/api/v1/ingest/policy", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("X-Test-User-ID", userID.String())
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		w := doRequest("GET", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var response dto.IngestPolicyDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if response.IncludeRetweets || response.IncludeQuotes || response.IncludeReplies {
+			t.Errorf("Expected everything but original posts to be excluded by default, got %+v", response)
+		}
+		if len(response.Languages) != 0 || response.UpdatedAt != nil {
+			t.Errorf("Expected no languages and no updated_at by default, got %+v", response)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		w := doRequest("PUT", []byte(`{"include_quotes": true, "languages": ["PL", "en", "pl"]}`))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		w = doRequest("GET", nil)
+		var response dto.IngestPolicyDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if !response.IncludeQuotes || response.IncludeRetweets || response.IncludeReplies {
+			t.Errorf("Expected only quotes to be included, got %+v", response)
+		}
+		if len(response.Languages) != 2 || response.Languages[0] != "pl" || response.Languages[1] != "en" {
+			t.Errorf("Expected languages [pl en], got %v", response.Languages)
+		}
+		if response.UpdatedAt == nil {
+			t.Error("Expected updated_at to be set")
+		}
+	})
+
+	t.Run("InvalidLanguage", func(t *testing.T) {
+		w := doRequest("PUT", []byte(`{"languages": ["english"]}`))
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d", w.Code)
+		}
+
+		var response dto.ErrorResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if response.Error.Code != "INVALID_LANGUAGE" {
+			t.Errorf("Expected error code INVALID_LANGUAGE, got %s", response.Error.Code)
+		}
+	})
+}
+
+// testRepostedAndQuotedPosts tests that retweets keep the original author and quoted posts are not Q&A content
+func testRepostedAndQuotedPosts(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	postRepo := repositories.NewPostRepository(database)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now().UTC()
+
+	followedID := int64(111)
+	originalID := int64(222)
+	dataHelper.InsertAuthor(t, followedID, "followed", StringPtr("Followed"), nil)
+	dataHelper.InsertAuthor(t, originalID, "original", StringPtr("Original"), nil)
+
+	posts := []*dto.TweetDTO{
+		{ID: 5001, AuthorID: originalID, Text: "retweeted", URL: "https://x.com/original/status/5001", PublishedAt: now.Add(-3 * time.Hour), Kind: db.PostKindRetweet, RepostedByID: followedID},
+		{ID: 5002, AuthorID: originalID, Text: "quoted", URL: "https://x.com/original/status/5002", PublishedAt: now.Add(-2 * time.Hour), Kind: db.PostKindQuoted},
+		{ID: 5003, AuthorID: followedID, Text: "comment", URL: "https://x.com/followed/status/5003", PublishedAt: now.Add(-time.Hour), Kind: db.PostKindQuote, QuotedPostID: 5002},
+	}
+	for _, post := range posts {
+		post.RawText = post.Text
+		if err := postRepo.InsertPost(ctx, userID, post); err != nil {
+			t.Fatalf("InsertPost failed: %v", err)
+		}
+	}
+
+	stored, err := postRepo.GetPostsByDateRange(ctx, userID, now.Add(-24*time.Hour), now, nil, "")
+	if err != nil {
+		t.Fatalf("GetPostsByDateRange failed: %v", err)
+	}
+	if len(stored) != 2 {
+		t.Fatalf("Expected the retweet and the quote tweet, got %d posts", len(stored))
+	}
+
+	byID := make(map[int64]db.PostWithAuthor)
+	for _, post := range stored {
+		byID[post.XPostID] = post
+	}
+
+	retweet := byID[5001]
+	if retweet.Handle != "original" || retweet.RepostedByHandle == nil || *retweet.RepostedByHandle != "followed" {
+		t.Errorf("Expected retweet by @original reposted by @followed, got @%s", retweet.Handle)
+	}
+
+	quote := byID[5003]
+	if quote.QuotedHandle == nil || *quote.QuotedHandle != "original" || quote.QuotedText == nil || *quote.QuotedText != "quoted" {
+		t.Errorf("Expected quote tweet to carry the quoted post of @original, got %+v", quote)
+	}
+}
@@ -0,0 +1,507 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/oklog/ulid/v2"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/internal/services"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+)
+
+// blockingUserRepository blocks user lookups of executing runs until their context ends
+// and reports the cause of the cancellation
+type blockingUserRepository struct {
+	repositories.UserRepository
+	started chan struct{}
+	stopped chan error
+}
+
+func (r blockingUserRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*db.User, error) {
+	r.started <- struct{}{}
+	<-ctx.Done()
+	r.stopped <- context.Cause(ctx)
+	return nil, ctx.Err()
+}
+
+// newTestIngestQueue creates a queue with one quickly polling worker, a short lease and a one minute base retry delay
+func newTestIngestQueue(database *sqlx.DB, userRepo repositories.UserRepository) *services.IngestQueue {
+	ingestRepo := repositories.NewIngestRepository(database)
+	followingRepo := repositories.NewFollowingRepository(database)
+	twitterClient := services.NewTwitterClient("", nil)
+	fetchPlanner := services.NewFetchPlanner(twitterClient, followingRepo, repositories.NewTimelineFetchRepository(database), services.DefaultFetchPlannerConfig())
+	ingestService := services.NewIngestService(twitterClient, fetchPlanner, nil, ingestRepo, followingRepo,
+		repositories.NewPostRepository(database), repositories.NewAuthorRepository(database),
+		repositories.NewWatermarkRepository(database), userRepo, repositories.NewIngestPolicyRepository(database),
+		repositories.NewFeedListRepository(database), repositories.NewSearchSourceRepository(database), nil, nil, nil)
+
+	config := services.DefaultIngestQueueConfig()
+	config.Workers = 1
+	config.PollInterval = 20 * time.Millisecond
+	config.LeaseDuration = 300 * time.Millisecond
+	config.MaxAttempts = 3
+	config.BaseRetryDelay = time.Minute
+	return services.NewIngestQueue(ingestService, repositories.NewIngestJobRepository(database), config)
+}
+
+// insertIngestJob inserts a job in the given state, bypassing EnqueueJob
+func insertIngestJob(t *testing.T, database *sqlx.DB, userID uuid.UUID, runID *string, status string, attempts int, lockedBy *string, lockedUntil *time.Time) string {
+	t.Helper()
+
+	id := ulid.Make().String()
+	query := `
+		INSERT INTO ingest_jobs (id, user_id, run_id, backfill_hours, status, attempts, max_attempts, run_after, locked_by, locked_until)
+		VALUES ($1, $2, $3, 24, $4, $5, 3, NOW(), $6, $7)
+	`
+	if _, err := database.Exec(query, id, userID, runID, status, attempts, lockedBy, lockedUntil); err != nil {
+		t.Fatalf("Failed to insert test ingest job: %v", err)
+	}
+	return id
+}
+
+// getIngestJob returns an ingest job by ID
+func getIngestJob(t *testing.T, database *sqlx.DB, jobID string) *db.IngestJob {
+	t.Helper()
+
+	var job db.IngestJob
+	if err := database.Get(&job, "SELECT * FROM ingest_jobs WHERE id = $1", jobID); err != nil {
+		t.Fatalf("Failed to get ingest job: %v", err)
+	}
+	return &job
+}
+
+// runIngestQueueUntil runs the queue workers until the job satisfies done
+func runIngestQueueUntil(t *testing.T, queue *services.IngestQueue, database *sqlx.DB, jobID string, done func(job *db.IngestJob) bool) *db.IngestJob {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		queue.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job := getIngestJob(t, database, jobID)
+		if done(job) {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for ingest job, last state: status %s, attempts %d", job.Status, job.Attempts)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// expectRetryAt checks that a job was requeued to run delay from now
+func expectRetryAt(t *testing.T, job *db.IngestJob, delay time.Duration) {
+	t.Helper()
+
+	until := time.Until(job.RunAfter)
+	if until < delay-10*time.Second || until > delay {
+		t.Errorf("Expected the job to be retried in %s, got run_after in %s", delay, until.Round(time.Second))
+	}
+}
+
+// TestIngestQueueIntegration tests the durable ingest job queue
+func TestIngestQueueIntegration(t *testing.T) {
+	logger.Init(slog.LevelInfo)
+
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("EnqueueDedupe", func(t *testing.T) {
+		testIngestJobEnqueueDedupe(t, dbHelper)
+	})
+
+	t.Run("ClaimAndLease", func(t *testing.T) {
+		testIngestJobClaimAndLease(t, dbHelper)
+	})
+
+	t.Run("ConcurrentClaims", func(t *testing.T) {
+		testIngestJobConcurrentClaims(t, dbHelper)
+	})
+
+	t.Run("RetryJob", func(t *testing.T) {
+		testIngestJobRetry(t, dbHelper)
+	})
+
+	t.Run("BackoffAndFinalFailure", func(t *testing.T) {
+		testIngestQueueBackoffAndFailure(t, dbHelper)
+	})
+
+	t.Run("MaxAttemptsExceeded", func(t *testing.T) {
+		testIngestQueueMaxAttemptsExceeded(t, dbHelper)
+	})
+
+	t.Run("LeaseLostStopsJob", func(t *testing.T) {
+		testIngestQueueLeaseLost(t, dbHelper)
+	})
+}
+
+// testIngestJobEnqueueDedupe tests that a user has at most one pending job: a queued job takes over the new run,
+// a running job is returned unchanged, and a finished job does not prevent a new one
+func testIngestJobEnqueueDedupe(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	jobRepo := repositories.NewIngestJobRepository(database)
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now().UTC()
+
+	runIDs := make([]string, 4)
+	for i := range runIDs {
+		runIDs[i] = dataHelper.InsertIngestRun(t, userID, now, &now, "ok", 0, 0, 0, nil)
+	}
+
+	first, created, err := jobRepo.EnqueueJob(ctx, ulid.Make().String(), userID, runIDs[0], 24, 3)
+	if err != nil {
+		t.Fatalf("EnqueueJob failed: %v", err)
+	}
+	if !created || first.Status != "queued" || first.Attempts != 0 {
+		t.Errorf("Expected a new queued job, got created=%v %+v", created, first)
+	}
+
+	// A queued job takes over the newer run and the larger backfill
+	queued, created, err := jobRepo.EnqueueJob(ctx, ulid.Make().String(), userID, runIDs[1], 48, 3)
+	if err != nil {
+		t.Fatalf("EnqueueJob failed: %v", err)
+	}
+	if created || queued.ID != first.ID || *queued.RunID != runIDs[1] || queued.BackfillHours != 48 {
+		t.Errorf("Expected the queued job to take over run %s, got created=%v %+v", runIDs[1], created, queued)
+	}
+
+	// A running job is left as it is
+	if _, err := jobRepo.ClaimJob(ctx, "worker-a", time.Minute); err != nil {
+		t.Fatalf("ClaimJob failed: %v", err)
+	}
+	running, created, err := jobRepo.EnqueueJob(ctx, ulid.Make().String(), userID, runIDs[2], 24, 3)
+	if err != nil {
+		t.Fatalf("EnqueueJob failed: %v", err)
+	}
+	if created || running.ID != first.ID || running.Status != "running" || *running.RunID != runIDs[1] {
+		t.Errorf("Expected the running job of run %s, got created=%v %+v", runIDs[1], created, running)
+	}
+	if count := countIngestJobs(t, database, userID); count != 1 {
+		t.Errorf("Expected one pending job, got %d", count)
+	}
+
+	// Once the job is done the user can be enqueued again
+	if err := jobRepo.CompleteJob(ctx, first.ID, "worker-a"); err != nil {
+		t.Fatalf("CompleteJob failed: %v", err)
+	}
+	next, created, err := jobRepo.EnqueueJob(ctx, ulid.Make().String(), userID, runIDs[3], 24, 3)
+	if err != nil {
+		t.Fatalf("EnqueueJob failed: %v", err)
+	}
+	if !created || next.ID == first.ID {
+		t.Errorf("Expected a new job after the previous one was done, got created=%v %+v", created, next)
+	}
+}
+
+// testIngestJobClaimAndLease tests that a leased job cannot be claimed by another worker until the lease
+// expires, and that the previous worker loses the job once it is reclaimed
+func testIngestJobClaimAndLease(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	jobRepo := repositories.NewIngestJobRepository(database)
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now().UTC()
+
+	runID := dataHelper.InsertIngestRun(t, userID, now, &now, "ok", 0, 0, 0, nil)
+	enqueued, _, err := jobRepo.EnqueueJob(ctx, ulid.Make().String(), userID, runID, 24, 3)
+	if err != nil {
+		t.Fatalf("EnqueueJob failed: %v", err)
+	}
+
+	job, err := jobRepo.ClaimJob(ctx, "worker-a", time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimJob failed: %v", err)
+	}
+	if job == nil || job.ID != enqueued.ID || job.Status != "running" || job.Attempts != 1 || *job.LockedBy != "worker-a" {
+		t.Fatalf("Expected worker-a to claim the job, got %+v", job)
+	}
+	if time.Until(*job.LockedUntil) < 50*time.Second {
+		t.Errorf("Expected a lease of a minute, got locked_until %v", job.LockedUntil)
+	}
+
+	other, err := jobRepo.ClaimJob(ctx, "worker-b", time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimJob failed: %v", err)
+	}
+	if other != nil {
+		t.Fatalf("Expected no job for worker-b while the lease is held, got %+v", other)
+	}
+
+	// worker-a stops extending its lease, e.g. because its process crashed
+	if _, err := database.Exec("UPDATE ingest_jobs SET locked_until = NOW() - INTERVAL '1 second' WHERE id = $1", job.ID); err != nil {
+		t.Fatalf("Failed to expire lease: %v", err)
+	}
+
+	reclaimed, err := jobRepo.ClaimJob(ctx, "worker-b", time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimJob failed: %v", err)
+	}
+	if reclaimed == nil || reclaimed.ID != job.ID || reclaimed.Attempts != 2 || *reclaimed.LockedBy != "worker-b" {
+		t.Fatalf("Expected worker-b to reclaim the job, got %+v", reclaimed)
+	}
+
+	for name, update := range map[string]func() error{
+		"ExtendLease": func() error { return jobRepo.ExtendLease(ctx, job.ID, "worker-a", time.Minute) },
+		"CompleteJob": func() error { return jobRepo.CompleteJob(ctx, job.ID, "worker-a") },
+		"RetryJob":    func() error { return jobRepo.RetryJob(ctx, job.ID, "worker-a", "boom", time.Now()) },
+		"FailJob":     func() error { return jobRepo.FailJob(ctx, job.ID, "worker-a", "boom") },
+	} {
+		if err := update(); !errors.Is(err, repositories.ErrIngestJobLeaseLost) {
+			t.Errorf("%s by worker-a: expected ErrIngestJobLeaseLost, got %v", name, err)
+		}
+	}
+
+	if err := jobRepo.ExtendLease(ctx, job.ID, "worker-b", time.Minute); err != nil {
+		t.Errorf("ExtendLease by worker-b failed: %v", err)
+	}
+	if job := getIngestJob(t, database, job.ID); job.Status != "running" || *job.LockedBy != "worker-b" {
+		t.Errorf("Expected the job to stay with worker-b, got %+v", job)
+	}
+}
+
+// testIngestJobConcurrentClaims tests that workers claiming at the same time skip each other's locked jobs
+func testIngestJobConcurrentClaims(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	jobRepo := repositories.NewIngestJobRepository(database)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	const workers = 5
+	for i := 0; i < workers; i++ {
+		userID := uuid.New()
+		runID := dataHelper.InsertIngestRun(t, userID, now, &now, "ok", 0, 0, 0, nil)
+		if _, _, err := jobRepo.EnqueueJob(ctx, ulid.Make().String(), userID, runID, 24, 3); err != nil {
+			t.Fatalf("EnqueueJob failed: %v", err)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	claimed := map[string]string{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(workerID string) {
+			defer wg.Done()
+			job, err := jobRepo.ClaimJob(ctx, workerID, time.Minute)
+			if err != nil {
+				t.Errorf("ClaimJob by %s failed: %v", workerID, err)
+				return
+			}
+			if job == nil {
+				t.Errorf("Expected %s to claim a job", workerID)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if previous, ok := claimed[job.ID]; ok {
+				t.Errorf("Job %s claimed by both %s and %s", job.ID, previous, workerID)
+			}
+			claimed[job.ID] = workerID
+		}(fmt.Sprintf("worker-%d", i))
+	}
+	wg.Wait()
+
+	if len(claimed) != workers {
+		t.Errorf("Expected %d distinct jobs claimed, got %d", workers, len(claimed))
+	}
+}
+
+// testIngestJobRetry tests that a job requeued for later is not claimed before its retry time
+func testIngestJobRetry(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	jobRepo := repositories.NewIngestJobRepository(database)
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now().UTC()
+
+	runID := dataHelper.InsertIngestRun(t, userID, now, &now, "ok", 0, 0, 0, nil)
+	if _, _, err := jobRepo.EnqueueJob(ctx, ulid.Make().String(), userID, runID, 24, 3); err != nil {
+		t.Fatalf("EnqueueJob failed: %v", err)
+	}
+	job, err := jobRepo.ClaimJob(ctx, "worker-a", time.Minute)
+	if err != nil || job == nil {
+		t.Fatalf("ClaimJob failed: %v", err)
+	}
+
+	if err := jobRepo.RetryJob(ctx, job.ID, "worker-a", "twitter unavailable", now.Add(time.Hour)); err != nil {
+		t.Fatalf("RetryJob failed: %v", err)
+	}
+	retried := getIngestJob(t, database, job.ID)
+	if retried.Status != "queued" || retried.LockedBy != nil || retried.LastError == nil || *retried.LastError != "twitter unavailable" {
+		t.Errorf("Expected the job back in the queue with its error, got %+v", retried)
+	}
+
+	if early, err := jobRepo.ClaimJob(ctx, "worker-b", time.Minute); err != nil || early != nil {
+		t.Fatalf("Expected no job before its retry time, got %+v (err %v)", early, err)
+	}
+
+	if _, err := database.Exec("UPDATE ingest_jobs SET run_after = NOW() - INTERVAL '1 second' WHERE id = $1", job.ID); err != nil {
+		t.Fatalf("Failed to move retry time: %v", err)
+	}
+	due, err := jobRepo.ClaimJob(ctx, "worker-b", time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimJob failed: %v", err)
+	}
+	if due == nil || due.ID != job.ID || due.Attempts != 2 {
+		t.Errorf("Expected the job to be claimed for its second attempt, got %+v", due)
+	}
+}
+
+// testIngestQueueBackoffAndFailure tests that a failing job is retried with exponential backoff
+// and marked as failed on its last attempt
+func testIngestQueueBackoffAndFailure(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	userID := uuid.New()
+
+	// A run the job does not own keeps the user busy, so every attempt fails to start its run
+	dataHelper.InsertIngestRun(t, userID, time.Now().UTC(), nil, "ok", 0, 0, 0, nil)
+	jobID := insertIngestJob(t, database, userID, nil, "queued", 0, nil, nil)
+
+	queue := newTestIngestQueue(database, repositories.NewUserRepository(database))
+	job := runIngestQueueUntil(t, queue, database, jobID, func(job *db.IngestJob) bool {
+		return job.Status == "queued" && job.Attempts == 1
+	})
+	expectRetryAt(t, job, time.Minute)
+	if job.LastError == nil || !strings.Contains(*job.LastError, "failed to start ingest run") {
+		t.Errorf("Expected the attempt's error to be recorded, got %v", job.LastError)
+	}
+
+	if _, err := database.Exec("UPDATE ingest_jobs SET run_after = NOW() WHERE id = $1", jobID); err != nil {
+		t.Fatalf("Failed to move retry time: %v", err)
+	}
+	job = runIngestQueueUntil(t, queue, database, jobID, func(job *db.IngestJob) bool {
+		return job.Status == "queued" && job.Attempts == 2
+	})
+	expectRetryAt(t, job, 2*time.Minute)
+
+	if _, err := database.Exec("UPDATE ingest_jobs SET run_after = NOW() WHERE id = $1", jobID); err != nil {
+		t.Fatalf("Failed to move retry time: %v", err)
+	}
+	job = runIngestQueueUntil(t, queue, database, jobID, func(job *db.IngestJob) bool {
+		return job.Status != "running" && job.Attempts == 3
+	})
+	if job.Status != "failed" || job.LockedBy != nil {
+		t.Errorf("Expected the job to fail on its last attempt, got %+v", job)
+	}
+}
+
+// testIngestQueueMaxAttemptsExceeded tests that a job abandoned on its last attempt is failed together with its run,
+// so the user can be ingested again right away
+func testIngestQueueMaxAttemptsExceeded(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	userID := uuid.New()
+	now := time.Now().UTC()
+
+	runID := dataHelper.InsertIngestRun(t, userID, now, nil, "ok", 0, 0, 0, nil)
+	expired := now.Add(-time.Second)
+	jobID := insertIngestJob(t, database, userID, &runID, "running", 3, StringPtr("crashed-worker"), &expired)
+
+	queue := newTestIngestQueue(database, repositories.NewUserRepository(database))
+	job := runIngestQueueUntil(t, queue, database, jobID, func(job *db.IngestJob) bool {
+		return job.Status != "running"
+	})
+	if job.Status != "failed" || job.LastError == nil || *job.LastError != "max attempts exceeded" {
+		t.Errorf("Expected the job to fail with max attempts exceeded, got %+v", job)
+	}
+
+	var run struct {
+		CompletedAt *time.Time `db:"completed_at"`
+		Status      string     `db:"status"`
+		ErrText     *string    `db:"err_text"`
+	}
+	if err := database.Get(&run, "SELECT completed_at, status, err_text FROM ingest_runs WHERE id = $1", runID); err != nil {
+		t.Fatalf("Failed to get ingest run: %v", err)
+	}
+	if run.CompletedAt == nil || run.Status != "error" || run.ErrText == nil || *run.ErrText != "max attempts exceeded" {
+		t.Errorf("Expected the job's run to be closed with max attempts exceeded, got %+v", run)
+	}
+
+	if _, _, err := queue.Submit(context.Background(), userID, 24); err != nil {
+		t.Errorf("Expected a new run to be accepted, got %v", err)
+	}
+}
+
+// testIngestQueueLeaseLost tests that a worker stops executing a job once another worker took over its lease
+func testIngestQueueLeaseLost(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	userID := uuid.New()
+	jobID := insertIngestJob(t, database, userID, nil, "queued", 0, nil, nil)
+
+	userRepo := blockingUserRepository{started: make(chan struct{}, 1), stopped: make(chan error, 1)}
+	queue := newTestIngestQueue(database, userRepo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		queue.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+
+	select {
+	case <-userRepo.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the worker to start executing the job")
+	}
+
+	// Another worker takes the job over
+	if _, err := database.Exec("UPDATE ingest_jobs SET locked_by = 'other-worker', locked_until = NOW() + INTERVAL '1 hour' WHERE id = $1", jobID); err != nil {
+		t.Fatalf("Failed to take over lease: %v", err)
+	}
+
+	select {
+	case cause := <-userRepo.stopped:
+		if !errors.Is(cause, repositories.ErrIngestJobLeaseLost) {
+			t.Errorf("Expected the job to be stopped because its lease was lost, got %v", cause)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the worker to stop the job after losing its lease")
+	}
+
+	if job := getIngestJob(t, database, jobID); job.Status != "running" || *job.LockedBy != "other-worker" || job.Attempts != 1 {
+		t.Errorf("Expected the job to stay with the other worker, got %+v", job)
+	}
+}
@@ -0,0 +1,163 @@
+package integration
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/internal/services"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+)
+
+// staticUserRepository returns the same user for every lookup
+type staticUserRepository struct {
+	repositories.UserRepository
+	user *db.User
+}
+
+func (r staticUserRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*db.User, error) {
+	return r.user, nil
+}
+
+// newRateLimitedTwitterServer serves a user following @author_a and @author_b; timelines of the
+// authors in limited are always rejected with 429 and a one second Retry-After, the others are empty
+func newRateLimitedTwitterServer(t *testing.T, limited ...string) *services.TwitterClient {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/twitter/user/followings":
+			_, _ = w.Write([]byte(`{"followings":[{"id":"801","userName":"author_a","name":"Author A"},{"id":"802","userName":"author_b","name":"Author B"}],"has_next_page":false,"next_cursor":"","status":"success"}`))
+		case "/twitter/user/last_tweets":
+			for _, handle := range limited {
+				if r.URL.Query().Get("userName") == handle {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusTooManyRequests)
+					_, _ = w.Write([]byte(`{"status":"error","msg":"Too many requests"}`))
+					return
+				}
+			}
+			_, _ = w.Write([]byte(`{"data":{"tweets":[]},"has_next_page":false,"next_cursor":"","status":"success"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+	return client
+}
+
+// executeTestRun starts and executes an ingest run of the user against the fixture client
+func executeTestRun(t *testing.T, database *sqlx.DB, client *services.TwitterClient, userID uuid.UUID) (*db.IngestRun, error) {
+	t.Helper()
+
+	ingestRepo := repositories.NewIngestRepository(database)
+	followingRepo := repositories.NewFollowingRepository(database)
+	userRepo := staticUserRepository{user: &db.User{ID: userID, XUsername: "reader", Plan: "free"}}
+	fetchPlanner := services.NewFetchPlanner(client, followingRepo, repositories.NewTimelineFetchRepository(database), services.DefaultFetchPlannerConfig())
+	ingestService := services.NewIngestService(client, fetchPlanner, nil, ingestRepo, followingRepo,
+		repositories.NewPostRepository(database), repositories.NewAuthorRepository(database),
+		repositories.NewWatermarkRepository(database), userRepo, repositories.NewIngestPolicyRepository(database),
+		repositories.NewFeedListRepository(database), repositories.NewSearchSourceRepository(database), nil, nil, nil)
+
+	ctx := context.Background()
+	run, err := ingestService.StartRun(ctx, userID)
+	if err != nil {
+		t.Fatalf("StartRun failed: %v", err)
+	}
+
+	execErr := ingestService.ExecuteRun(ctx, run, 0)
+
+	completed, err := ingestService.GetRun(ctx, userID, run.ID)
+	if err != nil {
+		t.Fatalf("GetRun failed: %v", err)
+	}
+	return completed, execErr
+}
+
+// countRunErrors returns the number of errors recorded against a run
+func countRunErrors(t *testing.T, database *sqlx.DB, runID string) int {
+	t.Helper()
+
+	var count int
+	if err := database.Get(&count, "SELECT COUNT(*) FROM ingest_run_errors WHERE run_id = $1", runID); err != nil {
+		t.Fatalf("Failed to count run errors: %v", err)
+	}
+	return count
+}
+
+// TestIngestRateLimitIntegration tests the status of runs whose author timelines are rate limited
+func TestIngestRateLimitIntegration(t *testing.T) {
+	logger.Init(slog.LevelInfo)
+
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("AllAuthorsRateLimited", func(t *testing.T) {
+		testAllAuthorsRateLimited(t, dbHelper)
+	})
+
+	t.Run("SomeAuthorsRateLimited", func(t *testing.T) {
+		testSomeAuthorsRateLimited(t, dbHelper)
+	})
+}
+
+// testAllAuthorsRateLimited tests that a run in which every author's timeline exhausted its 429 retries
+// is marked rate_limited rather than ok
+func testAllAuthorsRateLimited(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	userID := uuid.New()
+	client := newRateLimitedTwitterServer(t, "author_a", "author_b")
+
+	run, err := executeTestRun(t, database, client, userID)
+	if err == nil {
+		t.Error("Expected the run to fail")
+	}
+	if run.CompletedAt == nil || run.Status != "rate_limited" {
+		t.Errorf("Expected a completed rate_limited run, got status %q", run.Status)
+	}
+	if run.RateLimitHits < 2 {
+		t.Errorf("Expected the rate limit hits of both authors, got %d", run.RateLimitHits)
+	}
+	if run.ErrText == nil || !strings.Contains(*run.ErrText, "tweets of all 2 authors failed, rate limited") {
+		t.Errorf("Expected the error to name the rate limited authors, got %v", run.ErrText)
+	}
+	if count := countRunErrors(t, database, run.ID); count != 2 {
+		t.Errorf("Expected an error recorded for each author, got %d", count)
+	}
+}
+
+// testSomeAuthorsRateLimited tests that a run completes as ok when only some authors are rate limited
+func testSomeAuthorsRateLimited(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	userID := uuid.New()
+	client := newRateLimitedTwitterServer(t, "author_a")
+
+	run, err := executeTestRun(t, database, client, userID)
+	if err != nil {
+		t.Errorf("Expected the run to succeed, got %v", err)
+	}
+	if run.CompletedAt == nil || run.Status != "ok" {
+		t.Errorf("Expected a completed ok run, got status %q", run.Status)
+	}
+	if run.RateLimitHits < 1 {
+		t.Errorf("Expected the rate limit hits of @author_a, got %d", run.RateLimitHits)
+	}
+	if count := countRunErrors(t, database, run.ID); count != 1 {
+		t.Errorf("Expected an error recorded for @author_a, got %d", count)
+	}
+}
@@ -0,0 +1,144 @@
+package integration
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/internal/services"
+	"github.com/sopeal/AskYourFeed/pkg/logger"
+)
+
+// schedulerUserRepository lists a fixed set of users for the scheduler
+type schedulerUserRepository struct {
+	repositories.UserRepository
+	userIDs []uuid.UUID
+}
+
+func (r schedulerUserRepository) ListUserIDs(ctx context.Context) ([]uuid.UUID, error) {
+	return r.userIDs, nil
+}
+
+// newTestScheduler creates a scheduler without jitter whose jobs stay queued (no workers are started)
+func newTestScheduler(database *sqlx.DB, userIDs ...uuid.UUID) *services.IngestScheduler {
+	ingestRepo := repositories.NewIngestRepository(database)
+	followingRepo := repositories.NewFollowingRepository(database)
+	userRepo := schedulerUserRepository{userIDs: userIDs}
+	twitterClient := services.NewTwitterClient("", nil)
+	fetchPlanner := services.NewFetchPlanner(twitterClient, followingRepo, repositories.NewTimelineFetchRepository(database), services.DefaultFetchPlannerConfig())
+	ingestService := services.NewIngestService(twitterClient, fetchPlanner, nil, ingestRepo, followingRepo,
+		repositories.NewPostRepository(database), repositories.NewAuthorRepository(database),
+		repositories.NewWatermarkRepository(database), userRepo, repositories.NewIngestPolicyRepository(database),
+		repositories.NewFeedListRepository(database), repositories.NewSearchSourceRepository(database), nil, nil, nil)
+	ingestQueue := services.NewIngestQueue(ingestService, repositories.NewIngestJobRepository(database), services.DefaultIngestQueueConfig())
+
+	config := services.DefaultIngestSchedulerConfig()
+	config.Jitter = 0
+	return services.NewIngestScheduler(ingestQueue, ingestRepo, userRepo, config)
+}
+
+// countIngestJobs returns the number of ingest jobs of a user
+func countIngestJobs(t *testing.T, database *sqlx.DB, userID uuid.UUID) int {
+	t.Helper()
+
+	var count int
+	if err := database.Get(&count, "SELECT COUNT(*) FROM ingest_jobs WHERE user_id = $1", userID); err != nil {
+		t.Fatalf("Failed to count ingest jobs: %v", err)
+	}
+	return count
+}
+
+// TestIngestSchedulerIntegration tests which users the periodic scheduler enqueues ingest jobs for
+func TestIngestSchedulerIntegration(t *testing.T) {
+	logger.Init(slog.LevelInfo)
+
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("DueAndNotDueUsers", func(t *testing.T) {
+		testSchedulerDueUsers(t, dbHelper)
+	})
+
+	t.Run("RunInProgress", func(t *testing.T) {
+		testSchedulerRunInProgress(t, dbHelper)
+	})
+}
+
+// testSchedulerDueUsers tests that never-synced and overdue users are enqueued once and recently synced users are not
+func testSchedulerDueUsers(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	ctx := context.Background()
+	neverSynced := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	overdue := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	recent := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	now := time.Now().UTC()
+
+	overdueCompleted := now.Add(-5 * time.Hour)
+	dataHelper.InsertIngestRun(t, overdue, overdueCompleted.Add(-time.Minute), &overdueCompleted, "ok", 10, 0, 0, nil)
+	recentCompleted := now.Add(-time.Hour)
+	dataHelper.InsertIngestRun(t, recent, recentCompleted.Add(-time.Minute), &recentCompleted, "ok", 10, 0, 0, nil)
+
+	scheduler := newTestScheduler(database, neverSynced, overdue, recent)
+	if enqueued := scheduler.ScheduleDueUsers(ctx); enqueued != 2 {
+		t.Errorf("Expected jobs for the never-synced and overdue users, got %d", enqueued)
+	}
+	if countIngestJobs(t, database, neverSynced) != 1 || countIngestJobs(t, database, overdue) != 1 {
+		t.Error("Expected one job each for the never-synced and overdue users")
+	}
+	if countIngestJobs(t, database, recent) != 0 {
+		t.Error("Expected no job for the recently synced user")
+	}
+
+	// Enqueued users are rescheduled one interval later
+	if enqueued := scheduler.ScheduleDueUsers(ctx); enqueued != 0 {
+		t.Errorf("Expected no more jobs on the next check, got %d", enqueued)
+	}
+}
+
+// testSchedulerRunInProgress tests that a run in progress or completed elsewhere postpones the scheduled run
+// instead of leaving the user due as soon as that run completes
+func testSchedulerRunInProgress(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	ctx := context.Background()
+	running := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	completedElsewhere := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	now := time.Now().UTC()
+
+	lastCompleted := now.Add(-5 * time.Hour)
+	for _, userID := range []uuid.UUID{running, completedElsewhere} {
+		dataHelper.InsertIngestRun(t, userID, lastCompleted.Add(-time.Minute), &lastCompleted, "ok", 10, 0, 0, nil)
+	}
+
+	// A manual run started 10 minutes ago; another replica started one more than an interval ago
+	runningID := dataHelper.InsertIngestRun(t, running, now.Add(-10*time.Minute), nil, "ok", 0, 0, 0, nil)
+	elsewhereID := dataHelper.InsertIngestRun(t, completedElsewhere, now.Add(-5*time.Hour), nil, "ok", 0, 0, 0, nil)
+
+	scheduler := newTestScheduler(database, running, completedElsewhere)
+	if enqueued := scheduler.ScheduleDueUsers(ctx); enqueued != 0 {
+		t.Errorf("Expected no jobs while runs are in progress, got %d", enqueued)
+	}
+
+	// Both runs complete; neither user may be synced again right away
+	for _, runID := range []string{runningID, elsewhereID} {
+		if _, err := database.Exec("UPDATE ingest_runs SET completed_at = now() WHERE id = $1", runID); err != nil {
+			t.Fatalf("Failed to complete ingest run: %v", err)
+		}
+	}
+
+	if enqueued := scheduler.ScheduleDueUsers(ctx); enqueued != 0 {
+		t.Errorf("Expected no jobs right after the runs completed, got %d", enqueued)
+	}
+	if countIngestJobs(t, database, running) != 0 || countIngestJobs(t, database, completedElsewhere) != 0 {
+		t.Error("Expected no ingest jobs to be enqueued")
+	}
+}
@@ -71,6 +71,10 @@ func TestIngestStatusIntegration(t *testing.T) {
 	t.Run("MultipleUsers", func(t *testing.T) {
 		testMultipleUsers(t, dbHelper)
 	})
+
+	t.Run("RateLimitStats", func(t *testing.T) {
+		testRateLimitStats(t, dbHelper)
+	})
 }
 
 // testHappyPath tests the happy path scenario with completed runs
@@ -570,3 +574,71 @@ func testMultipleUsers(t *testing.T, dbHelper *DatabaseHelper) {
 		}
 	})
 }
+
+// testRateLimitStats tests daily aggregation of rate limit hits and retries
+func testRateLimitStats(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	db := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(db)
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	// Two runs today, one yesterday and one outside a 2-day window
+	for _, startedAt := range []time.Time{today.Add(time.Minute), today.Add(2 * time.Minute)} {
+		completedAt := startedAt.Add(time.Minute)
+		dataHelper.InsertIngestRun(t, userID, startedAt, &completedAt, "ok", 10, 1, 1, nil)
+	}
+	yesterday := today.Add(-12 * time.Hour)
+	yesterdayCompleted := yesterday.Add(time.Minute)
+	dataHelper.InsertIngestRun(t, userID, yesterday, &yesterdayCompleted, "rate_limited", 3, 3, 4, StringPtr("rate limited"))
+	old := today.Add(-72 * time.Hour)
+	oldCompleted := old.Add(time.Minute)
+	dataHelper.InsertIngestRun(t, userID, old, &oldCompleted, "rate_limited", 1, 5, 5, StringPtr("rate limited"))
+
+	router := NewTestRouter(db).GetEngine()
+
+	t.Run("TwoDayWindow", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/ingest/rate-limits?days=2", nil)
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("X-Test-User-ID", userID.String())
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var response dto.IngestRateLimitStatsDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if response.Runs != 3 || response.RateLimitedRuns != 1 {
+			t.Errorf("Expected 3 runs with 1 rate limited, got %d and %d", response.Runs, response.RateLimitedRuns)
+		}
+		if response.RateLimitHits != 6 || response.Retried != 5 {
+			t.Errorf("Expected 6 rate limit hits and 5 retries, got %d and %d", response.RateLimitHits, response.Retried)
+		}
+		if len(response.Daily) != 2 {
+			t.Fatalf("Expected 2 days, got %d", len(response.Daily))
+		}
+		if response.Daily[0].Date != today.Format(time.DateOnly) || response.Daily[0].Runs != 2 {
+			t.Errorf("Expected 2 runs on %s first, got %+v", today.Format(time.DateOnly), response.Daily[0])
+		}
+	})
+
+	t.Run("InvalidDays", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/v1/ingest/rate-limits?days=0", nil)
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("X-Test-User-ID", userID.String())
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
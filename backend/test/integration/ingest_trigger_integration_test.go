@@ -2,13 +2,18 @@ package integration
 
 import (
 	"bytes"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/dto"
 	"github.com/sopeal/AskYourFeed/pkg/logger"
 )
 
@@ -41,6 +46,30 @@ func TestIngestTriggerIntegration(t *testing.T) {
 		testTriggerNoAuth(t, dbHelper, userID)
 	})
 
+	t.Run("GetRun", func(t *testing.T) {
+		testGetIngestRun(t, dbHelper, dataHelper, userID)
+	})
+
+	t.Run("GetRunNotFound", func(t *testing.T) {
+		testGetIngestRunNotFound(t, dbHelper, dataHelper, userID)
+	})
+
+	t.Run("ConcurrentTriggers", func(t *testing.T) {
+		testConcurrentTriggers(t, dbHelper, userID)
+	})
+
+	t.Run("AbandonedRunReaped", func(t *testing.T) {
+		testTriggerReapsAbandonedRun(t, dbHelper, dataHelper, userID)
+	})
+
+	t.Run("CancelQueuedRun", func(t *testing.T) {
+		testCancelQueuedRun(t, dbHelper, userID)
+	})
+
+	t.Run("CancelCompletedRun", func(t *testing.T) {
+		testCancelCompletedRun(t, dbHelper, dataHelper, userID)
+	})
+
 	t.Run("InvalidUserID", func(t *testing.T) {
 		testTriggerInvalidUserID(t, dbHelper, userID)
 	})
@@ -119,10 +148,8 @@ func TestIngestTriggerIntegration(t *testing.T) {
 //	}
 //}
 
-// testTriggerBackfillHours tests parsing backfill_hours (not used but binds)
+// testTriggerBackfillHours tests parsing backfill_hours and that the returned run ID exists
 func testTriggerBackfillHours(t *testing.T, dbHelper *DatabaseHelper, dataHelper *TestDataHelper, userID uuid.UUID) {
-	dbHelper.CleanupTestData(t)
-
 	conn := dbHelper.GetDB()
 	router := NewTestRouter(conn).GetEngine()
 
@@ -137,6 +164,9 @@ func testTriggerBackfillHours(t *testing.T, dbHelper *DatabaseHelper, dataHelper
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// Each trigger creates a run synchronously, so start from a clean state
+			dbHelper.CleanupTestData(t)
+
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest("POST", "/api/v1/ingest/trigger", bytes.NewBufferString(tt.body))
 			req.Header.Set("Content-Type", "application/json")
@@ -145,12 +175,257 @@ func testTriggerBackfillHours(t *testing.T, dbHelper *DatabaseHelper, dataHelper
 			router.ServeHTTP(w, req)
 
 			if w.Code != http.StatusAccepted {
-				t.Errorf("%s: Expected 202, got %d", tt.name, w.Code)
+				t.Fatalf("%s: Expected 202, got %d", tt.name, w.Code)
+			}
+
+			var resp dto.TriggerIngestResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			var count int
+			if err := conn.QueryRow("SELECT COUNT(*) FROM ingest_runs WHERE id = $1 AND user_id = $2", resp.IngestRunID, userID).Scan(&count); err != nil {
+				t.Fatalf("Failed to count runs: %v", err)
+			}
+			if count != 1 {
+				t.Errorf("%s: Expected run %s to exist, found %d rows", tt.name, resp.IngestRunID, count)
 			}
 		})
 	}
 }
 
+// testGetIngestRun tests fetching a run with per-phase progress and recorded errors
+func testGetIngestRun(t *testing.T, dbHelper *DatabaseHelper, dataHelper *TestDataHelper, userID uuid.UUID) {
+	dbHelper.CleanupTestData(t)
+
+	conn := dbHelper.GetDB()
+	router := NewTestRouter(conn).GetEngine()
+
+	runID := dataHelper.InsertIngestRun(t, userID, time.Now().UTC().Add(-time.Minute), nil, "ok", 0, 0, 0, nil)
+	if _, err := conn.Exec(`UPDATE ingest_runs SET phase = 'tweets', following_count = 10, tweets_count = 5 WHERE id = $1`, runID); err != nil {
+		t.Fatalf("Failed to update run progress: %v", err)
+	}
+	if _, err := conn.Exec(`
+		INSERT INTO ingest_run_errors (run_id, user_id, phase, author_handle, message)
+		VALUES ($1, $2, 'tweets', 'someone', 'failed to get user tweets')`, runID, userID); err != nil {
+		t.Fatalf("Failed to insert run error: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/ingest/runs/"+runID, nil)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.Header.Set("X-Test-User-ID", userID.String())
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp dto.IngestRunDetailDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if resp.ID != runID {
+		t.Errorf("Expected run ID %s, got %s", runID, resp.ID)
+	}
+	if resp.Phase != "tweets" {
+		t.Errorf("Expected phase 'tweets', got '%s'", resp.Phase)
+	}
+	if resp.Progress.Following != 10 || resp.Progress.Tweets != 5 {
+		t.Errorf("Expected progress 10/5, got %d/%d", resp.Progress.Following, resp.Progress.Tweets)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].AuthorHandle != "someone" {
+		t.Errorf("Expected 1 error for 'someone', got %+v", resp.Errors)
+	}
+}
+
+// testGetIngestRunNotFound tests that runs of other users are not visible
+func testGetIngestRunNotFound(t *testing.T, dbHelper *DatabaseHelper, dataHelper *TestDataHelper, userID uuid.UUID) {
+	dbHelper.CleanupTestData(t)
+
+	conn := dbHelper.GetDB()
+	router := NewTestRouter(conn).GetEngine()
+
+	otherUserID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	runID := dataHelper.InsertIngestRun(t, otherUserID, time.Now().UTC(), nil, "ok", 0, 0, 0, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/ingest/runs/"+runID, nil)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.Header.Set("X-Test-User-ID", userID.String())
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", w.Code)
+	}
+}
+
+// testConcurrentTriggers tests that concurrent triggers start exactly one run and the rest get 409
+func testConcurrentTriggers(t *testing.T, dbHelper *DatabaseHelper, userID uuid.UUID) {
+	dbHelper.CleanupTestData(t)
+
+	conn := dbHelper.GetDB()
+	router := NewTestRouter(conn).GetEngine()
+
+	const requests = 8
+	codes := make(chan int, requests)
+
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/ingest/trigger", bytes.NewBufferString(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer test-token")
+			req.Header.Set("X-Test-User-ID", userID.String())
+			router.ServeHTTP(w, req)
+			codes <- w.Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	accepted, conflicts := 0, 0
+	for code := range codes {
+		switch code {
+		case http.StatusAccepted:
+			accepted++
+		case http.StatusConflict:
+			conflicts++
+		default:
+			t.Errorf("Unexpected status code %d", code)
+		}
+	}
+
+	if accepted != 1 || conflicts != requests-1 {
+		t.Errorf("Expected 1 accepted and %d conflicts, got %d and %d", requests-1, accepted, conflicts)
+	}
+
+	var openRuns int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM ingest_runs WHERE user_id = $1 AND completed_at IS NULL", userID).Scan(&openRuns); err != nil {
+		t.Fatalf("Failed to count open runs: %v", err)
+	}
+	if openRuns != 1 {
+		t.Errorf("Expected 1 open run, got %d", openRuns)
+	}
+}
+
+// testTriggerReapsAbandonedRun tests that a run left open by a crashed process does not block a new trigger
+func testTriggerReapsAbandonedRun(t *testing.T, dbHelper *DatabaseHelper, dataHelper *TestDataHelper, userID uuid.UUID) {
+	dbHelper.CleanupTestData(t)
+
+	conn := dbHelper.GetDB()
+	router := NewTestRouter(conn).GetEngine()
+
+	// Open run without a heartbeat for an hour
+	staleRunID := dataHelper.InsertIngestRun(t, userID, time.Now().UTC().Add(-time.Hour), nil, "ok", 3, 0, 0, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/ingest/trigger", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.Header.Set("X-Test-User-ID", userID.String())
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var status string
+	var errText *string
+	if err := conn.QueryRow("SELECT status, err_text FROM ingest_runs WHERE id = $1", staleRunID).Scan(&status, &errText); err != nil {
+		t.Fatalf("Failed to get stale run: %v", err)
+	}
+	if status != "error" {
+		t.Errorf("Expected stale run status 'error', got '%s'", status)
+	}
+	if errText == nil || !strings.HasPrefix(*errText, "abandoned") {
+		t.Errorf("Expected stale run to be marked as abandoned, got %v", errText)
+	}
+}
+
+// testCancelQueuedRun tests that a run still waiting in the queue is cancelled immediately
+func testCancelQueuedRun(t *testing.T, dbHelper *DatabaseHelper, userID uuid.UUID) {
+	dbHelper.CleanupTestData(t)
+
+	conn := dbHelper.GetDB()
+	router := NewTestRouter(conn).GetEngine()
+
+	// Trigger a run - no workers run in tests, so its job stays queued
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/ingest/trigger", bytes.NewBufferString(`{"backfill_hours": 720}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.Header.Set("X-Test-User-ID", userID.String())
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202 from trigger, got %d", w.Code)
+	}
+
+	var triggerResp dto.TriggerIngestResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &triggerResp); err != nil {
+		t.Fatalf("Failed to unmarshal trigger response: %v", err)
+	}
+
+	// Cancel it
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/v1/ingest/runs/"+triggerResp.IngestRunID+"/cancel", nil)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.Header.Set("X-Test-User-ID", userID.String())
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202 from cancel, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var cancelResp dto.CancelIngestResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &cancelResp); err != nil {
+		t.Fatalf("Failed to unmarshal cancel response: %v", err)
+	}
+	if cancelResp.Status != "cancelled" {
+		t.Errorf("Expected status 'cancelled', got '%s'", cancelResp.Status)
+	}
+
+	var status, jobStatus string
+	if err := conn.QueryRow("SELECT status FROM ingest_runs WHERE id = $1", triggerResp.IngestRunID).Scan(&status); err != nil {
+		t.Fatalf("Failed to get run status: %v", err)
+	}
+	if status != "cancelled" {
+		t.Errorf("Expected run status 'cancelled', got '%s'", status)
+	}
+	if err := conn.QueryRow("SELECT status FROM ingest_jobs WHERE run_id = $1", triggerResp.IngestRunID).Scan(&jobStatus); err != nil {
+		t.Fatalf("Failed to get job status: %v", err)
+	}
+	if jobStatus != "done" {
+		t.Errorf("Expected job status 'done', got '%s'", jobStatus)
+	}
+}
+
+// testCancelCompletedRun tests that cancelling a finished run returns 409
+func testCancelCompletedRun(t *testing.T, dbHelper *DatabaseHelper, dataHelper *TestDataHelper, userID uuid.UUID) {
+	dbHelper.CleanupTestData(t)
+
+	conn := dbHelper.GetDB()
+	router := NewTestRouter(conn).GetEngine()
+
+	now := time.Now().UTC()
+	runID := dataHelper.InsertIngestRun(t, userID, now.Add(-time.Minute), &now, "ok", 10, 0, 0, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/ingest/runs/"+runID+"/cancel", nil)
+	req.Header.Set("Authorization", "Bearer test-token")
+	req.Header.Set("X-Test-User-ID", userID.String())
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected 409, got %d", w.Code)
+	}
+}
+
 // testTriggerNoAuth tests missing auth header
 func testTriggerNoAuth(t *testing.T, dbHelper *DatabaseHelper, userID uuid.UUID) {
 	dbHelper.CleanupTestData(t)
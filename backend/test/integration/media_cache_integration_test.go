@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// TestMediaCacheIntegration tests the content-addressed cache of image descriptions
+func TestMediaCacheIntegration(t *testing.T) {
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("MediaDescriptionCache", func(t *testing.T) {
+		testMediaDescriptionCache(t, dbHelper)
+	})
+
+	t.Run("ImageDescriberCacheHit", func(t *testing.T) {
+		testImageDescriberCacheHit(t, dbHelper)
+	})
+}
+
+// testMediaDescriptionCache tests lookups by key order and model, expiry and purging
+func testMediaDescriptionCache(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	cacheRepo := repositories.NewMediaCacheRepository(dbHelper.GetDB())
+	ctx := context.Background()
+	model := services.MediaDescriptionModel
+	urlKey := services.ImageURLCacheKey("https://pbs.twimg.com/media/meme.jpg")
+	contentKey := services.ImageContentCacheKey([]byte("meme bytes"))
+
+	if err := cacheRepo.PutDescription(ctx, []string{urlKey}, model, "A meme by URL", time.Hour); err != nil {
+		t.Fatalf("PutDescription failed: %v", err)
+	}
+	if err := cacheRepo.PutDescription(ctx, []string{contentKey}, model, "A meme by content", time.Hour); err != nil {
+		t.Fatalf("PutDescription failed: %v", err)
+	}
+
+	entry, err := cacheRepo.GetDescription(ctx, []string{contentKey, urlKey}, model)
+	if err != nil {
+		t.Fatalf("GetDescription failed: %v", err)
+	}
+	if entry == nil || entry.Description != "A meme by content" || entry.Model != model || entry.CacheKey != contentKey {
+		t.Errorf("Expected the entry of the first key, got %+v", entry)
+	}
+
+	other, err := cacheRepo.GetDescription(ctx, []string{urlKey}, "another/model")
+	if err != nil {
+		t.Fatalf("GetDescription failed: %v", err)
+	}
+	if other != nil {
+		t.Errorf("Expected no description by another model, got %+v", other)
+	}
+
+	// Storing again replaces the entry; an expired entry is a miss until it is purged
+	if err := cacheRepo.PutDescription(ctx, []string{urlKey}, model, "An expired meme", -time.Minute); err != nil {
+		t.Fatalf("PutDescription failed: %v", err)
+	}
+	expired, err := cacheRepo.GetDescription(ctx, []string{urlKey}, model)
+	if err != nil {
+		t.Fatalf("GetDescription failed: %v", err)
+	}
+	if expired != nil {
+		t.Errorf("Expected an expired entry to be a miss, got %+v", expired)
+	}
+
+	deleted, err := cacheRepo.DeleteExpired(ctx)
+	if err != nil {
+		t.Fatalf("DeleteExpired failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 expired entry to be deleted, got %d", deleted)
+	}
+}
+
+// testImageDescriberCacheHit tests that a cached description is reused for a variant of the image URL
+// without calling the vision model
+func testImageDescriberCacheHit(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	cacheRepo := repositories.NewMediaCacheRepository(dbHelper.GetDB())
+	ctx := context.Background()
+
+	key := services.ImageURLCacheKey("https://pbs.twimg.com/media/chart.jpg")
+	if err := cacheRepo.PutDescription(ctx, []string{key}, services.MediaDescriptionModel, "A chart of rates", time.Hour); err != nil {
+		t.Fatalf("PutDescription failed: %v", err)
+	}
+
+	// Without an OpenRouter client only a cache hit can succeed
+	describer := services.NewImageDescriber(nil, cacheRepo, nil, services.DefaultImageDescriberConfig())
+	result := describer.DescribeImage(ctx, "https://pbs.twimg.com/media/chart?format=jpg&name=small")
+	if result.Err != nil || !result.Cached || result.Description != "A chart of rates" || result.Model != services.MediaDescriptionModel {
+		t.Errorf("Expected the cached description, got %+v", result)
+	}
+}
@@ -0,0 +1,150 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+)
+
+// TestMentionsIntegration tests storing mentions of the user and filtering questions to them
+func TestMentionsIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("MentionPosts", func(t *testing.T) {
+		testMentionPosts(t, dbHelper)
+	})
+
+	t.Run("MentionsPolicyAndQAValidation", func(t *testing.T) {
+		testMentionsPolicyAndQAValidation(t, dbHelper)
+	})
+}
+
+// testMentionPosts tests the source marker of posts and the source filter of Q&A retrieval
+func testMentionPosts(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	postRepo := repositories.NewPostRepository(database)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	authorID := int64(444)
+	dataHelper.InsertAuthor(t, authorID, "asker", StringPtr("Asker"), nil)
+
+	latest, err := postRepo.GetLatestMentionTime(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetLatestMentionTime failed: %v", err)
+	}
+	if latest != nil {
+		t.Fatalf("Expected no mention time before any mention, got %v", latest)
+	}
+
+	posts := []*dto.TweetDTO{
+		{ID: 8001, Text: "feed post", PublishedAt: now.Add(-3 * time.Hour)},
+		{ID: 8002, Text: "@owner question", PublishedAt: now.Add(-2 * time.Hour), Source: db.PostSourceMention},
+		{ID: 8003, Text: "feed post mentioning @owner", PublishedAt: now.Add(-time.Hour)},
+	}
+	for _, post := range posts {
+		post.AuthorID = authorID
+		post.RawText = post.Text
+		post.URL = fmt.Sprintf("https://x.com/asker/status/%d", post.ID)
+		if err := postRepo.InsertPost(ctx, userID, post); err != nil {
+			t.Fatalf("InsertPost failed: %v", err)
+		}
+	}
+
+	// A feed post found again by the mentions stage becomes a mention
+	if err := postRepo.MarkPostMention(ctx, userID, 8003); err != nil {
+		t.Fatalf("MarkPostMention failed: %v", err)
+	}
+
+	latest, err = postRepo.GetLatestMentionTime(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetLatestMentionTime failed: %v", err)
+	}
+	if latest == nil || !latest.Equal(now.Add(-time.Hour)) {
+		t.Errorf("Expected latest mention at %v, got %v", now.Add(-time.Hour), latest)
+	}
+
+	mentions, err := postRepo.GetPostsByDateRange(ctx, userID, now.Add(-24*time.Hour), now, nil, db.PostSourceMention)
+	if err != nil {
+		t.Fatalf("GetPostsByDateRange failed: %v", err)
+	}
+	if len(mentions) != 2 || mentions[0].XPostID != 8002 || mentions[1].XPostID != 8003 {
+		t.Errorf("Expected mentions 8002 and 8003, got %d posts", len(mentions))
+	}
+	for _, post := range mentions {
+		if post.Source != db.PostSourceMention {
+			t.Errorf("Expected post %d to have source mention, got %s", post.XPostID, post.Source)
+		}
+	}
+
+	feed, err := postRepo.GetPostsByDateRange(ctx, userID, now.Add(-24*time.Hour), now, nil, db.PostSourceFeed)
+	if err != nil {
+		t.Fatalf("GetPostsByDateRange failed: %v", err)
+	}
+	if len(feed) != 1 || feed[0].XPostID != 8001 {
+		t.Errorf("Expected only feed post 8001, got %d posts", len(feed))
+	}
+}
+
+// testMentionsPolicyAndQAValidation tests turning mentions on in the ingest policy and rejecting an unknown Q&A source
+func testMentionsPolicyAndQAValidation(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	router := NewTestRouter(dbHelper.GetDB()).GetEngine()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	doRequest := func(method, path string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("X-Test-User-ID", userID.String())
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := doRequest("PUT", "/api/v1/ingest/policy", []byte(`{"include_mentions": true}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var policy dto.IngestPolicyDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &policy); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if !policy.IncludeMentions {
+		t.Error("Expected mentions to be included")
+	}
+
+	w = doRequest("POST", "/api/v1/qa", []byte(`{"question": "What did people ask me?", "source": "dm"}`))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400 for an unknown source, got %d", w.Code)
+	}
+
+	var response dto.ErrorResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Error.Code != "INVALID_SOURCE" {
+		t.Errorf("Expected error code INVALID_SOURCE, got %s", response.Error.Code)
+	}
+}
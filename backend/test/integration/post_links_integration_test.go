@@ -0,0 +1,78 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+)
+
+// TestPostLinksIntegration tests storing linked pages of posts and loading them for Q&A
+func TestPostLinksIntegration(t *testing.T) {
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("PostLinks", func(t *testing.T) {
+		testPostLinks(t, dbHelper)
+	})
+}
+
+// testPostLinks tests that links are grouped by post and a link stored twice keeps the first content
+func testPostLinks(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	postRepo := repositories.NewPostRepository(database)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now().UTC()
+
+	authorID := int64(666)
+	dataHelper.InsertAuthor(t, authorID, "sharer", StringPtr("Sharer"), nil)
+
+	for _, postID := range []int64{8601, 8602} {
+		err := postRepo.InsertPost(ctx, userID, &dto.TweetDTO{
+			ID:          postID,
+			AuthorID:    authorID,
+			Text:        "Great writeup https://t.co/abc",
+			RawText:     "Great writeup https://t.co/abc",
+			PublishedAt: now.Add(-time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("InsertPost failed: %v", err)
+		}
+	}
+
+	summary := "Rates stay high because inflation is sticky."
+	links := []db.PostLink{
+		{XPostID: 8601, URL: "https://example.com/a", FinalURL: "https://example.com/a", Title: StringPtr("Rates"), Summary: &summary, Content: "Inflation is sticky."},
+		{XPostID: 8601, URL: "https://example.com/b", FinalURL: "https://example.com/b/final", Content: "Second page."},
+		{XPostID: 8601, URL: "https://example.com/a", FinalURL: "https://example.com/a", Content: "Fetched again."},
+	}
+	for _, link := range links {
+		if err := postRepo.InsertPostLink(ctx, userID, link); err != nil {
+			t.Fatalf("InsertPostLink failed: %v", err)
+		}
+	}
+
+	stored, err := postRepo.GetPostLinks(ctx, userID, []int64{8601, 8602})
+	if err != nil {
+		t.Fatalf("GetPostLinks failed: %v", err)
+	}
+	if len(stored) != 1 || len(stored[8601]) != 2 {
+		t.Fatalf("Expected 2 links of post 8601 only, got %+v", stored)
+	}
+
+	first := stored[8601][0]
+	if first.URL != "https://example.com/a" || first.Content != "Inflation is sticky." || first.Summary == nil || *first.Summary != summary {
+		t.Errorf("Expected the first stored content of https://example.com/a, got %+v", first)
+	}
+	if stored[8601][1].FinalURL != "https://example.com/b/final" || stored[8601][1].Summary != nil {
+		t.Errorf("Expected the unsummarized page at its final URL, got %+v", stored[8601][1])
+	}
+}
@@ -0,0 +1,270 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+	"github.com/sopeal/AskYourFeed/internal/services"
+	"github.com/sopeal/AskYourFeed/test"
+)
+
+// TestPostMediaIntegration tests storing media descriptions apart from posts and describing media again
+func TestPostMediaIntegration(t *testing.T) {
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("PostMedia", func(t *testing.T) {
+		testPostMedia(t, dbHelper)
+	})
+
+	t.Run("MediaReprocessing", func(t *testing.T) {
+		testMediaReprocessing(t, dbHelper)
+	})
+
+	t.Run("VideoTranscription", func(t *testing.T) {
+		testVideoTranscription(t, dbHelper)
+	})
+}
+
+// insertMediaPost inserts a post of the given user for the media tests
+func insertMediaPost(t *testing.T, postRepo *repositories.PostRepository, userID uuid.UUID, postID int64, authorID int64) {
+	t.Helper()
+
+	err := postRepo.InsertPost(context.Background(), userID, &dto.TweetDTO{
+		ID:          postID,
+		AuthorID:    authorID,
+		Text:        "Look at this chart",
+		RawText:     "Look at this chart",
+		PublishedAt: time.Now().UTC().Add(-time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("InsertPost failed: %v", err)
+	}
+}
+
+// testPostMedia tests that media is stored per post in order and described media can be reused
+func testPostMedia(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	postRepo := repositories.NewPostRepository(database)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	authorID := int64(777)
+	dataHelper.InsertAuthor(t, authorID, "charts", StringPtr("Charts"), nil)
+	insertMediaPost(t, postRepo, userID, 8701, authorID)
+
+	media := services.MediaFromTweet(&services.TweetData{
+		Media: &services.MediaData{
+			Photos: []services.PhotoData{{URL: "https://pbs.twimg.com/media/chart.jpg"}},
+			Videos: []services.VideoData{{URL: "https://video.twimg.com/clip.mp4", DurationMs: 5000}},
+		},
+	}, 8701)
+	description := "A line chart of rates rising since 2022"
+	model := services.MediaDescriptionModel
+	describedAt := time.Now().UTC()
+	media[0].Description = &description
+	media[0].Model = &model
+	media[0].Status = db.MediaStatusDescribed
+	media[0].DescribedAt = &describedAt
+
+	if err := postRepo.InsertPostMedia(ctx, userID, media); err != nil {
+		t.Fatalf("InsertPostMedia failed: %v", err)
+	}
+
+	stored, err := postRepo.GetPostMedia(ctx, userID, []int64{8701})
+	if err != nil {
+		t.Fatalf("GetPostMedia failed: %v", err)
+	}
+	if len(stored[8701]) != 2 || stored[8701][0].MediaType != db.MediaTypeImage || stored[8701][1].Status != db.MediaStatusPending {
+		t.Fatalf("Expected a described image and a pending video, got %+v", stored[8701])
+	}
+
+	posts, err := postRepo.GetPostsByDateRange(ctx, userID, time.Now().Add(-24*time.Hour), time.Now(), nil, "")
+	if err != nil {
+		t.Fatalf("GetPostsByDateRange failed: %v", err)
+	}
+	if len(posts) != 1 || posts[0].Text != "Look at this chart" {
+		t.Errorf("Expected the post text without media descriptions, got %+v", posts)
+	}
+
+	reused, err := postRepo.GetMediaDescription(ctx, services.MediaURLHash("https://pbs.twimg.com/media/chart.jpg"), model)
+	if err != nil {
+		t.Fatalf("GetMediaDescription failed: %v", err)
+	}
+	if reused == nil || *reused != description {
+		t.Errorf("Expected the stored description to be reused, got %v", reused)
+	}
+
+	other, err := postRepo.GetMediaDescription(ctx, media[0].URLHash, "another/model")
+	if err != nil {
+		t.Fatalf("GetMediaDescription failed: %v", err)
+	}
+	if other != nil {
+		t.Errorf("Expected no description by another model, got %q", *other)
+	}
+}
+
+// testMediaReprocessing tests listing media to describe and recording outcomes on every copy
+func testMediaReprocessing(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	postRepo := repositories.NewPostRepository(database)
+	ctx := context.Background()
+	firstUser := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	secondUser := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	model := services.MediaDescriptionModel
+
+	authorID := int64(778)
+	dataHelper.InsertAuthor(t, authorID, "photos", StringPtr("Photos"), nil)
+
+	// The same image is stored pending for two users
+	imageURL := "https://pbs.twimg.com/media/shared.jpg"
+	for _, userID := range []uuid.UUID{firstUser, secondUser} {
+		insertMediaPost(t, postRepo, userID, 8801, authorID)
+		media := services.MediaFromTweet(&services.TweetData{
+			Media: &services.MediaData{Photos: []services.PhotoData{{URL: imageURL}}},
+		}, 8801)
+		if err := postRepo.InsertPostMedia(ctx, userID, media); err != nil {
+			t.Fatalf("InsertPostMedia failed: %v", err)
+		}
+	}
+
+	listed, err := postRepo.ListMediaForReprocessing(ctx, model, "", false, services.MaxMediaAttempts, 10)
+	if err != nil {
+		t.Fatalf("ListMediaForReprocessing failed: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("Expected both pending copies to be listed, got %d", len(listed))
+	}
+
+	// A failed attempt is recorded on both copies and retried until the attempt limit
+	failure := "upstream timeout"
+	updated, err := postRepo.UpdateMediaDescription(ctx, db.PostMedia{URLHash: services.MediaURLHash(imageURL), Status: db.MediaStatusFailed, Error: &failure})
+	if err != nil {
+		t.Fatalf("UpdateMediaDescription failed: %v", err)
+	}
+	if updated != 2 {
+		t.Errorf("Expected both copies to be updated, got %d", updated)
+	}
+
+	// A description by an older model is only listed again when re-describing
+	oldModel := "openai/old-vision"
+	description := "A sunset over the sea"
+	describedAt := time.Now().UTC()
+	_, err = postRepo.UpdateMediaDescription(ctx, db.PostMedia{
+		URLHash:     services.MediaURLHash(imageURL),
+		Status:      db.MediaStatusDescribed,
+		Description: &description,
+		Model:       &oldModel,
+		DescribedAt: &describedAt,
+	})
+	if err != nil {
+		t.Fatalf("UpdateMediaDescription failed: %v", err)
+	}
+
+	listed, err = postRepo.ListMediaForReprocessing(ctx, model, "", false, services.MaxMediaAttempts, 10)
+	if err != nil {
+		t.Fatalf("ListMediaForReprocessing failed: %v", err)
+	}
+	if len(listed) != 0 {
+		t.Errorf("Expected described media not to be listed without re-describing, got %d", len(listed))
+	}
+
+	listed, err = postRepo.ListMediaForReprocessing(ctx, model, "", true, services.MaxMediaAttempts, 10)
+	if err != nil {
+		t.Fatalf("ListMediaForReprocessing failed: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("Expected media described by an older model to be listed when re-describing, got %d", len(listed))
+	}
+
+	// A failed re-description keeps the existing description
+	_, err = postRepo.UpdateMediaDescription(ctx, db.PostMedia{URLHash: services.MediaURLHash(imageURL), Status: db.MediaStatusFailed, Error: &failure})
+	if err != nil {
+		t.Fatalf("UpdateMediaDescription failed: %v", err)
+	}
+
+	stored, err := postRepo.GetPostMedia(ctx, secondUser, []int64{8801})
+	if err != nil {
+		t.Fatalf("GetPostMedia failed: %v", err)
+	}
+	item := stored[8801][0]
+	if item.Status != db.MediaStatusDescribed || item.Description == nil || *item.Description != description || item.Attempts != 3 {
+		t.Errorf("Expected the description to survive a failed re-description after 3 attempts, got %+v", item)
+	}
+}
+
+// testVideoTranscription tests that the reprocessor transcribes pending videos with a transcriber only
+func testVideoTranscription(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	postRepo := repositories.NewPostRepository(database)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	authorID := int64(779)
+	dataHelper.InsertAuthor(t, authorID, "clips", StringPtr("Clips"), nil)
+	insertMediaPost(t, postRepo, userID, 8901, authorID)
+
+	clipURL := "https://video.twimg.com/clip.mp4"
+	longURL := "https://video.twimg.com/long.mp4"
+	media := services.MediaFromTweet(&services.TweetData{
+		Media: &services.MediaData{
+			Photos: []services.PhotoData{{URL: "https://pbs.twimg.com/media/still.jpg"}},
+			Videos: []services.VideoData{
+				{URL: clipURL, DurationMs: 30000},
+				{URL: longURL, DurationMs: (services.MaxVideoDuration + 30) * 1000},
+			},
+		},
+	}, 8901)
+	if err := postRepo.InsertPostMedia(ctx, userID, media); err != nil {
+		t.Fatalf("InsertPostMedia failed: %v", err)
+	}
+
+	// Without an OpenRouter client only videos are listed
+	listed, err := postRepo.ListMediaForReprocessing(ctx, "", test.FakeTranscriberModel, false, services.MaxMediaAttempts, 10)
+	if err != nil {
+		t.Fatalf("ListMediaForReprocessing failed: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Fatalf("Expected the 2 pending videos to be listed, got %d", len(listed))
+	}
+
+	transcriber := test.NewFakeTranscriber(map[string]string{clipURL: "Rates will stay high this year."})
+	reprocessor := services.NewMediaReprocessor(nil, transcriber, postRepo, services.DefaultMediaReprocessorConfig())
+	if described := reprocessor.Reprocess(ctx); described != 1 {
+		t.Errorf("Expected 1 video to be transcribed, got %d", described)
+	}
+
+	stored, err := postRepo.GetPostMedia(ctx, userID, []int64{8901})
+	if err != nil {
+		t.Fatalf("GetPostMedia failed: %v", err)
+	}
+	if len(stored[8901]) != 3 {
+		t.Fatalf("Expected 3 media items, got %d", len(stored[8901]))
+	}
+
+	image, clip, long := stored[8901][0], stored[8901][1], stored[8901][2]
+	if image.Status != db.MediaStatusPending {
+		t.Errorf("Expected the image to stay pending without an OpenRouter client, got %q", image.Status)
+	}
+	if clip.Status != db.MediaStatusDescribed || clip.Description == nil || *clip.Description != "Rates will stay high this year." ||
+		clip.Model == nil || *clip.Model != test.FakeTranscriberModel {
+		t.Errorf("Expected the clip transcript by the fake transcriber, got %+v", clip)
+	}
+	if long.Status != db.MediaStatusSkipped || long.Description != nil {
+		t.Errorf("Expected the long video to be skipped, got %+v", long)
+	}
+}
@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+)
+
+// TestPostRevisionsIntegration tests detection of tweet edits and deletions
+func TestPostRevisionsIntegration(t *testing.T) {
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("EditsAndDeletions", func(t *testing.T) {
+		testPostEditsAndDeletions(t, dbHelper)
+	})
+}
+
+// testPostEditsAndDeletions tests that edits update every user's copy and deleted posts leave Q&A retrieval
+func testPostEditsAndDeletions(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	db := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(db)
+	postRepo := repositories.NewPostRepository(db)
+	ctx := context.Background()
+	user1ID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	user2ID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	now := time.Now().UTC()
+
+	authorID := int64(123456789)
+	postID := int64(1001)
+	dataHelper.InsertAuthor(t, authorID, "author", StringPtr("Author"), nil)
+
+	// Posts ingested before raw_text existed, with a media description appended
+	for _, userID := range []uuid.UUID{user1ID, user2ID} {
+		dataHelper.InsertPost(t, userID, postID, authorID, now.Add(-time.Hour),
+			"https://x.com/author/status/1001", "hello\n\n[Image 1: a cat]", nil, now, now, false)
+	}
+
+	// First re-fetch adopts the text without recording an edit
+	edited, err := postRepo.ApplyPostText(ctx, postID, "hello", now)
+	if err != nil {
+		t.Fatalf("ApplyPostText failed: %v", err)
+	}
+	if edited {
+		t.Error("Expected adopting raw text not to be an edit")
+	}
+
+	edited, err = postRepo.ApplyPostText(ctx, postID, "hello, edited", now)
+	if err != nil {
+		t.Fatalf("ApplyPostText failed: %v", err)
+	}
+	if !edited {
+		t.Fatal("Expected changed text to be recorded as an edit")
+	}
+
+	t.Run("EditAppliedToEveryUser", func(t *testing.T) {
+		var texts []string
+		err := db.Select(&texts, `SELECT text FROM posts WHERE x_post_id = $1 AND edited_seen`, postID)
+		if err != nil {
+			t.Fatalf("Failed to query posts: %v", err)
+		}
+		if len(texts) != 2 {
+			t.Fatalf("Expected 2 edited copies, got %d", len(texts))
+		}
+		for _, text := range texts {
+			if text != "hello, edited\n\n[Image 1: a cat]" {
+				t.Errorf("Expected media description to be kept, got %q", text)
+			}
+		}
+	})
+
+	t.Run("RevisionRecordedOnce", func(t *testing.T) {
+		edited, err := postRepo.ApplyPostText(ctx, postID, "hello, edited", now)
+		if err != nil {
+			t.Fatalf("ApplyPostText failed: %v", err)
+		}
+		if edited {
+			t.Error("Expected unchanged text not to be an edit")
+		}
+
+		var previousTexts []string
+		err = db.Select(&previousTexts, `SELECT previous_text FROM post_revisions WHERE x_post_id = $1`, postID)
+		if err != nil {
+			t.Fatalf("Failed to query post revisions: %v", err)
+		}
+		if len(previousTexts) != 1 || previousTexts[0] != "hello" {
+			t.Errorf("Expected one revision of %q, got %v", "hello", previousTexts)
+		}
+	})
+
+	t.Run("DeletedPostExcluded", func(t *testing.T) {
+		marked, err := postRepo.MarkPostsDeleted(ctx, []int64{postID, 9999}, now)
+		if err != nil {
+			t.Fatalf("MarkPostsDeleted failed: %v", err)
+		}
+		if marked != 1 {
+			t.Errorf("Expected 1 post marked deleted, got %d", marked)
+		}
+
+		posts, err := postRepo.GetPostsByDateRange(ctx, user1ID, now.Add(-24*time.Hour), now, nil, "")
+		if err != nil {
+			t.Fatalf("GetPostsByDateRange failed: %v", err)
+		}
+		if len(posts) != 0 {
+			t.Errorf("Expected deleted post to be excluded, got %d posts", len(posts))
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+)
+
+// TestSearchSourcesIntegration tests the search source endpoints and tagging of search results
+func TestSearchSourcesIntegration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("SearchSourceEndpoints", func(t *testing.T) {
+		testSearchSourceEndpoints(t, dbHelper)
+	})
+
+	t.Run("SearchResults", func(t *testing.T) {
+		testSearchResults(t, dbHelper)
+	})
+}
+
+// testSearchSourceEndpoints tests saving, listing and removing search sources
+func testSearchSourceEndpoints(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	router := NewTestRouter(dbHelper.GetDB()).GetEngine()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	doRequest := func(method, path string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.Header.Set("X-Test-User-ID", userID.String())
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	var created dto.SearchSourceDTO
+
+	t.Run("Add", func(t *testing.T) {
+		w := doRequest("POST", "/api/v1/search-sources", []byte(`{"query": " \"rust async\" min_faves:50 ", "name": "Rust"}`))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if created.ID == "" || created.Query != `"rust async" min_faves:50` {
+			t.Errorf("Expected a trimmed query with an ID, got %+v", created)
+		}
+
+		w = doRequest("POST", "/api/v1/search-sources", []byte(`{"query": "\"rust async\" min_faves:50"}`))
+		if w.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 for a query saved twice, got %d", w.Code)
+		}
+
+		w = doRequest("POST", "/api/v1/search-sources", []byte(`{"query": "   "}`))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for an empty query, got %d", w.Code)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		w := doRequest("GET", "/api/v1/search-sources", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		var response dto.SearchSourcesResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if len(response.Items) != 1 || response.Items[0].ID != created.ID || response.Items[0].Name != "Rust" {
+			t.Errorf("Expected the saved search named Rust, got %+v", response.Items)
+		}
+		if response.Items[0].LastRunAt != nil {
+			t.Error("Expected search not to have run yet")
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		w := doRequest("DELETE", "/api/v1/search-sources/"+created.ID, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+
+		w = doRequest("DELETE", "/api/v1/search-sources/"+created.ID, nil)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for a removed search, got %d", w.Code)
+		}
+	})
+}
+
+// testSearchResults tests that search results are tagged, counted and included in Q&A retrieval
+func testSearchResults(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	database := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(database)
+	searchSourceRepo := repositories.NewSearchSourceRepository(database)
+	postRepo := repositories.NewPostRepository(database)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now().UTC()
+
+	authorID := int64(333)
+	dataHelper.InsertAuthor(t, authorID, "rustacean", StringPtr("Rustacean"), nil)
+
+	source, err := searchSourceRepo.InsertSearchSource(ctx, "01JF0000000000000000000001", userID, "rust async", nil)
+	if err != nil || source == nil {
+		t.Fatalf("InsertSearchSource failed: %v", err)
+	}
+
+	postID := int64(7001)
+	err = postRepo.InsertPost(ctx, userID, &dto.TweetDTO{
+		ID:          postID,
+		AuthorID:    authorID,
+		Text:        "async rust",
+		RawText:     "async rust",
+		URL:         fmt.Sprintf("https://x.com/rustacean/status/%d", postID),
+		PublishedAt: now.Add(-time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("InsertPost failed: %v", err)
+	}
+	if err := searchSourceRepo.TagPost(ctx, userID, postID, source.ID); err != nil {
+		t.Fatalf("TagPost failed: %v", err)
+	}
+
+	// The newest seen result never moves backwards
+	if err := searchSourceRepo.MarkSearchRun(ctx, userID, source.ID, postID, now); err != nil {
+		t.Fatalf("MarkSearchRun failed: %v", err)
+	}
+	if err := searchSourceRepo.MarkSearchRun(ctx, userID, source.ID, 0, now); err != nil {
+		t.Fatalf("MarkSearchRun failed: %v", err)
+	}
+
+	sources, err := searchSourceRepo.GetSearchSources(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetSearchSources failed: %v", err)
+	}
+	if len(sources) != 1 || sources[0].PostCount != 1 || sources[0].LastRunAt == nil {
+		t.Fatalf("Expected one run search with 1 post, got %+v", sources)
+	}
+	if sources[0].LastTweetID == nil || *sources[0].LastTweetID != postID {
+		t.Errorf("Expected last_tweet_id %d, got %v", postID, sources[0].LastTweetID)
+	}
+
+	posts, err := postRepo.GetPostsByDateRange(ctx, userID, now.Add(-24*time.Hour), now, nil, "")
+	if err != nil {
+		t.Fatalf("GetPostsByDateRange failed: %v", err)
+	}
+	if len(posts) != 1 || posts[0].XPostID != postID {
+		t.Errorf("Expected the search result to be used for Q&A, got %d posts", len(posts))
+	}
+}
@@ -31,21 +31,30 @@ func NewTestRouterWithClient(db *sqlx.DB, httpClient *http.Client) *TestRouter {
 
 	// Initialize dependencies
 	ingestRepo := repositories.NewIngestRepository(db)
+	ingestJobRepo := repositories.NewIngestJobRepository(db)
 	followingRepo := repositories.NewFollowingRepository(db)
 	postRepo := repositories.NewPostRepository(db)
 	authorRepo := repositories.NewAuthorRepository(db)
+	watermarkRepo := repositories.NewWatermarkRepository(db)
+	ingestPolicyRepo := repositories.NewIngestPolicyRepository(db)
+	feedListRepo := repositories.NewFeedListRepository(db)
+	searchSourceRepo := repositories.NewSearchSourceRepository(db)
 	userRepo := repositories.NewUserRepository(db)
 	twitterClient := services.NewTwitterClient("", httpClient)       // Empty API key for testing
 	openRouterClient := services.NewOpenRouterClient("", httpClient) // Empty API key for testing
-	ingestService := services.NewIngestService(twitterClient, openRouterClient, ingestRepo, followingRepo, postRepo, authorRepo, userRepo)
+	fetchPlanner := services.NewFetchPlanner(twitterClient, followingRepo, repositories.NewTimelineFetchRepository(db), services.DefaultFetchPlannerConfig())
+	imageDescriber := services.NewImageDescriber(openRouterClient, repositories.NewMediaCacheRepository(db), httpClient, services.DefaultImageDescriberConfig())
+	ingestService := services.NewIngestService(twitterClient, fetchPlanner, openRouterClient, ingestRepo, followingRepo, postRepo, authorRepo, watermarkRepo, userRepo, ingestPolicyRepo, feedListRepo, searchSourceRepo, nil, imageDescriber, nil)
+	ingestQueue := services.NewIngestQueue(ingestService, ingestJobRepo, services.DefaultIngestQueueConfig())
 	ingestStatusService := services.NewIngestStatusService(ingestRepo)
-	ingestHandler := handlers.NewIngestHandler(ingestStatusService, ingestService)
+	ingestPolicyService := services.NewIngestPolicyService(ingestPolicyRepo)
+	ingestHandler := handlers.NewIngestHandler(ingestStatusService, ingestQueue, ingestPolicyService)
 
 	// Initialize QA dependencies
 	qaRepo := repositories.NewQARepository(db)
 	//postRepo := repositories.NewPostRepository(db)
 	llmService := services.NewLLMService(openRouterClient) // Mock service for testing
-	qaService := services.NewQAService(db, postRepo, qaRepo, llmService)
+	qaService := services.NewQAService(db, postRepo, qaRepo, feedListRepo, llmService)
 	qaHandler := handlers.NewQAHandler(qaService)
 
 	// Initialize Following dependencies
@@ -53,6 +62,14 @@ func NewTestRouterWithClient(db *sqlx.DB, httpClient *http.Client) *TestRouter {
 	followingService := services.NewFollowingService(followingRepo)
 	followingHandler := handlers.NewFollowingHandler(followingService)
 
+	// Initialize List dependencies
+	feedListService := services.NewFeedListService(feedListRepo)
+	feedListHandler := handlers.NewFeedListHandler(feedListService)
+
+	// Initialize Search source dependencies
+	searchSourceService := services.NewSearchSourceService(searchSourceRepo)
+	searchSourceHandler := handlers.NewSearchSourceHandler(searchSourceService)
+
 	// Setup routes with auth middleware
 	v1 := router.Group("/api/v1")
 	ingest := v1.Group("/ingest")
@@ -60,6 +77,11 @@ func NewTestRouterWithClient(db *sqlx.DB, httpClient *http.Client) *TestRouter {
 	{
 		ingest.GET("/status", ingestHandler.GetIngestStatus)
 		ingest.POST("/trigger", ingestHandler.TriggerIngest)
+		ingest.GET("/runs/:id", ingestHandler.GetIngestRun)
+		ingest.POST("/runs/:id/cancel", ingestHandler.CancelIngestRun)
+		ingest.GET("/rate-limits", ingestHandler.GetRateLimitStats)
+		ingest.GET("/policy", ingestHandler.GetIngestPolicy)
+		ingest.PUT("/policy", ingestHandler.UpdateIngestPolicy)
 	}
 
 	qa := v1.Group("/qa")
@@ -76,6 +98,25 @@ func NewTestRouterWithClient(db *sqlx.DB, httpClient *http.Client) *TestRouter {
 	following.Use(testAuthMiddleware())
 	{
 		following.GET("", followingHandler.GetFollowing)
+		following.GET("/events", followingHandler.GetFollowingEvents)
+		following.PUT("/:x_author_id/pin", followingHandler.PinAuthor)
+		following.DELETE("/:x_author_id/pin", followingHandler.UnpinAuthor)
+	}
+
+	lists := v1.Group("/lists")
+	lists.Use(testAuthMiddleware())
+	{
+		lists.GET("", feedListHandler.GetLists)
+		lists.POST("", feedListHandler.AddList)
+		lists.DELETE("/:x_list_id", feedListHandler.RemoveList)
+	}
+
+	searchSources := v1.Group("/search-sources")
+	searchSources.Use(testAuthMiddleware())
+	{
+		searchSources.GET("", searchSourceHandler.GetSearchSources)
+		searchSources.POST("", searchSourceHandler.AddSearchSource)
+		searchSources.DELETE("/:id", searchSourceHandler.RemoveSearchSource)
 	}
 
 	return &TestRouter{engine: router}
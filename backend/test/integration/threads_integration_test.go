@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sopeal/AskYourFeed/internal/dto"
+	"github.com/sopeal/AskYourFeed/internal/repositories"
+)
+
+// TestThreadsIntegration tests the threads view and the repository methods built on it
+func TestThreadsIntegration(t *testing.T) {
+	dbHelper := NewDatabaseHelper(t)
+	defer dbHelper.Close()
+
+	t.Run("IncompleteThreads", func(t *testing.T) {
+		testIncompleteThreads(t, dbHelper)
+	})
+}
+
+// testIncompleteThreads tests that a thread missing its root is reported until the root is ingested
+func testIncompleteThreads(t *testing.T, dbHelper *DatabaseHelper) {
+	dbHelper.CleanupTestData(t)
+
+	db := dbHelper.GetDB()
+	dataHelper := NewTestDataHelper(db)
+	postRepo := repositories.NewPostRepository(db)
+	ctx := context.Background()
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Now().UTC()
+
+	authorID := int64(123456789)
+	dataHelper.InsertAuthor(t, authorID, "author", StringPtr("Author"), nil)
+
+	insertTweet := func(id, conversationID, inReplyToID int64, publishedAt time.Time) {
+		t.Helper()
+		err := postRepo.InsertPost(ctx, userID, &dto.TweetDTO{
+			ID:             id,
+			AuthorID:       authorID,
+			Text:           fmt.Sprintf("part %d", id),
+			RawText:        fmt.Sprintf("part %d", id),
+			URL:            fmt.Sprintf("https://x.com/author/status/%d", id),
+			PublishedAt:    publishedAt,
+			ConversationID: conversationID,
+			InReplyToID:    inReplyToID,
+		})
+		if err != nil {
+			t.Fatalf("InsertPost failed: %v", err)
+		}
+	}
+
+	// Self-replies of a thread whose root was not ingested, and an unrelated post
+	insertTweet(2003, 2001, 2002, now.Add(-time.Hour))
+	insertTweet(2002, 2001, 2001, now.Add(-2*time.Hour))
+	insertTweet(3001, 3001, 0, now.Add(-3*time.Hour))
+
+	threads, err := postRepo.ListIncompleteThreads(ctx, userID, now.Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatalf("ListIncompleteThreads failed: %v", err)
+	}
+	if len(threads) != 1 {
+		t.Fatalf("Expected 1 incomplete thread, got %d", len(threads))
+	}
+	if threads[0].ConversationID != 2001 || len(threads[0].PostIDs) != 2 ||
+		threads[0].PostIDs[0] != 2002 || threads[0].PostIDs[1] != 2003 {
+		t.Errorf("Unexpected thread: %+v", threads[0])
+	}
+
+	t.Run("ThreadPostsInOrder", func(t *testing.T) {
+		posts, err := postRepo.GetThreadPosts(ctx, userID, []int64{2001, 3001})
+		if err != nil {
+			t.Fatalf("GetThreadPosts failed: %v", err)
+		}
+		if len(posts) != 2 || posts[0].XPostID != 2002 || posts[1].XPostID != 2003 {
+			t.Errorf("Expected posts 2002 and 2003 of the thread, got %+v", posts)
+		}
+	})
+
+	t.Run("CompleteAfterRootIngested", func(t *testing.T) {
+		insertTweet(2001, 2001, 0, now.Add(-3*time.Hour))
+
+		threads, err := postRepo.ListIncompleteThreads(ctx, userID, now.Add(-time.Hour), 10)
+		if err != nil {
+			t.Fatalf("ListIncompleteThreads failed: %v", err)
+		}
+		if len(threads) != 0 {
+			t.Errorf("Expected no incomplete threads, got %d", len(threads))
+		}
+
+		posts, err := postRepo.GetThreadPosts(ctx, userID, []int64{2001})
+		if err != nil {
+			t.Fatalf("GetThreadPosts failed: %v", err)
+		}
+		if len(posts) != 3 || posts[0].XPostID != 2001 {
+			t.Errorf("Expected the thread to start with its root, got %+v", posts)
+		}
+	})
+}
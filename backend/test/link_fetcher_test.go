@@ -0,0 +1,207 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+const linkFixturePage = `<!DOCTYPE html>
+<html>
+<head>
+	<title>Great writeup  on rates</title>
+	<meta name="description" content="Why rates stay high">
+	<style>body { color: red; }</style>
+	<script>var tracking = "do not read";</script>
+</head>
+<body>
+	<nav><a href="/">Home</a> <a href="/about">About</a></nav>
+	<header>Site header</header>
+	<article>
+		<h1>Why rates stay high</h1>
+		<p>Inflation   is sticky.</p>
+		<p>Wages keep <b>rising</b>.</p>
+	</article>
+	<footer>Copyright</footer>
+</body>
+</html>`
+
+// newLinkFixtureServer serves the pages used by the link fetcher tests
+func newLinkFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(linkFixturePage))
+	})
+	mux.HandleFunc("/short", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/article", http.StatusFound)
+	})
+	mux.HandleFunc("/notes.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("  " + strings.Repeat("a", 100) + "  "))
+	})
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte{0x89, 0x50, 0x4e, 0x47})
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestLinkFetcherReadablePage tests extracting the title and article text of an HTML page after a redirect
+func TestLinkFetcherReadablePage(t *testing.T) {
+	server := newLinkFixtureServer(t)
+	fetcher := services.NewLinkFetcher(server.Client(), services.DefaultLinkFetcherConfig())
+
+	page, err := fetcher.Fetch(context.Background(), server.URL+"/short")
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+
+	if page.URL != server.URL+"/short" || page.FinalURL != server.URL+"/article" {
+		t.Errorf("Expected the redirect to be followed to /article, got %q -> %q", page.URL, page.FinalURL)
+	}
+	if page.Title != "Great writeup on rates" {
+		t.Errorf("Expected title %q, got %q", "Great writeup on rates", page.Title)
+	}
+
+	want := "Why rates stay high\nInflation is sticky.\nWages keep rising."
+	if page.Text != want {
+		t.Errorf("Expected only the article text %q, got %q", want, page.Text)
+	}
+}
+
+// TestLinkFetcherLimits tests the size limit and the rejection of unreadable responses
+func TestLinkFetcherLimits(t *testing.T) {
+	server := newLinkFixtureServer(t)
+
+	t.Run("size limit", func(t *testing.T) {
+		config := services.DefaultLinkFetcherConfig()
+		config.MaxBodyBytes = 20
+		fetcher := services.NewLinkFetcher(server.Client(), config)
+
+		page, err := fetcher.Fetch(context.Background(), server.URL+"/notes.txt")
+		if err != nil {
+			t.Fatalf("Fetch returned error: %v", err)
+		}
+		if page.Text != strings.Repeat("a", 18) {
+			t.Errorf("Expected the text to stop at 20 bytes, got %q", page.Text)
+		}
+	})
+
+	t.Run("unsupported content", func(t *testing.T) {
+		fetcher := services.NewLinkFetcher(server.Client(), services.DefaultLinkFetcherConfig())
+		_, err := fetcher.Fetch(context.Background(), server.URL+"/image.png")
+		if !errors.Is(err, services.ErrLinkUnsupportedContent) {
+			t.Errorf("Expected ErrLinkUnsupportedContent, got %v", err)
+		}
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		fetcher := services.NewLinkFetcher(server.Client(), services.DefaultLinkFetcherConfig())
+		if _, err := fetcher.Fetch(context.Background(), server.URL+"/missing"); err == nil {
+			t.Error("Expected an error for a 404 page")
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		config := services.DefaultLinkFetcherConfig()
+		config.Timeout = 50 * time.Millisecond
+		fetcher := services.NewLinkFetcher(server.Client(), config)
+
+		start := time.Now()
+		if _, err := fetcher.Fetch(context.Background(), server.URL+"/slow"); err == nil {
+			t.Error("Expected an error for a page slower than the timeout")
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Expected the fetch to stop at the timeout, took %v", elapsed)
+		}
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		fetcher := services.NewLinkFetcher(server.Client(), services.DefaultLinkFetcherConfig())
+		_, err := fetcher.Fetch(context.Background(), "file:///etc/passwd")
+		if !errors.Is(err, services.ErrLinkUnsupportedScheme) {
+			t.Errorf("Expected ErrLinkUnsupportedScheme, got %v", err)
+		}
+	})
+}
+
+// TestLinkFetcherBlocksPrivateAddresses tests that the default client refuses the loopback fixture server
+func TestLinkFetcherBlocksPrivateAddresses(t *testing.T) {
+	server := newLinkFixtureServer(t)
+	fetcher := services.NewLinkFetcher(nil, services.DefaultLinkFetcherConfig())
+
+	_, err := fetcher.Fetch(context.Background(), server.URL+"/article")
+	if !errors.Is(err, services.ErrLinkBlockedAddress) {
+		t.Errorf("Expected ErrLinkBlockedAddress for a loopback address, got %v", err)
+	}
+}
+
+// TestIsPublicAddress tests classifying addresses a linked page may be fetched from
+func TestIsPublicAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := services.IsPublicAddress(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("IsPublicAddress(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestOutboundLinks tests collecting the links of a tweet to pages outside X
+func TestOutboundLinks(t *testing.T) {
+	tweet := services.TweetData{
+		Text: "Great writeup https://t.co/abc also https://example.org/b and https://x.com/someone/status/1",
+		Entities: &services.EntityData{URLs: []services.URLEntity{
+			{URL: "https://t.co/abc", ExpandedURL: "https://example.com/a"},
+			{URL: "https://t.co/def", ExpandedURL: "https://mobile.twitter.com/i/article/1"},
+			{URL: "https://t.co/ghi", ExpandedURL: "https://example.com/a"},
+		}},
+	}
+
+	links := services.OutboundLinks(tweet)
+	want := []string{"https://example.com/a", "https://example.org/b"}
+	if strings.Join(links, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected %v, got %v", want, links)
+	}
+}
@@ -0,0 +1,53 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// TestMediaFromTweet tests listing the media of a tweet as pending rows, images first
+func TestMediaFromTweet(t *testing.T) {
+	tweet := &services.TweetData{
+		ID: "9301",
+		Media: &services.MediaData{
+			Photos: []services.PhotoData{
+				{URL: "https://pbs.twimg.com/media/1.jpg"},
+				{URL: "https://pbs.twimg.com/media/2.jpg"},
+				{URL: "https://pbs.twimg.com/media/3.jpg"},
+				{URL: "https://pbs.twimg.com/media/4.jpg"},
+				{URL: "https://pbs.twimg.com/media/5.jpg"},
+			},
+			Videos: []services.VideoData{
+				{URL: "https://video.twimg.com/1.mp4", DurationMs: 42000},
+			},
+		},
+	}
+
+	media := services.MediaFromTweet(tweet, 9301)
+	if len(media) != services.MaxImagesPerPost+1 {
+		t.Fatalf("Expected %d images and 1 video, got %d items", services.MaxImagesPerPost, len(media))
+	}
+
+	for i, item := range media {
+		if item.MediaIndex != i || item.XPostID != 9301 || item.Status != db.MediaStatusPending || item.Description != nil {
+			t.Errorf("Expected pending item %d of post 9301, got %+v", i, item)
+		}
+		if item.URLHash != services.MediaURLHash(item.URL) || len(item.URLHash) != 64 {
+			t.Errorf("Expected the SHA-256 hex of the URL, got %q", item.URLHash)
+		}
+	}
+
+	video := media[len(media)-1]
+	if video.MediaType != db.MediaTypeVideo || video.DurationMs == nil || *video.DurationMs != 42000 {
+		t.Errorf("Expected the video last with its duration, got %+v", video)
+	}
+	if media[0].MediaType != db.MediaTypeImage || media[0].URL != "https://pbs.twimg.com/media/1.jpg" {
+		t.Errorf("Expected the first image first, got %+v", media[0])
+	}
+
+	if got := services.MediaFromTweet(&services.TweetData{ID: "9302"}, 9302); len(got) != 0 {
+		t.Errorf("Expected no media for a tweet without media, got %d items", len(got))
+	}
+}
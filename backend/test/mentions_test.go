@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// TestGetUserMentions tests reading a page of mentions newer than a given time
+func TestGetUserMentions(t *testing.T) {
+	var requestedUser, requestedSince, requestedCursor string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/twitter/user/mentions" {
+			t.Errorf("Expected path /twitter/user/mentions, got %s", r.URL.Path)
+		}
+		requestedUser = r.URL.Query().Get("userName")
+		requestedSince = r.URL.Query().Get("sinceTime")
+		requestedCursor = r.URL.Query().Get("cursor")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"tweets": [
+				{"id": "9101", "text": "@owner when is the next release?", "isReply": true, "createdAt": "Mon Dec 15 10:00:00 +0000 2025", "author": {"id": "77", "userName": "asker"}}
+			],
+			"has_next_page": false,
+			"next_cursor": "",
+			"status": "success",
+			"message": ""
+		}`))
+	}))
+	defer server.Close()
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+
+	since := time.Date(2025, 12, 14, 0, 0, 0, 0, time.UTC)
+	resp, err := client.GetUserMentions(context.Background(), "owner", since, "page-1")
+	if err != nil {
+		t.Fatalf("GetUserMentions returned error: %v", err)
+	}
+
+	if requestedUser != "owner" || requestedSince != "1765670400" || requestedCursor != "page-1" {
+		t.Errorf("Expected owner since 1765670400 with cursor page-1, got %q %q %q", requestedUser, requestedSince, requestedCursor)
+	}
+	if len(resp.Tweets) != 1 || resp.Tweets[0].Author.UserName != "asker" || !resp.Tweets[0].IsReply {
+		t.Fatalf("Expected a reply by asker, got %+v", resp.Tweets)
+	}
+	if resp.HasNextPage {
+		t.Error("Expected no next page")
+	}
+}
+
+// TestGetUserMentionsWithoutSince tests that a zero time leaves sinceTime out
+func TestGetUserMentionsWithoutSince(t *testing.T) {
+	sinceSet := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sinceSet = r.URL.Query().Has("sinceTime")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tweets": [], "has_next_page": false, "status": "success"}`))
+	}))
+	defer server.Close()
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+
+	if _, err := client.GetUserMentions(context.Background(), "owner", time.Time{}, ""); err != nil {
+		t.Fatalf("GetUserMentions returned error: %v", err)
+	}
+	if sinceSet {
+		t.Error("Expected sinceTime to be left out for a zero time")
+	}
+}
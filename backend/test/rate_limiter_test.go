@@ -0,0 +1,72 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// TestRateLimiter tests that the token bucket allows a burst and then paces requests
+func TestRateLimiter(t *testing.T) {
+	tests := []struct {
+		name       string
+		rate       float64
+		burst      int
+		requests   int
+		minElapsed time.Duration
+		maxElapsed time.Duration
+	}{
+		{
+			name:       "Burst is served immediately",
+			rate:       10,
+			burst:      5,
+			requests:   5,
+			minElapsed: 0,
+			maxElapsed: 50 * time.Millisecond,
+		},
+		{
+			name:       "Requests beyond burst are paced",
+			rate:       50,
+			burst:      1,
+			requests:   6, // 1 immediate + 5 at 20ms intervals
+			minElapsed: 80 * time.Millisecond,
+			maxElapsed: 500 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := services.NewRateLimiter(tt.rate, tt.burst)
+
+			start := time.Now()
+			for i := 0; i < tt.requests; i++ {
+				if err := limiter.Wait(context.Background()); err != nil {
+					t.Fatalf("Wait returned error: %v", err)
+				}
+			}
+			elapsed := time.Since(start)
+
+			if elapsed < tt.minElapsed || elapsed > tt.maxElapsed {
+				t.Errorf("Expected %d requests to take between %v and %v, took %v",
+					tt.requests, tt.minElapsed, tt.maxElapsed, elapsed)
+			}
+		})
+	}
+}
+
+// TestRateLimiterPauseAndCancel tests that a paused limiter holds callers back until their context is done
+func TestRateLimiterPauseAndCancel(t *testing.T) {
+	limiter := services.NewRateLimiter(1000, 10)
+	limiter.Pause(time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
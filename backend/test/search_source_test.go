@@ -0,0 +1,68 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// TestSearchTweets tests reading a page of advanced search results
+func TestSearchTweets(t *testing.T) {
+	var requestedQuery, requestedType, requestedCursor string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/twitter/tweet/advanced_search" {
+			t.Errorf("Expected path /twitter/tweet/advanced_search, got %s", r.URL.Path)
+		}
+		requestedQuery = r.URL.Query().Get("query")
+		requestedType = r.URL.Query().Get("queryType")
+		requestedCursor = r.URL.Query().Get("cursor")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"tweets": [
+				{"id": "9001", "text": "async rust is great", "createdAt": "Mon Dec 15 10:00:00 +0000 2025", "author": {"id": "42", "userName": "rustacean"}}
+			],
+			"has_next_page": true,
+			"next_cursor": "page-2"
+		}`))
+	}))
+	defer server.Close()
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+
+	query := `"rust async" min_faves:50`
+	resp, err := client.SearchTweets(context.Background(), query, "page-1")
+	if err != nil {
+		t.Fatalf("SearchTweets returned error: %v", err)
+	}
+
+	if requestedQuery != query || requestedType != "Latest" || requestedCursor != "page-1" {
+		t.Errorf("Expected query %q of type Latest with cursor page-1, got %q %q %q", query, requestedQuery, requestedType, requestedCursor)
+	}
+	if len(resp.Tweets) != 1 || resp.Tweets[0].ID != "9001" || resp.Tweets[0].Author.UserName != "rustacean" {
+		t.Fatalf("Expected tweet 9001 by rustacean, got %+v", resp.Tweets)
+	}
+	if !resp.HasNextPage || resp.NextCursor != "page-2" {
+		t.Errorf("Expected next page page-2, got %v %q", resp.HasNextPage, resp.NextCursor)
+	}
+}
+
+// TestSearchTweetsErrorStatus tests that an error status in the body is returned as an error
+func TestSearchTweetsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tweets": [], "status": "error", "msg": "invalid query"}`))
+	}))
+	defer server.Close()
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+
+	if _, err := client.SearchTweets(context.Background(), "min_faves:", ""); err == nil {
+		t.Fatal("Expected error for error status, got nil")
+	}
+}
@@ -0,0 +1,128 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/db"
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// TestGetTweetThreadContext tests the thread context lookup and detection of self-replies
+func TestGetTweetThreadContext(t *testing.T) {
+	var requestedID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/twitter/tweet/thread_context" {
+			t.Errorf("Expected path /twitter/tweet/thread_context, got %s", r.URL.Path)
+		}
+		requestedID = r.URL.Query().Get("tweetId")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"tweets": [
+				{
+					"id": "2001",
+					"text": "1/ a thread",
+					"createdAt": "Mon Jan 02 15:04:05 +0000 2006",
+					"conversationId": "2001",
+					"author": {"id": "42", "userName": "author"}
+				},
+				{
+					"id": "2002",
+					"text": "2/ continued",
+					"createdAt": "Mon Jan 02 15:05:05 +0000 2006",
+					"isReply": true,
+					"inReplyToId": "2001",
+					"inReplyToUserId": "42",
+					"conversationId": "2001",
+					"author": {"id": "42", "userName": "author"}
+				},
+				{
+					"id": "2003",
+					"text": "great thread",
+					"createdAt": "Mon Jan 02 15:06:05 +0000 2006",
+					"isReply": true,
+					"inReplyToId": "2002",
+					"inReplyToUserId": "42",
+					"conversationId": "2001",
+					"author": {"id": "7", "userName": "reader"}
+				}
+			],
+			"has_next_page": false,
+			"next_cursor": "",
+			"status": "success",
+			"message": ""
+		}`))
+	}))
+	defer server.Close()
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+
+	resp, err := client.GetTweetThreadContext(context.Background(), "2002", "")
+	if err != nil {
+		t.Fatalf("GetTweetThreadContext returned error: %v", err)
+	}
+
+	if requestedID != "2002" {
+		t.Errorf("Expected tweetId %q, got %q", "2002", requestedID)
+	}
+	if len(resp.Tweets) != 3 {
+		t.Fatalf("Expected 3 tweets, got %d", len(resp.Tweets))
+	}
+
+	if !client.IsOriginalPost(resp.Tweets[1]) {
+		t.Error("Expected self-reply to be an original post")
+	}
+	if client.IsOriginalPost(resp.Tweets[2]) {
+		t.Error("Expected reply to another author not to be an original post")
+	}
+
+	if got := client.ConvertToDTO(resp.Tweets[1]).InReplyToID; got != 2001 {
+		t.Errorf("Expected self-reply parent 2001, got %d", got)
+	}
+	if got := client.ConvertToDTO(resp.Tweets[2]).InReplyToID; got != 0 {
+		t.Errorf("Expected no parent for a reply to another author, got %d", got)
+	}
+}
+
+// TestGroupThreads tests grouping posts into chronologically ordered threads
+func TestGroupThreads(t *testing.T) {
+	base := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	post := func(id, authorID, conversationID int64, offset time.Duration) db.PostWithAuthor {
+		p := db.PostWithAuthor{}
+		p.XPostID = id
+		p.AuthorID = authorID
+		p.PublishedAt = base.Add(offset)
+		if conversationID > 0 {
+			p.ConversationID = &conversationID
+		}
+		return p
+	}
+
+	threads := services.GroupThreads([]db.PostWithAuthor{
+		post(3, 1, 1, 2*time.Minute),
+		post(10, 2, 0, time.Minute),
+		post(1, 1, 1, 0),
+		post(20, 2, 1, 3*time.Minute), // another author in the same conversation
+		post(2, 1, 1, time.Minute),
+	})
+
+	expected := [][]int64{{1, 2, 3}, {10}, {20}}
+	if len(threads) != len(expected) {
+		t.Fatalf("Expected %d threads, got %d", len(expected), len(threads))
+	}
+	for i, thread := range threads {
+		if len(thread) != len(expected[i]) {
+			t.Fatalf("Thread %d: expected %d posts, got %d", i, len(expected[i]), len(thread))
+		}
+		for j, p := range thread {
+			if p.XPostID != expected[i][j] {
+				t.Errorf("Thread %d post %d: expected %d, got %d", i, j, expected[i][j], p.XPostID)
+			}
+		}
+	}
+}
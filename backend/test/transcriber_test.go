@@ -0,0 +1,127 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// newTranscriptionFixtureServer serves videos and a Whisper-compatible transcription endpoint
+// Each request to the endpoint is checked and the uploaded file is recorded in uploads
+func newTranscriptionFixtureServer(t *testing.T, uploads *[]string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/media/clip.mp4", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "video/mp4")
+		_, _ = w.Write([]byte("fake video bytes"))
+	})
+	mux.HandleFunc("/media/huge.mp4", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(services.MaxVideoSize+1))
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/media/unsized.mp4", func(w http.ResponseWriter, r *http.Request) {
+		// Streamed without Content-Length, so only the bytes read reveal the size
+		w.Header().Set("Content-Type", "video/mp4")
+		chunk := make([]byte, 1024*1024)
+		for written := 0; written <= services.MaxVideoSize; written += len(chunk) {
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		}
+	})
+	mux.HandleFunc("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"invalid api key"}}`))
+			return
+		}
+		if r.FormValue("model") != "whisper-1" || r.FormValue("response_format") != "json" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"unexpected form"}}`))
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"missing file"}}`))
+			return
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		content, _ := io.ReadAll(file)
+		*uploads = append(*uploads, header.Filename+":"+string(content))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"text":"  Rates will stay high this year.  "}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestWhisperTranscriber tests downloading a video and uploading it to the transcription endpoint
+func TestWhisperTranscriber(t *testing.T) {
+	var uploads []string
+	server := newTranscriptionFixtureServer(t, &uploads)
+	transcriber := services.NewWhisperTranscriber(server.URL+"/v1/", "test-key", "", server.Client())
+
+	if transcriber.Model() != services.DefaultTranscriptionModel {
+		t.Errorf("Expected the default model, got %q", transcriber.Model())
+	}
+
+	transcript, err := transcriber.Transcribe(context.Background(), server.URL+"/media/clip.mp4?tag=12", 42)
+	if err != nil {
+		t.Fatalf("Transcribe failed: %v", err)
+	}
+	if transcript != "Rates will stay high this year." {
+		t.Errorf("Expected the trimmed transcript, got %q", transcript)
+	}
+	if len(uploads) != 1 || uploads[0] != "clip.mp4:fake video bytes" {
+		t.Errorf("Expected the downloaded video to be uploaded as clip.mp4, got %v", uploads)
+	}
+
+	unauthorized := services.NewWhisperTranscriber(server.URL+"/v1", "wrong-key", "", server.Client())
+	if _, err := unauthorized.Transcribe(context.Background(), server.URL+"/media/clip.mp4", 42); err == nil {
+		t.Error("Expected an error for a rejected API key")
+	}
+}
+
+// TestWhisperTranscriberLimits tests that videos over the duration or size limits are not uploaded
+func TestWhisperTranscriberLimits(t *testing.T) {
+	var uploads []string
+	server := newTranscriptionFixtureServer(t, &uploads)
+	transcriber := services.NewWhisperTranscriber(server.URL+"/v1", "test-key", "", server.Client())
+
+	tests := []struct {
+		name            string
+		path            string
+		durationSeconds int
+	}{
+		{name: "too long", path: "/media/clip.mp4", durationSeconds: services.MaxVideoDuration + 1},
+		{name: "declared too large", path: "/media/huge.mp4"},
+		{name: "streamed too large", path: "/media/unsized.mp4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := transcriber.Transcribe(context.Background(), server.URL+tt.path, tt.durationSeconds)
+			if !errors.Is(err, services.ErrMediaExceedsLimit) {
+				t.Errorf("Expected ErrMediaExceedsLimit, got %v", err)
+			}
+		})
+	}
+
+	if len(uploads) != 0 {
+		t.Errorf("Expected no uploads, got %d", len(uploads))
+	}
+}
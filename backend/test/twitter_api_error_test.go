@@ -0,0 +1,106 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// TestTwitterClientAPIError tests that non-200 responses are returned as *services.APIError
+func TestTwitterClientAPIError(t *testing.T) {
+	tests := []struct {
+		name            string
+		statusCode      int
+		headers         map[string]string
+		body            string
+		expectedMessage string
+		minRetryAfter   time.Duration
+		maxRetryAfter   time.Duration
+		rateLimited     bool
+		retryable       bool
+	}{
+		{
+			name:            "Rate limited with Retry-After seconds",
+			statusCode:      http.StatusTooManyRequests,
+			headers:         map[string]string{"Retry-After": "3"},
+			body:            `{"status":"error","msg":"Too many requests"}`,
+			expectedMessage: "Too many requests",
+			minRetryAfter:   3 * time.Second,
+			maxRetryAfter:   3 * time.Second,
+			rateLimited:     true,
+			retryable:       true,
+		},
+		{
+			name:            "Rate limited with reset timestamp",
+			statusCode:      http.StatusTooManyRequests,
+			headers:         map[string]string{"x-rate-limit-reset": "{reset}"},
+			body:            `{"message":"rate limit exceeded"}`,
+			expectedMessage: "rate limit exceeded",
+			minRetryAfter:   8 * time.Second,
+			maxRetryAfter:   10 * time.Second,
+			rateLimited:     true,
+			retryable:       true,
+		},
+		{
+			name:            "Server error with plain body",
+			statusCode:      http.StatusBadGateway,
+			body:            "bad gateway",
+			expectedMessage: "bad gateway",
+			retryable:       true,
+		},
+		{
+			name:            "Client error is not retryable",
+			statusCode:      http.StatusBadRequest,
+			body:            `{"error":"invalid userName"}`,
+			expectedMessage: "invalid userName",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tt.headers {
+					if value == "{reset}" {
+						value = strconv.FormatInt(time.Now().Add(10*time.Second).Unix(), 10)
+					}
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := services.NewTwitterClient("test-api-key", server.Client())
+			client.BaseURL = server.URL
+
+			_, err := client.GetUserTweets(context.Background(), "testuser", "")
+
+			var apiErr *services.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected *services.APIError, got %T: %v", err, err)
+			}
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("Expected status code %d, got %d", tt.statusCode, apiErr.StatusCode)
+			}
+			if apiErr.Message != tt.expectedMessage {
+				t.Errorf("Expected message %q, got %q", tt.expectedMessage, apiErr.Message)
+			}
+			if apiErr.RetryAfter < tt.minRetryAfter || apiErr.RetryAfter > tt.maxRetryAfter {
+				t.Errorf("Expected RetryAfter between %v and %v, got %v",
+					tt.minRetryAfter, tt.maxRetryAfter, apiErr.RetryAfter)
+			}
+			if apiErr.IsRateLimited() != tt.rateLimited {
+				t.Errorf("Expected IsRateLimited() = %v", tt.rateLimited)
+			}
+			if apiErr.IsRetryable() != tt.retryable {
+				t.Errorf("Expected IsRetryable() = %v", tt.retryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sopeal/AskYourFeed/internal/services"
+)
+
+// TestGetTweetsByIDs tests the batch tweet lookup and conversion of engagement metrics
+func TestGetTweetsByIDs(t *testing.T) {
+	var requestedIDs string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/twitter/tweets" {
+			t.Errorf("Expected path /twitter/tweets, got %s", r.URL.Path)
+		}
+		requestedIDs = r.URL.Query().Get("tweet_ids")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"tweets": [
+				{
+					"id": "1001",
+					"url": "https://x.com/author/status/1001",
+					"text": "hello",
+					"createdAt": "Mon Jan 02 15:04:05 +0000 2006",
+					"likeCount": 120,
+					"retweetCount": 30,
+					"replyCount": 12,
+					"quoteCount": 4,
+					"viewCount": 98000,
+					"bookmarkCount": 7,
+					"author": {"id": "42", "userName": "author"}
+				}
+			],
+			"status": "success",
+			"message": ""
+		}`))
+	}))
+	defer server.Close()
+
+	client := services.NewTwitterClient("test-api-key", server.Client())
+	client.BaseURL = server.URL
+
+	resp, err := client.GetTweetsByIDs(context.Background(), []string{"1001", "1002"})
+	if err != nil {
+		t.Fatalf("GetTweetsByIDs returned error: %v", err)
+	}
+
+	if requestedIDs != "1001,1002" {
+		t.Errorf("Expected tweet_ids %q, got %q", "1001,1002", requestedIDs)
+	}
+	if len(resp.Tweets) != 1 {
+		t.Fatalf("Expected 1 tweet, got %d", len(resp.Tweets))
+	}
+
+	tweetDTO := client.ConvertToDTO(resp.Tweets[0])
+	if tweetDTO.LikeCount != 120 || tweetDTO.RetweetCount != 30 || tweetDTO.ReplyCount != 12 ||
+		tweetDTO.QuoteCount != 4 || tweetDTO.ViewCount != 98000 || tweetDTO.BookmarkCount != 7 {
+		t.Errorf("Unexpected engagement metrics: %+v", tweetDTO)
+	}
+}
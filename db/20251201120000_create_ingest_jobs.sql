@@ -0,0 +1,33 @@
+-- migration: create durable ingest job queue
+-- timestamp: 2025-12-01 12:00:00 utc
+-- purpose: replaces fire-and-forget ingestion goroutines with a postgres-backed job table.
+-- includes: ingest_jobs table with claim/lease columns, indexes for claiming and per-user lookups.
+-- notes: ingest_jobs is a system table consumed by workers across all users, so it has no row level security
+--        (same as authors). at most one pending (queued or running) job per user is allowed.
+
+-- create system table: ingest_jobs
+create table if not exists ingest_jobs (
+    id char(26) primary key,
+    user_id uuid not null,
+    backfill_hours int not null check (backfill_hours between 0 and 720),
+    status text not null check (status in ('queued','running','done','failed')),
+    attempts int not null default 0,
+    max_attempts int not null check (max_attempts > 0),
+    run_after timestamptz not null default now(),
+    locked_by text,
+    locked_until timestamptz,
+    last_error text,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+-- index used by workers to find claimable jobs (queued and due, or running with an expired lease)
+create index if not exists idx_ingest_jobs_claimable on ingest_jobs (run_after) where status in ('queued','running');
+
+-- create index for ingest_jobs on (user_id, created_at desc)
+create index if not exists idx_ingest_jobs_user_created on ingest_jobs (user_id, created_at desc);
+
+-- only one pending job per user; enqueueing again returns the existing job
+create unique index if not exists uq_ingest_jobs_user_pending on ingest_jobs (user_id) where status in ('queued','running');
+
+-- end of migration
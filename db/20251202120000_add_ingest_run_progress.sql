@@ -0,0 +1,36 @@
+-- migration: track per-phase ingest run progress and errors
+-- timestamp: 2025-12-02 12:00:00 utc
+-- purpose: ingest runs are now created synchronously when triggered, so clients can look them up by id.
+-- includes: phase/progress columns on ingest_runs, run_id on ingest_jobs, ingest_run_errors table with rls.
+
+-- add phase and per-phase counters to ingest_runs
+alter table ingest_runs
+    add column if not exists phase text not null default 'queued'
+        check (phase in ('queued','following','tweets','done')),
+    add column if not exists following_count int not null default 0,
+    add column if not exists tweets_count int not null default 0;
+
+-- link jobs to the run they execute
+alter table ingest_jobs
+    add column if not exists run_id char(26) references ingest_runs (id) on delete set null;
+
+-- create user-scoped table: ingest_run_errors
+-- non-fatal errors recorded while a run progresses (e.g. a single author failing)
+create table if not exists ingest_run_errors (
+    id bigserial primary key,
+    run_id char(26) not null references ingest_runs (id) on delete cascade,
+    user_id uuid not null,
+    phase text not null check (phase in ('following','tweets')),
+    author_handle text,
+    message text not null,
+    created_at timestamptz not null default now()
+);
+-- create index for ingest_run_errors on (run_id, created_at)
+create index if not exists idx_ingest_run_errors_run_created on ingest_run_errors (run_id, created_at);
+
+-- ingest_run_errors rls
+alter table ingest_run_errors enable row level security;
+create policy user_isolation_ingest_run_errors on ingest_run_errors
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+-- end of migration
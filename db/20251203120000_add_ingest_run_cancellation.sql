@@ -0,0 +1,18 @@
+-- migration: allow cancelling ingest runs
+-- timestamp: 2025-12-03 12:00:00 utc
+-- purpose: users can stop a running ingestion (e.g. a long backfill) via post /api/v1/ingest/runs/{id}/cancel.
+-- includes: cancel_requested_at column and 'cancelled' status on ingest_runs.
+-- notes: cancel_requested_at is polled by the worker executing the run, so a cancel issued on one replica
+--        also stops a run executing on another.
+
+-- add cancellation request timestamp to ingest_runs
+alter table ingest_runs
+    add column if not exists cancel_requested_at timestamptz;
+
+-- extend the status check with 'cancelled'
+-- destructive: the existing check constraint is dropped and recreated; existing rows already satisfy it
+alter table ingest_runs drop constraint if exists ingest_runs_status_check;
+alter table ingest_runs
+    add constraint ingest_runs_status_check check (status in ('ok','rate_limited','error','cancelled'));
+
+-- end of migration
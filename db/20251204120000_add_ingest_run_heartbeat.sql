@@ -0,0 +1,19 @@
+-- migration: add heartbeats to ingest runs
+-- timestamp: 2025-12-04 12:00:00 utc
+-- purpose: runs left open by a crashed process are detected and closed by a background reaper,
+--          so they no longer block new ingestions with 409 ingest_in_progress.
+-- includes: heartbeat_at column on ingest_runs, index for finding open runs.
+-- notes: heartbeat_at is refreshed by the worker executing the run. runs without a heartbeat fall back to
+--        started_at. runs whose job is still queued are waiting, not abandoned, and are never reaped.
+
+-- add heartbeat timestamp to ingest_runs
+alter table ingest_runs
+    add column if not exists heartbeat_at timestamptz;
+
+-- existing open runs start from their start time
+update ingest_runs set heartbeat_at = started_at where heartbeat_at is null and completed_at is null;
+
+-- index used by the reaper to scan open runs
+create index if not exists idx_ingest_runs_open on ingest_runs (heartbeat_at) where completed_at is null;
+
+-- end of migration
@@ -0,0 +1,24 @@
+-- migration: allow at most one open ingest run per user
+-- timestamp: 2025-12-05 12:00:00 utc
+-- purpose: the "one ingestion at a time" rule was enforced with a check-then-insert in the application,
+--          which two quick triggers or two service replicas could both pass.
+-- includes: cleanup of duplicate open runs, partial unique index on ingest_runs (user_id) for open runs.
+-- notes: inserting a second open run for a user now fails with a unique violation (sqlstate 23505),
+--        which the application reports as 409 ingest_in_progress.
+
+-- close duplicate open runs, keeping the most recently started one per user
+-- destructive: older duplicates are marked as failed so the unique index can be created
+update ingest_runs r
+set completed_at = now(), status = 'error', err_text = 'superseded by a concurrent ingest run'
+where r.completed_at is null
+  and exists (
+      select 1 from ingest_runs newer
+      where newer.user_id = r.user_id
+        and newer.completed_at is null
+        and (newer.started_at, newer.id) > (r.started_at, r.id)
+  );
+
+-- only one open (completed_at is null) run per user
+create unique index if not exists uq_ingest_runs_user_open on ingest_runs (user_id) where completed_at is null;
+
+-- end of migration
@@ -0,0 +1,23 @@
+-- migration: per-author incremental sync watermarks
+-- timestamp: 2025-12-06 12:00:00 utc
+-- purpose: regular ingests only read the first page of each author, so tweets beyond it were lost between syncs.
+-- includes: author_watermarks table with rls.
+-- notes: the watermark is the newest tweet ingested for a (user, author) pair; ingest paginates until it reaches it.
+
+-- create user-scoped table: author_watermarks
+create table if not exists author_watermarks (
+    user_id uuid not null,
+    x_author_id bigint not null,
+    last_tweet_id bigint not null check (last_tweet_id > 0),
+    last_tweet_at timestamptz not null,
+    updated_at timestamptz not null default now(),
+    constraint pk_author_watermarks primary key (user_id, x_author_id),
+    constraint fk_author_watermarks_author foreign key (x_author_id) references authors (x_author_id) on delete cascade
+);
+
+-- author_watermarks rls
+alter table author_watermarks enable row level security;
+create policy user_isolation_author_watermarks on author_watermarks
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+-- end of migration
@@ -0,0 +1,29 @@
+-- migration: reconcile unfollows during following sync
+-- timestamp: 2025-12-07 12:00:00 utc
+-- purpose: accounts unfollowed on x stayed in user_following and kept being ingested forever.
+-- includes: unfollowed_at soft-deactivation column on user_following, following_events table with rls.
+-- notes: unfollowed authors are kept (with their posts) and reactivated if followed again.
+
+-- soft-deactivate unfollowed authors; null means the author is currently followed
+alter table user_following
+    add column if not exists unfollowed_at timestamptz;
+
+-- create user-scoped table: following_events
+-- follow/unfollow changes detected by following syncs
+create table if not exists following_events (
+    id bigserial primary key,
+    user_id uuid not null,
+    x_author_id bigint not null references authors (x_author_id) on delete cascade,
+    event text not null check (event in ('follow','unfollow')),
+    run_id char(26) references ingest_runs (id) on delete set null,
+    occurred_at timestamptz not null default now()
+);
+-- create index for following_events on (user_id, occurred_at desc)
+create index if not exists idx_following_events_user_occurred on following_events (user_id, occurred_at desc);
+
+-- following_events rls
+alter table following_events enable row level security;
+create policy user_isolation_following_events on following_events
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+-- end of migration
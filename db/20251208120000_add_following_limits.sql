@@ -0,0 +1,18 @@
+-- migration: configurable following limit with prioritization
+-- timestamp: 2025-12-08 12:00:00 utc
+-- purpose: the 150-author limit was hard-coded and kept whichever authors the api returned first.
+-- includes: plan and following_limit columns on users, pinned and excluded_at columns on user_following.
+-- notes: following_limit overrides the plan's limit when set. authors over the limit are marked with
+--        excluded_at during following sync and are not ingested; pinned authors are kept first.
+
+-- per-user plan and optional limit override; null following_limit means the plan's limit applies
+alter table users
+    add column if not exists plan text not null default 'free' check (plan in ('free','pro')),
+    add column if not exists following_limit integer check (following_limit > 0);
+
+-- user-pinned priority and exclusion marker; null excluded_at means the author is ingested
+alter table user_following
+    add column if not exists pinned boolean not null default false,
+    add column if not exists excluded_at timestamptz;
+
+-- end of migration
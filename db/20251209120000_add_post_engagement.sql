@@ -0,0 +1,36 @@
+-- migration: store tweet engagement metrics and their history
+-- timestamp: 2025-12-09 12:00:00 utc
+-- purpose: engagement metrics returned by twitterapi.io were dropped on ingest.
+-- includes: engagement columns on posts, post_engagement time series table, published_at index on posts.
+-- notes: post_engagement is global (like authors): metrics belong to the tweet, not to the user who
+--        ingested it, so it has no user_id and no rls. posts columns hold the latest snapshot.
+
+-- latest engagement metrics; metrics_updated_at is null until the first refresh after ingest
+alter table posts
+    add column if not exists like_count bigint not null default 0 check (like_count >= 0),
+    add column if not exists retweet_count bigint not null default 0 check (retweet_count >= 0),
+    add column if not exists reply_count bigint not null default 0 check (reply_count >= 0),
+    add column if not exists quote_count bigint not null default 0 check (quote_count >= 0),
+    add column if not exists view_count bigint not null default 0 check (view_count >= 0),
+    add column if not exists bookmark_count bigint not null default 0 check (bookmark_count >= 0),
+    add column if not exists metrics_updated_at timestamptz;
+
+-- create index for posts on (published_at desc)
+-- the engagement refresher selects recent posts across all users
+create index if not exists idx_posts_published on posts (published_at desc);
+
+-- create global table: post_engagement
+-- one row per engagement refresh of a tweet
+create table if not exists post_engagement (
+    x_post_id bigint not null check (x_post_id > 0),
+    captured_at timestamptz not null default now(),
+    like_count bigint not null check (like_count >= 0),
+    retweet_count bigint not null check (retweet_count >= 0),
+    reply_count bigint not null check (reply_count >= 0),
+    quote_count bigint not null check (quote_count >= 0),
+    view_count bigint not null check (view_count >= 0),
+    bookmark_count bigint not null check (bookmark_count >= 0),
+    primary key (x_post_id, captured_at)
+);
+
+-- end of migration
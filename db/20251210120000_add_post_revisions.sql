@@ -0,0 +1,26 @@
+-- migration: detect tweet edits and deletions
+-- timestamp: 2025-12-10 12:00:00 utc
+-- purpose: posts were never updated after ingest, so edits and deletions on x were not reflected.
+-- includes: raw_text and deleted_at columns on posts, post_revisions table.
+-- notes: raw_text is the tweet text as returned by x, without the media descriptions merged into text.
+--        it is null for posts ingested before this migration; they are adopted on their next re-fetch.
+--        post_revisions is global (like post_engagement): an edit belongs to the tweet, not to a user.
+
+-- tweet text before media descriptions, and the time the tweet was found deleted upstream
+alter table posts
+    add column if not exists raw_text text,
+    add column if not exists deleted_at timestamptz;
+
+-- create global table: post_revisions
+-- one row per detected edit, holding the text before and after the edit
+create table if not exists post_revisions (
+    id bigserial primary key,
+    x_post_id bigint not null check (x_post_id > 0),
+    previous_text text not null,
+    text text not null,
+    detected_at timestamptz not null default now()
+);
+-- create index for post_revisions on (x_post_id, detected_at desc)
+create index if not exists idx_post_revisions_post_detected on post_revisions (x_post_id, detected_at desc);
+
+-- end of migration
@@ -0,0 +1,39 @@
+-- migration: reconstruct self-reply threads
+-- timestamp: 2025-12-11 12:00:00 utc
+-- purpose: self-replies were stored with their conversation_id but presented as unrelated posts.
+-- includes: in_reply_to_id column on posts, threads view.
+-- notes: in_reply_to_id is only set for self-replies, i.e. when the parent tweet has the same author.
+--        it is null for posts ingested before this migration, so their threads are never reported incomplete.
+--        threads is a security_invoker view (postgres 15+) so the rls policy of posts applies to its callers.
+
+-- parent tweet of a self-reply
+alter table posts
+    add column if not exists in_reply_to_id bigint check (in_reply_to_id > 0);
+
+-- create view: threads
+-- one row per author per conversation of a user; a thread of one post is a regular post.
+-- complete is false while a self-reply's parent has not been ingested
+create or replace view threads
+with (security_invoker = true) as
+select
+    p.user_id,
+    p.conversation_id,
+    p.author_id,
+    count(*) as post_count,
+    array_agg(p.x_post_id order by p.published_at, p.x_post_id) as post_ids,
+    min(p.published_at) as started_at,
+    max(p.published_at) as last_published_at,
+    max(p.ingested_at) as last_ingested_at,
+    bool_and(
+        p.in_reply_to_id is null
+        or exists (
+            select 1 from posts parent
+            where parent.user_id = p.user_id and parent.x_post_id = p.in_reply_to_id
+        )
+    ) as complete
+from posts p
+where p.conversation_id > 0
+  and p.deleted_at is null
+group by p.user_id, p.conversation_id, p.author_id;
+
+-- end of migration
@@ -0,0 +1,33 @@
+-- migration: per-user ingest policy
+-- timestamp: 2025-12-12 12:00:00 utc
+-- purpose: retweets, quote tweets and replies to other authors were always dropped on ingest.
+-- includes: ingest_policies table with rls, kind/reposted_by_id/quoted_post_id columns on posts.
+-- notes: users without an ingest_policies row get the defaults below, which match the previous behaviour.
+--        retweeted and quoted tweets are stored under their original author:
+--        a retweet is stored as the original tweet (kind 'retweet') with the followed author in reposted_by_id;
+--        a quote tweet (kind 'quote') points to the quoted tweet, stored as kind 'quoted'.
+
+-- create user-scoped table: ingest_policies
+-- languages holds x language codes (e.g. 'en', 'pl'); an empty list allows every language
+create table if not exists ingest_policies (
+    user_id uuid primary key,
+    include_retweets boolean not null default false,
+    include_quotes boolean not null default false,
+    include_replies boolean not null default false,
+    languages text[] not null default '{}',
+    updated_at timestamptz not null default now()
+);
+
+-- ingest_policies rls
+alter table ingest_policies enable row level security;
+create policy user_isolation_ingest_policies on ingest_policies
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+-- how a post entered the feed; existing posts are original posts or self-replies
+alter table posts
+    add column if not exists kind text not null default 'original'
+        check (kind in ('original','reply','quote','retweet','quoted')),
+    add column if not exists reposted_by_id bigint references authors (x_author_id) on delete set null,
+    add column if not exists quoted_post_id bigint check (quoted_post_id > 0);
+
+-- end of migration
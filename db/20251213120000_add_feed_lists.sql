@@ -0,0 +1,63 @@
+-- migration: x lists as feed sources
+-- timestamp: 2025-12-13 12:00:00 utc
+-- purpose: ingestion only read the user's followings; curated x lists could not be used as a source.
+-- includes: feed_lists, feed_list_members and post_lists tables with rls, list_id column on qa_messages.
+-- notes: list members are synced during the following phase and merged into the authors ingested for the user.
+--        members are not user_following rows, so the following limit and unfollow reconciliation do not apply to them.
+--        removing a list drops its members and post tags; the posts themselves are kept.
+
+-- create user-scoped table: feed_lists
+-- x lists registered by the user as an additional source of authors
+create table if not exists feed_lists (
+    user_id uuid not null,
+    x_list_id bigint not null check (x_list_id > 0),
+    name text,
+    created_at timestamptz not null default now(),
+    last_synced_at timestamptz,
+    constraint pk_feed_lists primary key (user_id, x_list_id)
+);
+
+-- create user-scoped table: feed_list_members
+-- members of a registered list as of its last sync
+create table if not exists feed_list_members (
+    user_id uuid not null,
+    x_list_id bigint not null,
+    x_author_id bigint not null references authors (x_author_id) on delete cascade,
+    added_at timestamptz not null default now(),
+    constraint pk_feed_list_members primary key (user_id, x_list_id, x_author_id),
+    constraint fk_feed_list_members_lists foreign key (user_id, x_list_id) references feed_lists (user_id, x_list_id) on delete cascade
+);
+-- create index for feed_list_members on (user_id, x_author_id)
+create index if not exists idx_feed_list_members_user_author on feed_list_members (user_id, x_author_id);
+
+-- create user-scoped junction table: post_lists
+-- lists a post was ingested through; a post of an author in several lists is tagged with each of them
+create table if not exists post_lists (
+    user_id uuid not null,
+    x_post_id bigint not null,
+    x_list_id bigint not null,
+    constraint pk_post_lists primary key (user_id, x_list_id, x_post_id),
+    constraint fk_post_lists_posts foreign key (user_id, x_post_id) references posts (user_id, x_post_id) on delete cascade,
+    constraint fk_post_lists_lists foreign key (user_id, x_list_id) references feed_lists (user_id, x_list_id) on delete cascade
+);
+-- create index for post_lists on (user_id, x_post_id)
+create index if not exists idx_post_lists_user_post on post_lists (user_id, x_post_id);
+
+-- list a question was scoped to; null means the whole feed
+alter table qa_messages
+    add column if not exists list_id bigint check (list_id > 0);
+
+-- feed_lists, feed_list_members and post_lists rls
+alter table feed_lists enable row level security;
+create policy user_isolation_feed_lists on feed_lists
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+alter table feed_list_members enable row level security;
+create policy user_isolation_feed_list_members on feed_list_members
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+alter table post_lists enable row level security;
+create policy user_isolation_post_lists on post_lists
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+-- end of migration
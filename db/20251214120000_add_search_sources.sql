@@ -0,0 +1,43 @@
+-- migration: saved search queries as feed sources
+-- timestamp: 2025-12-14 12:00:00 utc
+-- purpose: only followed and listed authors were ingested; users could not follow a topic.
+-- includes: search_sources and post_search_sources tables with rls.
+-- notes: every ingestion run executes each saved query through the advanced search api after the authors' tweets.
+--        last_tweet_id holds the newest result seen, so a regular run only reads results newer than it.
+--        removing a search source drops its post tags; the posts themselves are kept.
+
+-- create user-scoped table: search_sources
+-- advanced search queries registered by the user, e.g. "rust async" min_faves:50
+create table if not exists search_sources (
+    id text primary key,
+    user_id uuid not null,
+    query text not null check (length(query) between 1 and 512),
+    name text,
+    created_at timestamptz not null default now(),
+    last_run_at timestamptz,
+    last_tweet_id bigint,
+    constraint uq_search_sources_user_query unique (user_id, query)
+);
+
+-- create user-scoped junction table: post_search_sources
+-- search sources a post was found by
+create table if not exists post_search_sources (
+    user_id uuid not null,
+    x_post_id bigint not null,
+    search_source_id text not null references search_sources (id) on delete cascade,
+    constraint pk_post_search_sources primary key (user_id, search_source_id, x_post_id),
+    constraint fk_post_search_sources_posts foreign key (user_id, x_post_id) references posts (user_id, x_post_id) on delete cascade
+);
+-- create index for post_search_sources on (user_id, x_post_id)
+create index if not exists idx_post_search_sources_user_post on post_search_sources (user_id, x_post_id);
+
+-- search_sources and post_search_sources rls
+alter table search_sources enable row level security;
+create policy user_isolation_search_sources on search_sources
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+alter table post_search_sources enable row level security;
+create policy user_isolation_post_search_sources on post_search_sources
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+-- end of migration
@@ -0,0 +1,24 @@
+-- migration: mentions of the user's own account
+-- timestamp: 2025-12-15 12:00:00 utc
+-- purpose: posts mentioning the registered x account were never ingested, so users could not ask what people said to them.
+-- includes: include_mentions column on ingest_policies, source column on posts, source column on qa_messages.
+-- notes: mentions are ingested only when include_mentions is set; they run after the authors' tweets and saved searches.
+--        posts.source tells how a post entered the feed; a post found both ways is marked 'mention'.
+--        existing posts all come from the feed.
+
+-- optional mentions stage of the ingest policy
+alter table ingest_policies
+    add column if not exists include_mentions boolean not null default false;
+
+-- how a post entered the feed: 'feed' for followed, listed and searched authors, 'mention' for mentions of the user
+alter table posts
+    add column if not exists source text not null default 'feed'
+        check (source in ('feed','mention'));
+-- create partial index for mentions on (user_id, published_at)
+create index if not exists idx_posts_user_mentions on posts (user_id, published_at desc) where source = 'mention';
+
+-- source a question was scoped to; null means every post
+alter table qa_messages
+    add column if not exists source text check (source in ('feed','mention'));
+
+-- end of migration
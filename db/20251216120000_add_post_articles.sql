@@ -0,0 +1,40 @@
+-- migration: long-form x articles
+-- timestamp: 2025-12-16 12:00:00 utc
+-- purpose: only the teaser tweet of an x article was stored, so the article itself never reached q&a.
+-- includes: post_articles and post_article_chunks tables with rls.
+-- notes: an article is fetched once, when the tweet linking to it is first ingested, and stored in order as chunks.
+--        chunks are kept small enough that q&a can include the first ones of an article without the whole body.
+
+-- create user-scoped table: post_articles
+-- x article linked from a post
+create table if not exists post_articles (
+    user_id uuid not null,
+    x_post_id bigint not null,
+    title text,
+    chunk_count integer not null check (chunk_count >= 0),
+    fetched_at timestamptz not null default now(),
+    constraint pk_post_articles primary key (user_id, x_post_id),
+    constraint fk_post_articles_posts foreign key (user_id, x_post_id) references posts (user_id, x_post_id) on delete cascade
+);
+
+-- create user-scoped table: post_article_chunks
+-- article body split into ordered chunks
+create table if not exists post_article_chunks (
+    user_id uuid not null,
+    x_post_id bigint not null,
+    chunk_index integer not null check (chunk_index >= 0),
+    text text not null,
+    constraint pk_post_article_chunks primary key (user_id, x_post_id, chunk_index),
+    constraint fk_post_article_chunks_articles foreign key (user_id, x_post_id) references post_articles (user_id, x_post_id) on delete cascade
+);
+
+-- post_articles and post_article_chunks rls
+alter table post_articles enable row level security;
+create policy user_isolation_post_articles on post_articles
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+alter table post_article_chunks enable row level security;
+create policy user_isolation_post_article_chunks on post_article_chunks
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+-- end of migration
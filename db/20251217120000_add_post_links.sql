@@ -0,0 +1,29 @@
+-- migration: linked page summaries
+-- timestamp: 2025-12-17 12:00:00 utc
+-- purpose: posts that only share a link gave q&a nothing but the url.
+-- includes: post_links table with rls.
+-- notes: outbound links of a newly stored post are fetched once during ingestion; x and t.co links are not fetched.
+--        content holds the readable text of the page (truncated), summary the optional llm summary of it.
+--        links that could not be fetched are not stored.
+
+-- create user-scoped table: post_links
+-- readable content of pages linked from a post
+create table if not exists post_links (
+    user_id uuid not null,
+    x_post_id bigint not null,
+    url text not null,
+    final_url text not null,
+    title text,
+    summary text,
+    content text not null,
+    fetched_at timestamptz not null default now(),
+    constraint pk_post_links primary key (user_id, x_post_id, url),
+    constraint fk_post_links_posts foreign key (user_id, x_post_id) references posts (user_id, x_post_id) on delete cascade
+);
+
+-- post_links rls
+alter table post_links enable row level security;
+create policy user_isolation_post_links on post_links
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+-- end of migration
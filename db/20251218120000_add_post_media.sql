@@ -0,0 +1,42 @@
+-- migration: media descriptions stored apart from post text
+-- timestamp: 2025-12-18 12:00:00 utc
+-- purpose: image descriptions were appended to posts.text, mixing model output into the author's words
+--          and making it impossible to describe the media again with a better model.
+-- includes: post_media table with rls, indexes for description reuse and reprocessing.
+-- notes: posts.text of new posts holds the tweet text only; media of a post is stored as one row per image or video.
+--        url_hash (sha256 of the media url) lets a description made for one user be reused for another.
+--        pending and failed rows (up to 3 attempts) are picked up by the media reprocessor, as are rows described
+--        by an older model when re-describing is enabled.
+--        posts ingested before this migration keep their appended descriptions; their media urls were not stored.
+
+-- create user-scoped table: post_media
+-- images and videos attached to a post with their generated descriptions
+create table if not exists post_media (
+    user_id uuid not null,
+    x_post_id bigint not null,
+    media_index integer not null check (media_index >= 0),
+    media_type text not null check (media_type in ('image', 'video')),
+    url text not null,
+    url_hash text not null,
+    duration_ms integer,
+    description text,
+    model text,
+    status text not null default 'pending' check (status in ('pending', 'described', 'failed', 'skipped')),
+    error text,
+    attempts integer not null default 0,
+    described_at timestamptz,
+    created_at timestamptz not null default now(),
+    constraint pk_post_media primary key (user_id, x_post_id, media_index),
+    constraint fk_post_media_posts foreign key (user_id, x_post_id) references posts (user_id, x_post_id) on delete cascade
+);
+-- create index for post_media on url_hash
+create index if not exists idx_post_media_url_hash on post_media (url_hash);
+-- create partial index for post_media awaiting a description
+create index if not exists idx_post_media_reprocess on post_media (created_at) where status in ('pending', 'failed');
+
+-- post_media rls
+alter table post_media enable row level security;
+create policy user_isolation_post_media on post_media
+    using (user_id = current_setting('app.user_id', true)::uuid);
+
+-- end of migration
@@ -0,0 +1,18 @@
+-- migration: requeue videos skipped before transcription existed
+-- timestamp: 2025-12-19 12:00:00 utc
+-- purpose: video transcription was a stub, so every stored video was marked skipped with a
+--          "not yet implemented" error and would never be picked up by the media reprocessor.
+-- includes: update of post_media only; no schema change.
+-- notes: videos skipped for exceeding the duration or size limits stay skipped.
+--        requeued rows are transcribed by the media reprocessor once a transcription api is configured.
+
+-- mark stub-skipped videos pending again
+update post_media
+set status = 'pending',
+    error = null,
+    attempts = 0
+where media_type = 'video'
+  and status = 'skipped'
+  and error like '%not yet implemented%';
+
+-- end of migration
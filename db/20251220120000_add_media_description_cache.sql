@@ -0,0 +1,23 @@
+-- migration: content-addressed cache of image descriptions
+-- timestamp: 2025-12-20 12:00:00 utc
+-- purpose: the same meme or chart posted by several authors was described again for every post,
+--          and a new user's backfill described every image again.
+-- includes: media_description_cache table, index for purging expired entries.
+-- notes: media_description_cache is a system table shared by all users, so it has no row level security
+--        (same as authors); it holds descriptions of public media only.
+--        cache_key is 'url:' plus the sha256 of the normalized image url, or 'sha256:' plus the sha256 of the
+--        image bytes when the image was downloaded. entries are per model and expire after a ttl.
+
+-- create system table: media_description_cache
+create table if not exists media_description_cache (
+    cache_key text not null,
+    model text not null,
+    description text not null,
+    created_at timestamptz not null default now(),
+    expires_at timestamptz not null,
+    constraint pk_media_description_cache primary key (cache_key, model)
+);
+-- create index for media_description_cache on expires_at
+create index if not exists idx_media_description_cache_expires on media_description_cache (expires_at);
+
+-- end of migration
@@ -0,0 +1,16 @@
+-- migration: resumable catch-up of author timelines
+-- timestamp: 2025-12-21 12:00:00 utc
+-- purpose: a regular ingest reads at most 10 pages per author; when it stopped before the watermark the watermark
+--          still moved to the newest tweet, so the tweets between the last page read and the old watermark were never fetched.
+-- includes: resume columns on author_watermarks.
+-- notes: last_tweet_id now only advances once paging reached it. until then resume_cursor is the timeline cursor where
+--        the last run stopped and pending_tweet_id/pending_tweet_at the newest tweet fetched by the runs catching up.
+--        the next run reads the new tweets on top, then jumps to resume_cursor once it reaches pending_tweet_id.
+
+-- add resume state to author_watermarks
+alter table author_watermarks
+    add column if not exists resume_cursor text,
+    add column if not exists pending_tweet_id bigint,
+    add column if not exists pending_tweet_at timestamptz;
+
+-- end of migration
@@ -0,0 +1,23 @@
+-- migration: rank followed authors by real tweet activity and rotate excluded authors back in
+-- timestamp: 2025-12-22 12:00:00 utc
+-- purpose: authors.last_seen_at was set to the account's creation date when an author was stored, so the following
+--          limit ranked old accounts as the most active; and since it was only refreshed for ingested authors,
+--          an author once excluded by the limit could never rank back in.
+-- includes: sampled_at column on user_following, reset of authors.last_seen_at from stored posts.
+-- notes: last_seen_at is now null until a tweet of the author is ingested. each following sync hands the last few
+--        slots under the limit to the authors over it, least recently sampled first; sampled_at records when an
+--        author last got such a slot, so excluded authors take turns and re-enter once their tweets rank them in.
+
+-- last time the author got one of the sampled slots under the user's following limit
+alter table user_following
+    add column if not exists sampled_at timestamptz;
+
+-- replace account creation dates with the newest stored tweet; authors without posts have no known activity
+update authors
+set last_seen_at = (
+    select max(p.published_at)
+    from posts p
+    where p.author_id = authors.x_author_id
+);
+
+-- end of migration
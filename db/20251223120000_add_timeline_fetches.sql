@@ -0,0 +1,30 @@
+-- migration: shared timeline fetches planned per cycle
+-- timestamp: 2025-12-23 12:00:00 utc
+-- purpose: timelines of authors followed by several users were shared through an in-memory cache of each process,
+--          so replicas fetched them again and users synced more than 30 minutes apart never shared a fetch.
+-- includes: timeline_fetches table, index for purging past cycles.
+-- notes: timeline_fetches is a system table shared by all users, so it has no row level security (same as authors);
+--        it holds public timeline pages only. a row is one page of an author's timeline (page_cursor '' is the
+--        first page) fetched once per cycle, a fixed window of time aligned to the epoch (1 hour by default).
+--        the run that inserts the row claims the fetch until claimed_until; runs of other followers wait for it
+--        and read response, or get the error when it failed. an expired claim is taken over by the next run.
+--        rows of past cycles are deleted.
+
+-- create system table: timeline_fetches
+create table if not exists timeline_fetches (
+    x_author_id bigint not null,
+    page_cursor text not null default '',
+    cycle_start timestamptz not null,
+    status text not null default 'fetching' check (status in ('fetching','done','failed')),
+    claim_id char(26) not null,
+    claimed_until timestamptz not null,
+    response jsonb,
+    error text,
+    error_status integer,
+    fetched_at timestamptz,
+    constraint pk_timeline_fetches primary key (x_author_id, page_cursor, cycle_start)
+);
+-- create index for timeline_fetches on cycle_start
+create index if not exists idx_timeline_fetches_cycle on timeline_fetches (cycle_start);
+
+-- end of migration
@@ -0,0 +1,18 @@
+-- migration: cap the authors ingested through x lists per plan
+-- timestamp: 2025-12-24 12:00:00 utc
+-- purpose: list members were fetched on every run without any limit, so up to 10 lists of 1000 members each
+--          bypassed the user's following limit and spent api quota on authors the plan does not cover.
+-- includes: excluded_at and sampled_at columns on feed_list_members.
+-- notes: authors that are only listed (not followed within the following limit) are ranked by activity like
+--        followings, and the ones over the plan's list author limit are marked excluded on every member row.
+--        the last few slots under the limit rotate among the excluded authors, least recently sampled first.
+
+-- when the author was first left out by the user's list author limit; null while its tweets are ingested
+alter table feed_list_members
+    add column if not exists excluded_at timestamptz;
+
+-- last time the author got one of the sampled slots under the user's list author limit
+alter table feed_list_members
+    add column if not exists sampled_at timestamptz;
+
+-- end of migration